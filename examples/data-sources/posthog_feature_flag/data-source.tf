@@ -0,0 +1,26 @@
+data "posthog_feature_flag" "checkout_button" {
+  project_id = "1234"
+  key        = "checkout-button-color"
+
+  # Evaluate the flag locally for a couple of test users
+  evaluate = [
+    {
+      distinct_id       = "user-1"
+      person_properties = { email = "alice@example.com" }
+    },
+    {
+      distinct_id = "user-2"
+    },
+  ]
+}
+
+output "checkout_button_variants" {
+  value = [for v in data.posthog_feature_flag.checkout_button.variants : v.key]
+}
+
+check "internal_users_get_the_flag" {
+  assert {
+    condition     = data.posthog_feature_flag.checkout_button.evaluate[0].enabled == true
+    error_message = "Internal users should have the checkout button flag enabled."
+  }
+}
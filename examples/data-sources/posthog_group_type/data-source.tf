@@ -0,0 +1,16 @@
+data "posthog_group_type" "company" {
+  project_id = "1234"
+  group_type = "company"
+}
+
+resource "posthog_feature_flag" "beta" {
+  project_id                   = "1234"
+  key                          = "beta"
+  aggregation_group_type_index = data.posthog_group_type.company.group_type_index
+
+  release_conditions = [
+    {
+      rollout_percentage = 10
+    },
+  ]
+}
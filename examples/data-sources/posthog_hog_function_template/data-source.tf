@@ -0,0 +1,21 @@
+data "posthog_hog_function_template" "slack" {
+  project_id = "1234"
+  id         = "template-slack"
+}
+
+output "slack_required_inputs" {
+  value = [for i in data.posthog_hog_function_template.slack.inputs_schema : i.key if i.required]
+}
+
+# Create a destination from the template, with the template filters
+resource "posthog_hog_function" "slack" {
+  project_id  = "1234"
+  type        = data.posthog_hog_function_template.slack.type
+  name        = "Slack notifications"
+  template_id = data.posthog_hog_function_template.slack.id
+  filters     = data.posthog_hog_function_template.slack.filters
+
+  inputs = {
+    channel = jsonencode("#alerts")
+  }
+}
@@ -0,0 +1,19 @@
+# Slack workspace connected from the PostHog web UI
+data "posthog_integration" "slack" {
+  project_id   = "1234"
+  kind         = "slack"
+  display_name = "Example Inc."
+}
+
+# Posts new signups to Slack
+resource "posthog_hog_function" "signup_slack" {
+  project_id  = "1234"
+  type        = "destination"
+  name        = "Signups on Slack"
+  template_id = "template-slack"
+
+  inputs = {
+    slack_workspace = jsonencode(tonumber(data.posthog_integration.slack.id))
+    channel         = jsonencode("C0123456789")
+  }
+}
@@ -0,0 +1,9 @@
+# Access controls can be imported by specifying the ID of the project (found in
+# the project settings page next to the API key), the resource type, the ID of
+# the resource when the access control targets a single resource, and the ID of
+# the role or the UUID of the user.
+#
+# The syntax is PROJECT_ID/RESOURCE[/RESOURCE_ID]/role/ROLE_ID or
+# PROJECT_ID/RESOURCE[/RESOURCE_ID]/user/USER_UUID
+terraform import posthog_access_control.engineers_flags 1234/feature_flag/role/0190fbb8-2a33-0000-b5bd-5b4bd8a3c3f4
+terraform import posthog_access_control.jane_dashboard 1234/dashboard/42/user/0190fbb8-4c1d-0000-9a3e-0e8b0d5f9a11
@@ -0,0 +1,38 @@
+resource "posthog_project" "test" {
+  name                  = "test"
+  enable_access_control = true
+}
+
+resource "posthog_role" "engineers" {
+  name = "Engineers"
+}
+
+resource "posthog_dashboard" "kpis" {
+  project_id = posthog_project.test.id
+  name       = "KPIs"
+}
+
+# Engineers are members of the project
+resource "posthog_access_control" "engineers_project" {
+  project_id   = posthog_project.test.id
+  resource     = "project"
+  role_id      = posthog_role.engineers.id
+  access_level = "member"
+}
+
+# Engineers can edit all the feature flags of the project
+resource "posthog_access_control" "engineers_flags" {
+  project_id   = posthog_project.test.id
+  resource     = "feature_flag"
+  role_id      = posthog_role.engineers.id
+  access_level = "editor"
+}
+
+# Engineers can only view the KPIs dashboard
+resource "posthog_access_control" "engineers_kpis" {
+  project_id   = posthog_project.test.id
+  resource     = "dashboard"
+  resource_id  = posthog_dashboard.kpis.id
+  role_id      = posthog_role.engineers.id
+  access_level = "viewer"
+}
@@ -0,0 +1,43 @@
+resource "posthog_project" "test" {
+  name = "test"
+}
+
+resource "posthog_insight" "signups" {
+  project_id = posthog_project.test.id
+  name       = "Signups per hour"
+
+  trends_query = {
+    series = [
+      { event = "signed_up" },
+    ]
+    interval  = "hour"
+    date_from = "-24h"
+  }
+}
+
+resource "posthog_alert" "low_signups" {
+  project_id           = posthog_project.test.id
+  name                 = "Signups dropped"
+  insight_id           = posthog_insight.signups.id
+  calculation_interval = "hourly"
+  subscribed_user_ids  = ["1234"]
+
+  threshold = {
+    lower = 10
+  }
+}
+
+resource "posthog_alert" "signups_spike" {
+  project_id             = posthog_project.test.id
+  name                   = "Signups spike"
+  insight_id             = posthog_insight.signups.id
+  condition              = "relative_increase"
+  calculation_interval   = "hourly"
+  check_ongoing_interval = true
+  subscribed_user_ids    = ["1234"]
+
+  threshold = {
+    type  = "percentage"
+    upper = 0.5
+  }
+}
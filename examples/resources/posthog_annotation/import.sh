@@ -0,0 +1,5 @@
+# Annotations can be imported by specifying their ID and the ID of the project
+# (found in the project settings page next to the API key).
+#
+# The syntax is PROJECT_ID/ANNOTATION_ID
+terraform import posthog_annotation.test 1234/5678
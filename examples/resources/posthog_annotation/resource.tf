@@ -0,0 +1,25 @@
+resource "posthog_project" "test" {
+  name = "test"
+}
+
+variable "release_version" {
+  type = string
+}
+
+# Recorded by the deploy pipeline
+resource "posthog_annotation" "release" {
+  project_id  = posthog_project.test.id
+  content     = "${var.release_version} released"
+  date_marker = plantimestamp()
+
+  lifecycle {
+    ignore_changes = [date_marker]
+  }
+}
+
+resource "posthog_annotation" "pricing_change" {
+  project_id  = posthog_project.test.id
+  content     = "New pricing"
+  date_marker = "2024-03-01T09:00:00Z"
+  scope       = "organization"
+}
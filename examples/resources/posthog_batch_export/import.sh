@@ -0,0 +1,5 @@
+# Batch exports can be imported by specifying their ID and the ID of the project
+# (found in the project settings page next to the API key).
+#
+# The syntax is PROJECT_ID/BATCH_EXPORT_ID
+terraform import posthog_batch_export.test 1234/018cca2c-9b1e-0000-8a0a-44d67bd2d0a1
@@ -0,0 +1,6 @@
+# Cohorts can be imported by specifying their ID (found for example in the URL
+# when editing them in the web UI) and the ID of the project (found in the
+# project settings page next to the API key).
+#
+# The syntax is PROJECT_ID/COHORT_ID
+terraform import posthog_cohort.test 1234/5678
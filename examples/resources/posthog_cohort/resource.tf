@@ -0,0 +1,48 @@
+resource "posthog_project" "test" {
+  name = "test"
+}
+
+resource "posthog_cohort" "beta_testers" {
+  project_id = posthog_project.test.id
+  name       = "Beta testers"
+  is_static  = true
+
+  distinct_ids = ["user-1", "user-2", "user-3"]
+}
+
+resource "posthog_cohort" "vip" {
+  project_id = posthog_project.test.id
+  name       = "VIP customers"
+  is_static  = true
+
+  # Distinct IDs in the first column, members are uploaded again when the
+  # content of the file changes
+  csv_file = "${path.module}/vip.csv"
+}
+
+# Paying EU customers who purchased something in the last 30 days, or beta
+# testers
+resource "posthog_cohort" "paying_eu_customers" {
+  project_id  = posthog_project.test.id
+  name        = "Paying EU customers"
+  description = "Customers in the EU with an active subscription"
+  match       = "any"
+
+  groups = [
+    {
+      match = "all"
+      properties = [
+        { key = "$geoip_continent_code", values = ["EU"] },
+        { key = "plan", operator = "is_not", values = ["free"] },
+      ]
+      performed_events = [
+        { event = "purchase", time_value = 30, time_interval = "day" },
+      ]
+    },
+    {
+      cohorts = [
+        { cohort_id = posthog_cohort.beta_testers.id },
+      ]
+    },
+  ]
+}
@@ -0,0 +1,6 @@
+# Dashboards can be imported by specifying their ID (found for example in the
+# URL when viewing them in the web UI) and the ID of the project (found in the
+# project settings page next to the API key).
+#
+# The syntax is PROJECT_ID/DASHBOARD_ID
+terraform import posthog_dashboard.test 1234/5678
@@ -0,0 +1,33 @@
+resource "posthog_project" "test" {
+  name = "test"
+}
+
+variable "signups_insight_id" {
+  type = string
+}
+
+resource "posthog_dashboard" "growth" {
+  project_id  = posthog_project.test.id
+  name        = "Growth"
+  description = "Weekly growth metrics"
+  tags        = ["growth"]
+  pinned      = true
+
+  tiles = [
+    {
+      text = "## Signups\nNew users over the last 30 days"
+      layouts = {
+        sm = { x = 0, y = 0, w = 12, h = 2 }
+      }
+    },
+    {
+      insight_id = var.signups_insight_id
+      layouts = {
+        sm = { x = 0, y = 2, w = 6, h = 5 }
+      }
+    },
+  ]
+
+  # Let people rearrange the tiles in the web UI
+  ignore_layout_changes = true
+}
@@ -0,0 +1,15 @@
+resource "posthog_feature_flag" "ai_summaries" {
+  project_id         = "1234"
+  key                = "ai-summaries"
+  name               = "AI summaries"
+  rollout_percentage = 0
+}
+
+resource "posthog_early_access_feature" "ai_summaries" {
+  project_id        = "1234"
+  name              = "AI summaries"
+  description       = "Get a summary of your week every Monday"
+  stage             = "beta"
+  documentation_url = "https://example.com/docs/ai-summaries"
+  feature_flag_id   = posthog_feature_flag.ai_summaries.id
+}
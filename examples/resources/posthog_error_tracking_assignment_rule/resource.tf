@@ -0,0 +1,35 @@
+resource "posthog_role" "payments" {
+  name = "Payments team"
+}
+
+resource "posthog_organization_membership" "jane" {
+  email = "jane@example.com"
+  level = "member"
+}
+
+# Issues raised from the checkout code go to the payments team
+resource "posthog_error_tracking_assignment_rule" "checkout" {
+  project_id = "1234"
+
+  filters = {
+    properties = [
+      { key = "$exception_sources", operator = "icontains", values = ["src/checkout/"] },
+    ]
+  }
+
+  assignee_role_id = posthog_role.payments.id
+}
+
+resource "posthog_error_tracking_assignment_rule" "search" {
+  project_id = "1234"
+
+  filters = {
+    match = "any"
+    properties = [
+      { key = "$exception_types", values = ["SearchError"] },
+      { key = "$pathname", operator = "icontains", values = ["/search"] },
+    ]
+  }
+
+  assignee_user_uuid = posthog_organization_membership.jane.id
+}
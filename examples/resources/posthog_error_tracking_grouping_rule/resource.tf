@@ -0,0 +1,13 @@
+# All the network errors end up in a single issue
+resource "posthog_error_tracking_grouping_rule" "network_errors" {
+  project_id  = "1234"
+  description = "Network errors"
+
+  filters = {
+    match = "any"
+    properties = [
+      { key = "$exception_types", values = ["NetworkError"] },
+      { key = "$exception_values", operator = "icontains", values = ["Failed to fetch"] },
+    ]
+  }
+}
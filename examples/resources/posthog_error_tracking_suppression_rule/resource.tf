@@ -0,0 +1,10 @@
+# Ignore the errors raised by browser extensions
+resource "posthog_error_tracking_suppression_rule" "extensions" {
+  project_id = "1234"
+
+  filters = {
+    properties = [
+      { key = "$exception_sources", operator = "regex", values = ["^(chrome|moz)-extension://"] },
+    ]
+  }
+}
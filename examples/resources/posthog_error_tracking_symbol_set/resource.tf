@@ -0,0 +1,12 @@
+variable "release_id" {
+  type = string
+}
+
+# Symbol set of the main bundle of the release, whose chunk ID was injected
+# by `posthog-cli sourcemap inject`
+resource "posthog_error_tracking_symbol_set" "main" {
+  project_id = "1234"
+  chunk_id   = "0195f2b6-c4a8-7d2e-b1a3-5f6e7d8c9b0a"
+  release_id = var.release_id
+  file       = "${path.module}/symbols/main.symbolset"
+}
@@ -0,0 +1,5 @@
+# Event definitions can be imported by specifying the name of the event and the
+# ID of the project (found in the project settings page next to the API key).
+#
+# The syntax is PROJECT_ID/EVENT_NAME
+terraform import posthog_event_definition.signed_up 1234/signed_up
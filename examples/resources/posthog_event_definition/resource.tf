@@ -0,0 +1,17 @@
+resource "posthog_project" "test" {
+  name = "test"
+}
+
+resource "posthog_event_definition" "signed_up" {
+  project_id  = posthog_project.test.id
+  name        = "signed_up"
+  description = "A user created an account"
+  tags        = ["growth"]
+  verified    = true
+}
+
+resource "posthog_event_definition" "debug" {
+  project_id = posthog_project.test.id
+  name       = "debug_ping"
+  hidden     = true
+}
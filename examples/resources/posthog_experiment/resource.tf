@@ -0,0 +1,29 @@
+resource "posthog_project" "test" {
+  name = "test"
+}
+
+resource "posthog_experiment" "checkout" {
+  project_id       = posthog_project.test.id
+  name             = "One page checkout"
+  description      = "Does merging the checkout steps increase conversion?"
+  feature_flag_key = "one-page-checkout"
+
+  variants = [
+    { key = "control", rollout_percentage = 50 },
+    { key = "test", rollout_percentage = 50 },
+  ]
+
+  metrics = [jsonencode({
+    kind        = "ExperimentMetric"
+    metric_type = "funnel"
+    series = [
+      { kind = "EventsNode", event = "checkout started" },
+      { kind = "EventsNode", event = "order placed" },
+    ]
+  })]
+
+  minimum_detectable_effect = 5
+
+  # Launch the experiment, set end_date to conclude it
+  start_date = "2024-06-01T00:00:00Z"
+}
@@ -0,0 +1,6 @@
+# Feature flags can be imported by specifying their ID (found for example in
+# the URL when editing them in the web UI) and the ID of the project (found in
+# the project settings page next to the API key).
+#
+# The syntax is PROJECT_ID/FEATURE_FLAG_ID
+terraform import posthog_feature_flag.test 1234/5678
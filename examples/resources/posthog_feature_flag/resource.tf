@@ -0,0 +1,40 @@
+resource "posthog_project" "test" {
+  name = "test"
+}
+
+# Boolean flag rolled out to half of the users
+resource "posthog_feature_flag" "new_onboarding" {
+  project_id         = posthog_project.test.id
+  key                = "new-onboarding"
+  name               = "New onboarding flow"
+  rollout_percentage = 50
+  payload            = jsonencode({ steps = 3 })
+}
+
+# Multivariate flag targeting EU users, with the beta testers cohort always
+# getting the "blue" variant
+resource "posthog_feature_flag" "checkout_button" {
+  project_id = posthog_project.test.id
+  key        = "checkout-button-color"
+
+  release_conditions = [
+    {
+      cohort_ids = ["1234"]
+      variant    = "blue"
+    },
+    {
+      properties = [
+        { key = "$geoip_continent_code", values = ["EU"] },
+        { key = "email", operator = "icontains", values = ["@example.com"] },
+      ]
+      rollout_percentage = 20
+    },
+  ]
+
+  variants = [
+    { key = "control", rollout_percentage = 50 },
+    { key = "blue", rollout_percentage = 50, payload = jsonencode({ color = "#0000ff" }) },
+  ]
+
+  ensure_experience_continuity = true
+}
@@ -0,0 +1,5 @@
+# Group types can be imported by specifying their name and the ID of the
+# project (found in the project settings page next to the API key).
+#
+# The syntax is PROJECT_ID/GROUP_TYPE
+terraform import posthog_group_type.company 1234/company
@@ -0,0 +1,6 @@
+resource "posthog_group_type" "company" {
+  project_id    = "1234"
+  group_type    = "company"
+  name_singular = "Company"
+  name_plural   = "Companies"
+}
@@ -0,0 +1,5 @@
+# Hog functions can be imported by specifying their ID and the ID of the project
+# (found in the project settings page next to the API key).
+#
+# The syntax is PROJECT_ID/HOG_FUNCTION_ID
+terraform import posthog_hog_function.test 1234/0190fbb8-2a33-0000-b5bd-5b4bd8a3c3f4
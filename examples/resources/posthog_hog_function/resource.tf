@@ -0,0 +1,60 @@
+resource "posthog_project" "test" {
+  name = "test"
+}
+
+resource "posthog_action" "signup" {
+  name                = "User signed up"
+  project_id          = posthog_project.test.id
+  match_custom_events = [{ event = "signed up" }]
+}
+
+variable "webhook_token" {
+  type      = string
+  sensitive = true
+}
+
+# Destination sending signups to a webhook
+resource "posthog_hog_function" "signup_webhook" {
+  project_id = posthog_project.test.id
+  type       = "destination"
+  name       = "Signups webhook"
+
+  hog = <<-EOT
+    fetch(inputs.url, {
+      'method': 'POST',
+      'headers': {'Authorization': f'Bearer {inputs.token}'},
+      'body': {'email': person.properties.email}
+    })
+  EOT
+
+  inputs_schema = [
+    { key = "url", type = "string", label = "Webhook URL", required = true },
+    { key = "token", type = "string", label = "Token", required = true, secret = true },
+  ]
+
+  inputs = {
+    url = jsonencode("https://example.com/hooks/signup")
+  }
+
+  sensitive_inputs = {
+    token = jsonencode(var.webhook_token)
+  }
+
+  filters = {
+    actions              = [{ action_id = posthog_action.signup.id }]
+    filter_test_accounts = true
+  }
+
+  masking = {
+    ttl  = 3600
+    hash = "{person.id}"
+  }
+}
+
+# Transformation created from a template
+resource "posthog_hog_function" "geoip" {
+  project_id  = posthog_project.test.id
+  type        = "transformation"
+  name        = "GeoIP"
+  template_id = "plugin-posthog-plugin-geoip"
+}
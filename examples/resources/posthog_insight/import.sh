@@ -0,0 +1,7 @@
+# Insights can be imported by specifying their numeric ID and the ID of the
+# project (found in the project settings page next to the API key).
+#
+# Imported insights have their query in the query_json attribute.
+#
+# The syntax is PROJECT_ID/INSIGHT_ID
+terraform import posthog_insight.test 1234/5678
@@ -0,0 +1,84 @@
+resource "posthog_project" "test" {
+  name = "test"
+}
+
+resource "posthog_dashboard" "growth" {
+  project_id = posthog_project.test.id
+  name       = "Growth"
+}
+
+resource "posthog_insight" "daily_users" {
+  project_id    = posthog_project.test.id
+  name          = "Daily active users"
+  tags          = ["growth"]
+  dashboard_ids = [posthog_dashboard.growth.id]
+
+  trends_query = {
+    series = [
+      { event = "$pageview", math = "dau", custom_name = "Active users" },
+    ]
+    interval             = "day"
+    date_from            = "-30d"
+    filter_test_accounts = true
+    breakdown            = { property = "$browser" }
+  }
+}
+
+resource "posthog_insight" "signup_funnel" {
+  project_id = posthog_project.test.id
+  name       = "Signup funnel"
+
+  funnels_query = {
+    series = [
+      {
+        event = "$pageview"
+        properties = [
+          { key = "$pathname", values = ["/signup"] },
+        ]
+      },
+      { event = "signed_up" },
+    ]
+    conversion_window      = 1
+    conversion_window_unit = "day"
+  }
+}
+
+resource "posthog_insight" "weekly_retention" {
+  project_id = posthog_project.test.id
+  name       = "Weekly retention"
+
+  retention_query = {
+    target          = { event = "signed_up" }
+    returning       = { event = "$pageview" }
+    retention_type  = "retention_first_time"
+    period          = "Week"
+    total_intervals = 8
+  }
+}
+
+resource "posthog_insight" "top_pages" {
+  project_id = posthog_project.test.id
+  name       = "Top pages"
+
+  hogql_query = <<-EOT
+    SELECT properties.$pathname AS page, count() AS views
+    FROM events
+    WHERE event = '$pageview' AND timestamp > now() - INTERVAL 7 DAY
+    GROUP BY page
+    ORDER BY views DESC
+    LIMIT 10
+  EOT
+}
+
+resource "posthog_insight" "raw" {
+  project_id = posthog_project.test.id
+  name       = "Pageviews (raw query)"
+
+  query_json = jsonencode({
+    kind = "InsightVizNode"
+    source = {
+      kind   = "TrendsQuery"
+      series = [{ kind = "EventsNode", event = "$pageview", name = "$pageview" }]
+    }
+  })
+}
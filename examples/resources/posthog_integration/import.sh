@@ -0,0 +1,5 @@
+# Integrations can be imported by specifying their ID and the ID of the project
+# (found in the project settings page next to the API key).
+#
+# The syntax is PROJECT_ID/INTEGRATION_ID
+terraform import posthog_integration.test 1234/5678
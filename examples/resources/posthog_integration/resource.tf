@@ -0,0 +1,17 @@
+variable "twilio_auth_token" {
+  type      = string
+  sensitive = true
+}
+
+resource "posthog_integration" "twilio" {
+  project_id = "1234"
+  kind       = "twilio"
+
+  config = jsonencode({
+    account_sid = "AC0123456789abcdef0123456789abcdef"
+  })
+
+  sensitive_config = jsonencode({
+    auth_token = var.twilio_auth_token
+  })
+}
@@ -0,0 +1,6 @@
+# Notebooks can be imported by specifying their short ID (as found in their
+# URL) and the ID of the project (found in the project settings page next to
+# the API key). The content of imported notebooks is set in content_json.
+#
+# The syntax is PROJECT_ID/SHORT_ID
+terraform import posthog_notebook.test 1234/aBcD1234
@@ -0,0 +1,48 @@
+resource "posthog_insight" "checkout_errors" {
+  project_id = "1234"
+  name       = "Checkout errors"
+
+  trends_query = {
+    series = [{ event = "checkout_error" }]
+  }
+}
+
+resource "posthog_feature_flag" "new_checkout" {
+  project_id = "1234"
+  key        = "new-checkout"
+}
+
+# Notebook written in Markdown, embedding an insight and a feature flag
+resource "posthog_notebook" "checkout_runbook" {
+  project_id = "1234"
+  title      = "Checkout incident runbook"
+
+  content = <<-EOT
+    Follow these steps when the **checkout error rate** goes up.
+
+    ## Check the error rate
+
+    {{insight ${posthog_insight.checkout_errors.short_id}}}
+
+    ## Roll back
+
+    1. Disable the `new-checkout` flag below
+    2. Post in _#incidents_, see [the incident process](https://example.com/incidents)
+
+    {{feature_flag ${posthog_feature_flag.new_checkout.id}}}
+  EOT
+}
+
+# Notebook defined as a ProseMirror document
+resource "posthog_notebook" "notes" {
+  project_id = "1234"
+  title      = "Notes"
+
+  content_json = jsonencode({
+    type = "doc"
+    content = [
+      { type = "heading", attrs = { level = 1 }, content = [{ type = "text", text = "Notes" }] },
+      { type = "paragraph", content = [{ type = "text", text = "Nothing yet." }] },
+    ]
+  })
+}
@@ -0,0 +1,16 @@
+resource "posthog_project" "test" {
+  name = "test"
+}
+
+resource "posthog_organization_invite" "jane" {
+  email   = "jane@example.com"
+  level   = "member"
+  message = "Welcome aboard!"
+
+  private_project_access = [
+    {
+      project_id = posthog_project.test.id
+      level      = "admin"
+    },
+  ]
+}
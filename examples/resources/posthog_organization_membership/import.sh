@@ -0,0 +1,6 @@
+# Organization memberships can be imported by specifying the UUID of the user
+# and the ID of the organization (or @current for the organization of the API
+# key).
+#
+# The syntax is ORGANIZATION_ID/USER_UUID
+terraform import posthog_organization_membership.test @current/0190fbb8-2a33-0000-b5bd-5b4bd8a3c3f4
@@ -0,0 +1,4 @@
+resource "posthog_organization_membership" "jane" {
+  email = "jane@example.com"
+  level = "admin"
+}
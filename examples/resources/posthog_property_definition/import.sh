@@ -0,0 +1,10 @@
+# Property definitions can be imported by specifying the type and the name of
+# the property, and the ID of the project (found in the project settings page
+# next to the API key).
+#
+# The syntax is PROJECT_ID/TYPE/PROPERTY_NAME, where TYPE is event or person
+terraform import posthog_property_definition.plan 1234/person/plan
+
+# For group properties, the syntax is
+# PROJECT_ID/group/GROUP_TYPE_INDEX/PROPERTY_NAME
+terraform import posthog_property_definition.company_size 1234/group/0/size
@@ -0,0 +1,19 @@
+resource "posthog_project" "test" {
+  name = "test"
+}
+
+resource "posthog_property_definition" "order_amount" {
+  project_id    = posthog_project.test.id
+  name          = "amount"
+  description   = "Amount of the order, in cents"
+  property_type = "Numeric"
+  verified      = true
+}
+
+resource "posthog_property_definition" "plan" {
+  project_id  = posthog_project.test.id
+  name        = "plan"
+  type        = "person"
+  description = "Subscription plan of the user"
+  tags        = ["billing"]
+}
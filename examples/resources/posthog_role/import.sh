@@ -0,0 +1,5 @@
+# Roles can be imported by specifying their ID and the ID of the organization
+# (or @current for the organization of the API key).
+#
+# The syntax is ORGANIZATION_ID/ROLE_ID
+terraform import posthog_role.test @current/0190fbb8-2a33-0000-b5bd-5b4bd8a3c3f4
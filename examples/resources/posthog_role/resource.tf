@@ -0,0 +1,3 @@
+resource "posthog_role" "engineers" {
+  name = "Engineers"
+}
@@ -0,0 +1,6 @@
+# Role memberships can be imported by specifying the ID of the organization (or
+# @current for the organization of the API key), the ID of the role and the
+# UUID of the user.
+#
+# The syntax is ORGANIZATION_ID/ROLE_ID/USER_UUID
+terraform import posthog_role_membership.test @current/0190fbb8-2a33-0000-b5bd-5b4bd8a3c3f4/0190fbb8-4c1d-0000-9a3e-0e8b0d5f9a11
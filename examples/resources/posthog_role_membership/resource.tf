@@ -0,0 +1,13 @@
+resource "posthog_role" "engineers" {
+  name = "Engineers"
+}
+
+resource "posthog_organization_membership" "jane" {
+  email = "jane@example.com"
+  level = "member"
+}
+
+resource "posthog_role_membership" "jane_engineer" {
+  role_id   = posthog_role.engineers.id
+  user_uuid = posthog_organization_membership.jane.id
+}
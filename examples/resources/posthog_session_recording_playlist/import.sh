@@ -0,0 +1,6 @@
+# Session recording playlists can be imported by specifying their short ID (as
+# found in their URL) and the ID of the project (found in the project settings
+# page next to the API key).
+#
+# The syntax is PROJECT_ID/SHORT_ID
+terraform import posthog_session_recording_playlist.test 1234/aBcD1234
@@ -0,0 +1,38 @@
+resource "posthog_session_recording_playlist" "checkout_errors" {
+  project_id  = "1234"
+  name        = "Checkout errors in last 7 days"
+  description = "Sessions of customers hitting an error during checkout"
+  pinned      = true
+
+  filters = {
+    date_from = "-7d"
+
+    events = [
+      {
+        event = "$pageview"
+        properties = [
+          { key = "$current_url", operator = "icontains", values = ["/checkout"] },
+        ]
+      },
+    ]
+
+    console_log_levels = ["error"]
+
+    duration = {
+      seconds = 10
+    }
+
+    filter_test_accounts = true
+  }
+}
+
+# Without a name, PostHog displays a name derived from the filters
+resource "posthog_session_recording_playlist" "enterprise" {
+  project_id = "1234"
+
+  filters = {
+    properties = [
+      { key = "plan", values = ["enterprise"] },
+    ]
+  }
+}
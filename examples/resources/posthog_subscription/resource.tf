@@ -0,0 +1,33 @@
+# Sends the dashboard by email on the first monday of every month
+resource "posthog_subscription" "monthly_report" {
+  project_id   = "1234"
+  dashboard_id = "5678"
+  title        = "Monthly report"
+
+  target_type  = "email"
+  target_value = "ceo@example.com,cfo@example.com"
+
+  frequency  = "monthly"
+  byweekday  = ["monday"]
+  bysetpos   = 1
+  start_date = "2024-01-01T09:00:00Z"
+}
+
+data "posthog_integration" "slack" {
+  project_id = "1234"
+  kind       = "slack"
+}
+
+# Posts the insight to a Slack channel every weekday
+resource "posthog_subscription" "daily_signups" {
+  project_id = "1234"
+  insight_id = "91011"
+
+  target_type    = "slack"
+  target_value   = "C0123456789|#growth"
+  integration_id = data.posthog_integration.slack.id
+
+  frequency  = "weekly"
+  byweekday  = ["monday", "tuesday", "wednesday", "thursday", "friday"]
+  start_date = "2024-01-01T08:00:00+01:00"
+}
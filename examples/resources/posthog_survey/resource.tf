@@ -0,0 +1,95 @@
+resource "posthog_project" "test" {
+  name = "test"
+}
+
+resource "posthog_survey" "nps" {
+  project_id = posthog_project.test.id
+  name       = "NPS"
+  type       = "popover"
+
+  questions = [
+    {
+      type              = "rating"
+      question          = "How likely are you to recommend us to a friend?"
+      display           = "number"
+      scale             = 10
+      lower_bound_label = "Unlikely"
+      upper_bound_label = "Very likely"
+
+      branching = {
+        type = "response_based"
+        response_values = {
+          detractors = "1"
+          passives   = "1"
+          promoters  = "end"
+        }
+      }
+    },
+    {
+      type     = "open"
+      question = "What could we do better?"
+      optional = true
+    },
+  ]
+
+  appearance = {
+    background_color          = "#eeeded"
+    display_thank_you_message = true
+    thank_you_message_header  = "Thanks for your feedback!"
+  }
+
+  conditions = {
+    url              = "/app"
+    url_match_type   = "icontains"
+    wait_period_days = 30
+  }
+
+  responses_limit          = 500
+  iteration_count          = 4
+  iteration_frequency_days = 90
+
+  # Launch the survey, set end_date to stop it
+  start_date = "2024-06-01T00:00:00Z"
+}
+
+resource "posthog_survey" "feedback" {
+  project_id  = posthog_project.test.id
+  name        = "Feedback button"
+  description = "Always available feedback form"
+  type        = "widget"
+
+  questions = [
+    {
+      type     = "single_choice"
+      question = "What is this about?"
+      choices  = ["A bug", "A feature request", "Something else"]
+
+      branching = {
+        type = "response_based"
+        response_values = {
+          "0" = "1"
+          "1" = "2"
+          "2" = "2"
+        }
+      }
+    },
+    {
+      type     = "link"
+      question = "Please report bugs in our issue tracker"
+      link     = "https://example.com/issues"
+
+      branching = {
+        type = "end"
+      }
+    },
+    {
+      type     = "open"
+      question = "Tell us more"
+    },
+  ]
+
+  appearance = {
+    widget_type  = "tab"
+    widget_label = "Feedback"
+  }
+}
@@ -0,0 +1,18 @@
+resource "posthog_warehouse_saved_query" "active_customers" {
+  project_id = "1234"
+  name       = "active_customers"
+
+  query = <<-EOT
+    SELECT person_id, count() AS event_count
+    FROM events
+    WHERE timestamp > now() - INTERVAL 30 DAY
+    GROUP BY person_id
+  EOT
+}
+
+# Insight querying the view
+resource "posthog_insight" "top_customers" {
+  project_id  = "1234"
+  name        = "Top customers"
+  hogql_query = "SELECT * FROM ${posthog_warehouse_saved_query.active_customers.name} ORDER BY event_count DESC LIMIT 10"
+}
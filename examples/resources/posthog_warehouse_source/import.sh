@@ -0,0 +1,7 @@
+# Warehouse sources can be imported by specifying their ID and the ID of the
+# project (found in the project settings page next to the API key). The
+# connection settings are not returned by PostHog: they are set when applying
+# the configuration after the import.
+#
+# The syntax is PROJECT_ID/WAREHOUSE_SOURCE_ID
+terraform import posthog_warehouse_source.test 1234/0190fbb8-2a33-0000-b5bd-5b4bd8a3c3f4
@@ -0,0 +1,34 @@
+variable "postgres_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "posthog_warehouse_source" "app_db" {
+  project_id  = "1234"
+  source_type = "Postgres"
+  prefix      = "app"
+
+  settings = {
+    host     = "db.example.com"
+    port     = "5432"
+    database = "app"
+    user     = "posthog"
+    schema   = "public"
+  }
+
+  secret_settings = {
+    password = var.postgres_password
+  }
+
+  sync_frequency = "6hour"
+
+  schemas = [
+    { name = "plans" },
+    {
+      name                   = "subscriptions"
+      sync_type              = "incremental"
+      incremental_field      = "updated_at"
+      incremental_field_type = "timestamp"
+    },
+  ]
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 )
 
@@ -28,12 +29,20 @@ type apiRequest struct {
 	Input          any
 	Output         any
 	OutputNilIf404 bool
+
+	// RawInput is sent as is instead of Input, with the given content type
+	RawInput            io.Reader
+	RawInputContentType string
 }
 
 func (c *Client) do(ctx context.Context, r apiRequest) error {
 	var body io.Reader
+	contentType := "application/json"
 
-	if r.Input != nil {
+	if r.RawInput != nil {
+		body = r.RawInput
+		contentType = r.RawInputContentType
+	} else if r.Input != nil {
 		j, err := json.Marshal(r.Input)
 		if err != nil {
 			return fmt.Errorf("error marshalling input to json: %w", err)
@@ -47,7 +56,7 @@ func (c *Client) do(ctx context.Context, r apiRequest) error {
 	}
 
 	if body != nil {
-		req.Header.Add("Content-Type", "application/json")
+		req.Header.Add("Content-Type", contentType)
 	}
 
 	if r.Output != nil {
@@ -70,7 +79,7 @@ func (c *Client) do(ctx context.Context, r apiRequest) error {
 		responseBody = strings.NewReader("null") // ¯\_(ツ)_/¯
 	} else if res.StatusCode != r.ExpectedCode {
 		errorBody, _ := io.ReadAll(res.Body)
-		return fmt.Errorf("unexpected HTTP status code, expected %d, got %d (server response: %s)", r.ExpectedCode, res.StatusCode, string(errorBody))
+		return &StatusCodeError{Expected: r.ExpectedCode, Got: res.StatusCode, Body: string(errorBody)}
 	} else { // res.StatusCode == r.ExpectedCode
 		responseBody = res.Body
 	}
@@ -83,3 +92,56 @@ func (c *Client) do(ctx context.Context, r apiRequest) error {
 
 	return nil
 }
+
+// StatusCodeError is returned when the API replies with an unexpected status
+// code.
+type StatusCodeError struct {
+	Expected int
+	Got      int
+	Body     string
+}
+
+func (e *StatusCodeError) Error() string {
+	return fmt.Sprintf("unexpected HTTP status code, expected %d, got %d (server response: %s)", e.Expected, e.Got, e.Body)
+}
+
+type paginatedResponse[T any] struct {
+	Next    string `json:"next"`
+	Results []T    `json:"results"`
+}
+
+// listAll fetches all the pages of a paginated list endpoint.
+func listAll[T any](ctx context.Context, c *Client, path string) ([]T, error) {
+	var res []T
+
+	for path != "" {
+		var page paginatedResponse[T]
+		err := c.do(ctx, apiRequest{
+			Method:       "GET",
+			Path:         path,
+			ExpectedCode: http.StatusOK,
+			Output:       &page,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, page.Results...)
+		path = ""
+
+		if page.Next != "" {
+			nextURL, err := url.Parse(page.Next)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing next page URL: %w", err)
+			}
+
+			// Next page URLs are absolute, strip the host and the API prefix
+			path = strings.TrimPrefix(nextURL.Path, "/api")
+			if nextURL.RawQuery != "" {
+				path += "?" + nextURL.RawQuery
+			}
+		}
+	}
+
+	return res, nil
+}
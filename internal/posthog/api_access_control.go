@@ -0,0 +1,125 @@
+package posthog
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+type AccessControlResource string
+
+const (
+	AccessControlResourceProject     AccessControlResource = "project"
+	AccessControlResourceDashboard   AccessControlResource = "dashboard"
+	AccessControlResourceFeatureFlag AccessControlResource = "feature_flag"
+	AccessControlResourceInsight     AccessControlResource = "insight"
+	AccessControlResourceNotebook    AccessControlResource = "notebook"
+)
+
+var accessControlResourcePaths = map[AccessControlResource]string{
+	AccessControlResourceDashboard:   "dashboards",
+	AccessControlResourceFeatureFlag: "feature_flags",
+	AccessControlResourceInsight:     "insights",
+	AccessControlResourceNotebook:    "notebooks",
+}
+
+// AccessLevel is the access granted by an access control. Projects use the
+// none, member and admin levels, other resources none, viewer and editor.
+type AccessLevel string
+
+const (
+	AccessLevelNone   AccessLevel = "none"
+	AccessLevelViewer AccessLevel = "viewer"
+	AccessLevelEditor AccessLevel = "editor"
+	AccessLevelMember AccessLevel = "member"
+	AccessLevelAdmin  AccessLevel = "admin"
+)
+
+// AccessControl grants a role or an organization member (identified by the
+// ID of their membership, not of the user) access to a project or to one of
+// its resources. When ResourceID is empty, the access control applies to all
+// the resources of that type in the project.
+type AccessControl struct {
+	ID                 string                `json:"id"`
+	Resource           AccessControlResource `json:"resource"`
+	ResourceID         *string               `json:"resource_id"`
+	AccessLevel        AccessLevel           `json:"access_level"`
+	OrganizationMember *string               `json:"organization_member"`
+	Role               *RoleID               `json:"role"`
+}
+
+// SetAccessControlRequest creates or updates the access control of a role or
+// member. Resource is only used for the access controls that apply to all the
+// resources of a type.
+type SetAccessControlRequest struct {
+	Resource           AccessControlResource `json:"resource,omitempty"`
+	AccessLevel        *AccessLevel          `json:"access_level"`
+	OrganizationMember *string               `json:"organization_member,omitempty"`
+	Role               *RoleID               `json:"role,omitempty"`
+}
+
+type accessControlsResponse struct {
+	AccessControls []AccessControl `json:"access_controls"`
+}
+
+func accessControlsPath(projectID ProjectID, resource AccessControlResource, resourceID string) string {
+	projectPath := "/projects/" + url.PathEscape(projectID.String())
+
+	if resource == AccessControlResourceProject {
+		return projectPath + "/access_controls"
+	}
+
+	if resourceID == "" {
+		return projectPath + "/resource_access_controls"
+	}
+
+	return projectPath + "/" + accessControlResourcePaths[resource] + "/" + url.PathEscape(resourceID) + "/access_controls"
+}
+
+// GetAccessControls returns the access controls of a project (when resource
+// is AccessControlResourceProject), of a single resource, or of all the
+// resources of a type when resourceID is empty.
+func (c *Client) GetAccessControls(ctx context.Context, projectID ProjectID, resource AccessControlResource, resourceID string) ([]AccessControl, error) {
+	var res accessControlsResponse
+	err := c.do(ctx, apiRequest{
+		Method:       "GET",
+		Path:         accessControlsPath(projectID, resource, resourceID),
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	return res.AccessControls, err
+}
+
+func (c *Client) SetAccessControl(ctx context.Context, projectID ProjectID, resource AccessControlResource, resourceID string, a SetAccessControlRequest) (*AccessControl, error) {
+	if resourceID == "" && resource != AccessControlResourceProject {
+		a.Resource = resource
+	}
+
+	var res *AccessControl
+	err := c.do(ctx, apiRequest{
+		Method:       "PUT",
+		Path:         accessControlsPath(projectID, resource, resourceID),
+		Input:        a,
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	return res, err
+}
+
+// DeleteAccessControl deletes the access control of a role or member, the
+// access level of the request is ignored.
+func (c *Client) DeleteAccessControl(ctx context.Context, projectID ProjectID, resource AccessControlResource, resourceID string, a SetAccessControlRequest) error {
+	if resourceID == "" && resource != AccessControlResourceProject {
+		a.Resource = resource
+	}
+
+	a.AccessLevel = nil
+
+	err := c.do(ctx, apiRequest{
+		Method:       "PUT",
+		Path:         accessControlsPath(projectID, resource, resourceID),
+		Input:        a,
+		ExpectedCode: http.StatusNoContent,
+	})
+	return err
+}
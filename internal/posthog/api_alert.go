@@ -0,0 +1,173 @@
+package posthog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// AlertID is the UUID of an alert.
+type AlertID string
+
+func (i AlertID) String() string {
+	return string(i)
+}
+
+func AlertIDFromString(s string) (AlertID, error) {
+	if s == "" {
+		return "", errors.New("empty alert ID")
+	}
+
+	return AlertID(s), nil
+}
+
+type AlertThresholdType string
+
+const (
+	AlertThresholdTypeAbsolute   AlertThresholdType = "absolute"
+	AlertThresholdTypePercentage AlertThresholdType = "percentage"
+)
+
+type AlertConditionType string
+
+const (
+	AlertConditionTypeAbsoluteValue    AlertConditionType = "absolute_value"
+	AlertConditionTypeRelativeIncrease AlertConditionType = "relative_increase"
+	AlertConditionTypeRelativeDecrease AlertConditionType = "relative_decrease"
+)
+
+type AlertCalculationInterval string
+
+const (
+	AlertCalculationIntervalHourly  AlertCalculationInterval = "hourly"
+	AlertCalculationIntervalDaily   AlertCalculationInterval = "daily"
+	AlertCalculationIntervalWeekly  AlertCalculationInterval = "weekly"
+	AlertCalculationIntervalMonthly AlertCalculationInterval = "monthly"
+)
+
+// AlertThreshold fires the alert when the value (or its change, depending on
+// the condition) goes below Lower or above Upper. Percentage thresholds are
+// fractions, 0.1 meaning 10%.
+type AlertThreshold struct {
+	Configuration AlertThresholdConfiguration `json:"configuration"`
+}
+
+type AlertThresholdConfiguration struct {
+	Type   AlertThresholdType `json:"type"`
+	Bounds AlertBounds        `json:"bounds"`
+}
+
+type AlertBounds struct {
+	Lower *float64 `json:"lower,omitempty"`
+	Upper *float64 `json:"upper,omitempty"`
+}
+
+type AlertCondition struct {
+	Type AlertConditionType `json:"type"`
+}
+
+const alertConfigTypeTrends = "TrendsAlertConfig"
+
+// AlertConfig selects the series of the insight the alert checks.
+// CheckOngoingInterval also checks the current, incomplete, interval.
+type AlertConfig struct {
+	Type                 string `json:"type"`
+	SeriesIndex          int64  `json:"series_index"`
+	CheckOngoingInterval bool   `json:"check_ongoing_interval"`
+}
+
+type CreateAlertRequest struct {
+	Name                string                   `json:"name"`
+	Insight             InsightID                `json:"insight"`
+	SubscribedUsers     []UserID                 `json:"subscribed_users"`
+	Threshold           AlertThreshold           `json:"threshold"`
+	Condition           AlertCondition           `json:"condition"`
+	CalculationInterval AlertCalculationInterval `json:"calculation_interval"`
+	Enabled             bool                     `json:"enabled"`
+	Config              AlertConfig              `json:"config"`
+}
+
+// Alert checks the value of a trends insight periodically, and notifies the
+// subscribed users when it crosses the threshold.
+type Alert struct {
+	ID                  AlertID                  `json:"id"`
+	Name                string                   `json:"name"`
+	Insight             AlertInsight             `json:"insight"`
+	SubscribedUsers     []UserBasic              `json:"subscribed_users"`
+	Threshold           AlertThreshold           `json:"threshold"`
+	Condition           AlertCondition           `json:"condition"`
+	CalculationInterval AlertCalculationInterval `json:"calculation_interval"`
+	Enabled             bool                     `json:"enabled"`
+	Config              AlertConfig              `json:"config"`
+	State               string                   `json:"state"`
+}
+
+// AlertInsight is the insight of an alert, returned either as an ID or as an
+// object depending on the API version.
+type AlertInsight struct {
+	ID InsightID `json:"id"`
+}
+
+func (i *AlertInsight) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &i.ID); err == nil {
+		return nil
+	}
+
+	type rawAlertInsight AlertInsight
+	return json.Unmarshal(b, (*rawAlertInsight)(i))
+}
+
+func (c *Client) CreateAlert(ctx context.Context, projectID ProjectID, a CreateAlertRequest) (*Alert, error) {
+	nilSliceToEmpty(&a.SubscribedUsers)
+	a.Config.Type = alertConfigTypeTrends
+
+	var res *Alert
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/alerts",
+		Input:        a,
+		ExpectedCode: http.StatusCreated,
+		Output:       &res,
+	})
+	return res, err
+}
+
+// UpdateAlert updates an alert. Subscribed users are sent as IDs, while
+// alerts return them as objects, hence the use of CreateAlertRequest.
+func (c *Client) UpdateAlert(ctx context.Context, projectID ProjectID, alertID AlertID, a CreateAlertRequest) (*Alert, error) {
+	nilSliceToEmpty(&a.SubscribedUsers)
+	a.Config.Type = alertConfigTypeTrends
+
+	var res *Alert
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/alerts/" + url.PathEscape(alertID.String()),
+		Input:        a,
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) GetAlert(ctx context.Context, projectID ProjectID, alertID AlertID) (*Alert, error) {
+	var res *Alert
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/alerts/" + url.PathEscape(alertID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
+
+func (c *Client) DeleteAlert(ctx context.Context, projectID ProjectID, alertID AlertID) error {
+	err := c.do(ctx, apiRequest{
+		Method:       "DELETE",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/alerts/" + url.PathEscape(alertID.String()),
+		ExpectedCode: http.StatusNoContent,
+	})
+	return err
+}
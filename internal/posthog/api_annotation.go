@@ -0,0 +1,84 @@
+package posthog
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type AnnotationID uint64
+
+func (i AnnotationID) String() string {
+	return strconv.FormatUint(uint64(i), 10)
+}
+
+func AnnotationIDFromString(s string) (AnnotationID, error) {
+	res, err := strconv.ParseUint(s, 10, 64)
+	return AnnotationID(res), err
+}
+
+type AnnotationScope string
+
+const (
+	AnnotationScopeProject       AnnotationScope = "project"
+	AnnotationScopeOrganization  AnnotationScope = "organization"
+	AnnotationScopeDashboardItem AnnotationScope = "dashboard_item"
+)
+
+type CreateAnnotationRequest struct {
+	Content       string          `json:"content"`
+	DateMarker    time.Time       `json:"date_marker"`
+	Scope         AnnotationScope `json:"scope"`
+	DashboardItem *InsightID      `json:"dashboard_item"`
+}
+
+// Annotation is a note displayed on the charts of insights at a given date.
+// DashboardItem is the insight the annotation is attached to, for annotations
+// scoped to a single insight.
+type Annotation struct {
+	ID            AnnotationID    `json:"id"`
+	Content       string          `json:"content"`
+	DateMarker    time.Time       `json:"date_marker"`
+	Scope         AnnotationScope `json:"scope"`
+	DashboardItem *InsightID      `json:"dashboard_item"`
+	Deleted       bool            `json:"deleted"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+func (c *Client) CreateAnnotation(ctx context.Context, projectID ProjectID, a CreateAnnotationRequest) (*Annotation, error) {
+	var res *Annotation
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/annotations",
+		Input:        a,
+		ExpectedCode: http.StatusCreated,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) UpdateAnnotation(ctx context.Context, projectID ProjectID, a Annotation) (*Annotation, error) {
+	var res *Annotation
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/annotations/" + url.PathEscape(a.ID.String()),
+		Input:        a,
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) GetAnnotation(ctx context.Context, projectID ProjectID, annotationID AnnotationID) (*Annotation, error) {
+	var res *Annotation
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/annotations/" + url.PathEscape(annotationID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
@@ -0,0 +1,205 @@
+package posthog
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// BatchExportID is the UUID of a batch export.
+type BatchExportID string
+
+func (i BatchExportID) String() string {
+	return string(i)
+}
+
+func BatchExportIDFromString(s string) (BatchExportID, error) {
+	if s == "" {
+		return "", errors.New("empty batch export ID")
+	}
+
+	return BatchExportID(s), nil
+}
+
+type BatchExportModel string
+
+const (
+	BatchExportModelEvents   BatchExportModel = "events"
+	BatchExportModelPersons  BatchExportModel = "persons"
+	BatchExportModelSessions BatchExportModel = "sessions"
+)
+
+type BatchExportInterval string
+
+const (
+	BatchExportIntervalHour          BatchExportInterval = "hour"
+	BatchExportIntervalDay           BatchExportInterval = "day"
+	BatchExportIntervalEvery5Minutes BatchExportInterval = "every 5 minutes"
+)
+
+type BatchExportDestinationType string
+
+const (
+	BatchExportDestinationTypeS3        BatchExportDestinationType = "S3"
+	BatchExportDestinationTypeBigQuery  BatchExportDestinationType = "BigQuery"
+	BatchExportDestinationTypeSnowflake BatchExportDestinationType = "Snowflake"
+	BatchExportDestinationTypePostgres  BatchExportDestinationType = "Postgres"
+	BatchExportDestinationTypeRedshift  BatchExportDestinationType = "Redshift"
+	BatchExportDestinationTypeHTTP      BatchExportDestinationType = "HTTP"
+)
+
+type BatchExportDestination struct {
+	Type   BatchExportDestinationType   `json:"type"`
+	Config BatchExportDestinationConfig `json:"config"`
+}
+
+// BatchExportDestinationConfig holds the settings of all the destination
+// types, only the ones of the destination type are set. Secrets (passwords,
+// keys and tokens) are not returned by the API.
+type BatchExportDestinationConfig struct {
+	IncludeEvents []string `json:"include_events,omitempty"`
+	ExcludeEvents []string `json:"exclude_events,omitempty"`
+
+	// S3
+	BucketName         string `json:"bucket_name,omitempty"`
+	Region             string `json:"region,omitempty"`
+	Prefix             string `json:"prefix,omitempty"`
+	AWSAccessKeyID     string `json:"aws_access_key_id,omitempty"`
+	AWSSecretAccessKey string `json:"aws_secret_access_key,omitempty"`
+	Compression        string `json:"compression,omitempty"`
+	Encryption         string `json:"encryption,omitempty"`
+	KMSKeyID           string `json:"kms_key_id,omitempty"`
+	EndpointURL        string `json:"endpoint_url,omitempty"`
+	FileFormat         string `json:"file_format,omitempty"`
+	MaxFileSizeMB      *int64 `json:"max_file_size_mb,omitempty"`
+
+	// BigQuery
+	ProjectID    string `json:"project_id,omitempty"`
+	DatasetID    string `json:"dataset_id,omitempty"`
+	TableID      string `json:"table_id,omitempty"`
+	ClientEmail  string `json:"client_email,omitempty"`
+	PrivateKey   string `json:"private_key,omitempty"`
+	PrivateKeyID string `json:"private_key_id,omitempty"`
+	TokenURI     string `json:"token_uri,omitempty"`
+	UseJSONType  bool   `json:"use_json_type,omitempty"`
+
+	// Snowflake, Postgres and Redshift
+	Account            string `json:"account,omitempty"`
+	Warehouse          string `json:"warehouse,omitempty"`
+	Role               string `json:"role,omitempty"`
+	Host               string `json:"host,omitempty"`
+	Port               int64  `json:"port,omitempty"`
+	User               string `json:"user,omitempty"`
+	Password           string `json:"password,omitempty"`
+	Database           string `json:"database,omitempty"`
+	Schema             string `json:"schema,omitempty"`
+	TableName          string `json:"table_name,omitempty"`
+	HasSelfSignedCert  bool   `json:"has_self_signed_cert,omitempty"`
+	PropertiesDataType string `json:"properties_data_type,omitempty"`
+
+	// HTTP
+	URL   string `json:"url,omitempty"`
+	Token string `json:"token,omitempty"`
+}
+
+type CreateBatchExportRequest struct {
+	Name        string                 `json:"name"`
+	Model       BatchExportModel       `json:"model"`
+	Destination BatchExportDestination `json:"destination"`
+	Interval    BatchExportInterval    `json:"interval"`
+	Paused      bool                   `json:"paused"`
+}
+
+// BatchExport periodically exports the events (or persons, or sessions) of
+// a project to a destination. The paused state can't be updated directly, see
+// PauseBatchExport and UnpauseBatchExport.
+type BatchExport struct {
+	ID          BatchExportID          `json:"id"`
+	Name        string                 `json:"name"`
+	Model       BatchExportModel       `json:"model"`
+	Destination BatchExportDestination `json:"destination"`
+	Interval    BatchExportInterval    `json:"interval"`
+	Paused      bool                   `json:"paused"`
+	CreatedAt   time.Time              `json:"created_at"`
+}
+
+type CreateBatchExportBackfillRequest struct {
+	StartAt time.Time `json:"start_at"`
+	EndAt   time.Time `json:"end_at"`
+}
+
+func (c *Client) CreateBatchExport(ctx context.Context, projectID ProjectID, b CreateBatchExportRequest) (*BatchExport, error) {
+	var res *BatchExport
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/batch_exports",
+		Input:        b,
+		ExpectedCode: http.StatusCreated,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) UpdateBatchExport(ctx context.Context, projectID ProjectID, b BatchExport) (*BatchExport, error) {
+	var res *BatchExport
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/batch_exports/" + url.PathEscape(b.ID.String()),
+		Input:        b,
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) GetBatchExport(ctx context.Context, projectID ProjectID, batchExportID BatchExportID) (*BatchExport, error) {
+	var res *BatchExport
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/batch_exports/" + url.PathEscape(batchExportID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
+
+func (c *Client) DeleteBatchExport(ctx context.Context, projectID ProjectID, batchExportID BatchExportID) error {
+	err := c.do(ctx, apiRequest{
+		Method:       "DELETE",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/batch_exports/" + url.PathEscape(batchExportID.String()),
+		ExpectedCode: http.StatusNoContent,
+	})
+	return err
+}
+
+func (c *Client) PauseBatchExport(ctx context.Context, projectID ProjectID, batchExportID BatchExportID) error {
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/batch_exports/" + url.PathEscape(batchExportID.String()) + "/pause",
+		ExpectedCode: http.StatusOK,
+	})
+	return err
+}
+
+func (c *Client) UnpauseBatchExport(ctx context.Context, projectID ProjectID, batchExportID BatchExportID) error {
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/batch_exports/" + url.PathEscape(batchExportID.String()) + "/unpause",
+		ExpectedCode: http.StatusOK,
+	})
+	return err
+}
+
+// CreateBatchExportBackfill exports the data between the given dates again.
+func (c *Client) CreateBatchExportBackfill(ctx context.Context, projectID ProjectID, batchExportID BatchExportID, b CreateBatchExportBackfillRequest) error {
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/batch_exports/" + url.PathEscape(batchExportID.String()) + "/backfills",
+		Input:        b,
+		ExpectedCode: http.StatusCreated,
+	})
+	return err
+}
@@ -0,0 +1,325 @@
+package posthog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type CohortID uint64
+
+func (i CohortID) String() string {
+	return strconv.FormatUint(uint64(i), 10)
+}
+
+func CohortIDFromString(s string) (CohortID, error) {
+	res, err := strconv.ParseUint(s, 10, 64)
+	return CohortID(res), err
+}
+
+// CohortFilters describes the members of a dynamic cohort: a list of groups of
+// criteria, combined with AND or OR.
+type CohortFilters struct {
+	Properties CohortPropertyGroup `json:"properties"`
+}
+
+type CohortPropertyGroup struct {
+	Type   PropertyGroupType     `json:"type"`
+	Values []CohortCriteriaGroup `json:"values"`
+}
+
+type CohortCriteriaGroup struct {
+	Type   PropertyGroupType `json:"type"`
+	Values []CohortCriterion `json:"values"`
+}
+
+type CohortCriterionType string
+
+const (
+	CohortCriterionTypePerson     CohortCriterionType = "person"
+	CohortCriterionTypeBehavioral CohortCriterionType = "behavioral"
+	CohortCriterionTypeCohort     CohortCriterionType = "cohort"
+)
+
+type CohortBehavior string
+
+const (
+	CohortBehaviorPerformedEvent          CohortBehavior = "performed_event"
+	CohortBehaviorPerformedEventMultiple  CohortBehavior = "performed_event_multiple"
+	CohortBehaviorPerformedEventFirstTime CohortBehavior = "performed_event_first_time"
+)
+
+type CohortEventType string
+
+const (
+	CohortEventTypeEvents  CohortEventType = "events"
+	CohortEventTypeActions CohortEventType = "actions"
+)
+
+// CohortCriterion is a single criterion of a cohort: a person property, an
+// event performed by the person or the membership of another cohort.
+//
+// The meaning of Value depends on the type of the criterion: it is the
+// property value for person criteria, the behavior (see CohortBehavior) for
+// behavioral criteria and the cohort ID for cohort criteria.
+type CohortCriterion struct {
+	Type     CohortCriterionType `json:"type"`
+	Key      string              `json:"key"`
+	Value    PropertyValue       `json:"value"`
+	Operator PropertyOperator    `json:"operator,omitempty"`
+	Negation bool                `json:"negation"`
+
+	// behavioral criteria
+
+	EventType     CohortEventType `json:"event_type,omitempty"`
+	TimeValue     int64           `json:"time_value,omitempty"`
+	TimeInterval  string          `json:"time_interval,omitempty"`
+	OperatorValue *int64          `json:"operator_value,omitempty"`
+}
+
+func (c CohortCriterion) MarshalJSON() ([]byte, error) {
+	if c.Type == CohortCriterionTypePerson {
+		propertyJSON, err := json.Marshal(PropertyFilter{
+			Key:      c.Key,
+			Type:     PropertyFilterTypePerson,
+			Operator: c.Operator,
+			Value:    c.Value,
+			Negation: c.Negation,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return propertyJSON, nil
+	}
+
+	type rawCohortCriterion struct {
+		Type          CohortCriterionType `json:"type"`
+		Key           any                 `json:"key"`
+		Value         any                 `json:"value"`
+		Operator      PropertyOperator    `json:"operator,omitempty"`
+		Negation      bool                `json:"negation"`
+		EventType     CohortEventType     `json:"event_type,omitempty"`
+		TimeValue     int64               `json:"time_value,omitempty"`
+		TimeInterval  string              `json:"time_interval,omitempty"`
+		OperatorValue *int64              `json:"operator_value,omitempty"`
+	}
+
+	if len(c.Value) != 1 {
+		return nil, fmt.Errorf("%s cohort criterion must have exactly one value", c.Type)
+	}
+
+	raw := rawCohortCriterion{
+		Type:          c.Type,
+		Key:           c.Key,
+		Value:         c.Value[0],
+		Operator:      c.Operator,
+		Negation:      c.Negation,
+		EventType:     c.EventType,
+		TimeValue:     c.TimeValue,
+		TimeInterval:  c.TimeInterval,
+		OperatorValue: c.OperatorValue,
+	}
+
+	if c.EventType == CohortEventTypeActions {
+		id, err := ActionIDFromString(c.Key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid action ID %q: %w", c.Key, err)
+		}
+		raw.Key = id
+	}
+
+	if c.Type == CohortCriterionTypeCohort {
+		id, err := CohortIDFromString(c.Value[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cohort ID %q: %w", c.Value[0], err)
+		}
+		raw.Value = id
+	}
+
+	return json.Marshal(raw)
+}
+
+func (c *CohortCriterion) UnmarshalJSON(b []byte) error {
+	type rawCohortCriterion CohortCriterion
+
+	// Keys are action IDs (numbers) for behavioral criteria on actions
+	aux := struct {
+		*rawCohortCriterion
+		Key json.RawMessage `json:"key"`
+	}{
+		rawCohortCriterion: (*rawCohortCriterion)(c),
+	}
+
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	var key PropertyValue
+	if len(aux.Key) > 0 {
+		if err := json.Unmarshal(aux.Key, &key); err != nil {
+			return fmt.Errorf("error decoding cohort criterion key: %w", err)
+		}
+	}
+
+	c.Key = ""
+	if len(key) > 0 {
+		c.Key = key[0]
+	}
+
+	if c.Operator == PropertyOperatorIsSet || c.Operator == PropertyOperatorIsNotSet {
+		// The web UI stores the operator name as the value
+		c.Value = nil
+	}
+
+	return nil
+}
+
+type CreateCohortRequest struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Filters     *CohortFilters `json:"filters,omitempty"`
+	IsStatic    bool           `json:"is_static"`
+}
+
+type Cohort struct {
+	ID          CohortID       `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Filters     *CohortFilters `json:"filters,omitempty"`
+	IsStatic    bool           `json:"is_static"`
+	Deleted     bool           `json:"deleted"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+func (f *CohortFilters) normalize() {
+	nilSliceToEmpty(&f.Properties.Values)
+
+	for i := range f.Properties.Values {
+		nilSliceToEmpty(&f.Properties.Values[i].Values)
+	}
+}
+
+// cohortCSVForm builds the multipart form used to upload the members of a
+// static cohort. PostHog reads the distinct IDs from the first column of the
+// CSV file.
+func cohortCSVForm(fields map[string]string, csv []byte) (*bytes.Buffer, string, error) {
+	var buf bytes.Buffer
+
+	w := multipart.NewWriter(&buf)
+
+	for k, v := range fields {
+		if err := w.WriteField(k, v); err != nil {
+			return nil, "", fmt.Errorf("error writing form field %s: %w", k, err)
+		}
+	}
+
+	fw, err := w.CreateFormFile("csv", "cohort.csv")
+	if err != nil {
+		return nil, "", fmt.Errorf("error creating CSV form field: %w", err)
+	}
+
+	if _, err := fw.Write(csv); err != nil {
+		return nil, "", fmt.Errorf("error writing CSV: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, "", fmt.Errorf("error finalizing form: %w", err)
+	}
+
+	return &buf, w.FormDataContentType(), nil
+}
+
+func (c *Client) CreateCohort(ctx context.Context, projectID ProjectID, co CreateCohortRequest) (*Cohort, error) {
+	if co.Filters != nil {
+		co.Filters.normalize()
+	}
+
+	var res *Cohort
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/cohorts",
+		Input:        co,
+		ExpectedCode: http.StatusCreated,
+		Output:       &res,
+	})
+	return res, err
+}
+
+// CreateStaticCohort creates a static cohort whose members are read from the
+// given CSV file.
+func (c *Client) CreateStaticCohort(ctx context.Context, projectID ProjectID, name string, description string, csv []byte) (*Cohort, error) {
+	form, contentType, err := cohortCSVForm(map[string]string{
+		"name":        name,
+		"description": description,
+		"is_static":   "true",
+	}, csv)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *Cohort
+	err = c.do(ctx, apiRequest{
+		Method:              "POST",
+		Path:                "/projects/" + url.PathEscape(projectID.String()) + "/cohorts",
+		RawInput:            form,
+		RawInputContentType: contentType,
+		ExpectedCode:        http.StatusCreated,
+		Output:              &res,
+	})
+	return res, err
+}
+
+func (c *Client) UpdateCohort(ctx context.Context, projectID ProjectID, co Cohort) (*Cohort, error) {
+	if co.Filters != nil {
+		co.Filters.normalize()
+	}
+
+	var res *Cohort
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/cohorts/" + url.PathEscape(co.ID.String()),
+		Input:        co,
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	return res, err
+}
+
+// UploadStaticCohortMembers adds the persons listed in the given CSV file to
+// a static cohort. Existing members are kept.
+func (c *Client) UploadStaticCohortMembers(ctx context.Context, projectID ProjectID, cohortID CohortID, csv []byte) (*Cohort, error) {
+	form, contentType, err := cohortCSVForm(nil, csv)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *Cohort
+	err = c.do(ctx, apiRequest{
+		Method:              "PATCH",
+		Path:                "/projects/" + url.PathEscape(projectID.String()) + "/cohorts/" + url.PathEscape(cohortID.String()),
+		RawInput:            form,
+		RawInputContentType: contentType,
+		ExpectedCode:        http.StatusOK,
+		Output:              &res,
+	})
+	return res, err
+}
+
+func (c *Client) GetCohort(ctx context.Context, projectID ProjectID, cohortID CohortID) (*Cohort, error) {
+	var res *Cohort
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/cohorts/" + url.PathEscape(cohortID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
@@ -0,0 +1,134 @@
+package posthog
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type DashboardID uint64
+
+func (i DashboardID) String() string {
+	return strconv.FormatUint(uint64(i), 10)
+}
+
+func DashboardIDFromString(s string) (DashboardID, error) {
+	res, err := strconv.ParseUint(s, 10, 64)
+	return DashboardID(res), err
+}
+
+type DashboardTileID uint64
+
+type DashboardRestrictionLevel int
+
+const (
+	DashboardRestrictionLevelEveryoneCanEdit          DashboardRestrictionLevel = 21
+	DashboardRestrictionLevelOnlyCollaboratorsCanEdit DashboardRestrictionLevel = 37
+)
+
+type DashboardTileLayout struct {
+	X int64 `json:"x"`
+	Y int64 `json:"y"`
+	W int64 `json:"w"`
+	H int64 `json:"h"`
+}
+
+// DashboardTile is either an insight or a text card on a dashboard. Layouts
+// are keyed by breakpoint (eg. sm, xs).
+type DashboardTile struct {
+	ID      DashboardTileID                `json:"id,omitempty"`
+	Insight *DashboardTileInsight          `json:"insight,omitempty"`
+	Text    *DashboardTileText             `json:"text,omitempty"`
+	Layouts map[string]DashboardTileLayout `json:"layouts,omitempty"`
+	Deleted bool                           `json:"deleted,omitempty"`
+}
+
+type DashboardTileInsight struct {
+	ID      InsightID `json:"id"`
+	Deleted bool      `json:"deleted,omitempty"`
+}
+
+type DashboardTileText struct {
+	Body string `json:"body"`
+}
+
+type CreateDashboardRequest struct {
+	Name             string                    `json:"name"`
+	Description      string                    `json:"description"`
+	Tags             []string                  `json:"tags"`
+	Pinned           bool                      `json:"pinned"`
+	RestrictionLevel DashboardRestrictionLevel `json:"restriction_level"`
+}
+
+type Dashboard struct {
+	ID               DashboardID               `json:"id"`
+	Name             string                    `json:"name"`
+	Description      string                    `json:"description"`
+	Tags             []string                  `json:"tags"`
+	Pinned           bool                      `json:"pinned"`
+	RestrictionLevel DashboardRestrictionLevel `json:"restriction_level"`
+	Tiles            []DashboardTile           `json:"tiles,omitempty"`
+	Deleted          bool                      `json:"deleted"`
+	CreatedAt        time.Time                 `json:"created_at"`
+}
+
+func (c *Client) CreateDashboard(ctx context.Context, projectID ProjectID, d CreateDashboardRequest) (*Dashboard, error) {
+	nilSliceToEmpty(&d.Tags)
+
+	var res *Dashboard
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/dashboards",
+		Input:        d,
+		ExpectedCode: http.StatusCreated,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) UpdateDashboard(ctx context.Context, projectID ProjectID, d Dashboard) (*Dashboard, error) {
+	nilSliceToEmpty(&d.Tags)
+
+	var res *Dashboard
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/dashboards/" + url.PathEscape(d.ID.String()),
+		Input:        d,
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) GetDashboard(ctx context.Context, projectID ProjectID, dashboardID DashboardID) (*Dashboard, error) {
+	var res *Dashboard
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/dashboards/" + url.PathEscape(dashboardID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
+
+// UpdateDashboardTiles creates (text tiles without an ID), updates or deletes
+// the given tiles of a dashboard, other tiles are left untouched.
+//
+// Insight tiles are created by adding the dashboard to the insight, see
+// SetInsightDashboards.
+func (c *Client) UpdateDashboardTiles(ctx context.Context, projectID ProjectID, dashboardID DashboardID, tiles []DashboardTile) (*Dashboard, error) {
+	var res *Dashboard
+	err := c.do(ctx, apiRequest{
+		Method: "PATCH",
+		Path:   "/projects/" + url.PathEscape(projectID.String()) + "/dashboards/" + url.PathEscape(dashboardID.String()),
+		Input: struct {
+			Tiles []DashboardTile `json:"tiles"`
+		}{tiles},
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	return res, err
+}
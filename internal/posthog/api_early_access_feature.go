@@ -0,0 +1,112 @@
+package posthog
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// EarlyAccessFeatureID is the UUID of an early access feature.
+type EarlyAccessFeatureID string
+
+func (i EarlyAccessFeatureID) String() string {
+	return string(i)
+}
+
+func EarlyAccessFeatureIDFromString(s string) (EarlyAccessFeatureID, error) {
+	if s == "" {
+		return "", errors.New("empty early access feature ID")
+	}
+
+	return EarlyAccessFeatureID(s), nil
+}
+
+type EarlyAccessFeatureStage string
+
+const (
+	EarlyAccessFeatureStageDraft               EarlyAccessFeatureStage = "draft"
+	EarlyAccessFeatureStageConcept             EarlyAccessFeatureStage = "concept"
+	EarlyAccessFeatureStageAlpha               EarlyAccessFeatureStage = "alpha"
+	EarlyAccessFeatureStageBeta                EarlyAccessFeatureStage = "beta"
+	EarlyAccessFeatureStageGeneralAvailability EarlyAccessFeatureStage = "general-availability"
+	EarlyAccessFeatureStageArchived            EarlyAccessFeatureStage = "archived"
+)
+
+type CreateEarlyAccessFeatureRequest struct {
+	Name             string                  `json:"name"`
+	Description      string                  `json:"description"`
+	Stage            EarlyAccessFeatureStage `json:"stage"`
+	DocumentationURL string                  `json:"documentation_url"`
+	FeatureFlagID    FeatureFlagID           `json:"feature_flag_id"`
+}
+
+type EarlyAccessFeatureFlag struct {
+	ID  FeatureFlagID `json:"id"`
+	Key string        `json:"key"`
+}
+
+// EarlyAccessFeature is a feature that users can opt into, from the early
+// access features site app or the SDKs. Opting in enables the linked feature
+// flag for the user. Features in the concept stage only register interest
+// without enabling the flag.
+type EarlyAccessFeature struct {
+	ID               EarlyAccessFeatureID    `json:"id"`
+	Name             string                  `json:"name"`
+	Description      string                  `json:"description"`
+	Stage            EarlyAccessFeatureStage `json:"stage"`
+	DocumentationURL string                  `json:"documentation_url"`
+	FeatureFlag      *EarlyAccessFeatureFlag `json:"feature_flag,omitempty"`
+	CreatedAt        time.Time               `json:"created_at"`
+}
+
+func (c *Client) CreateEarlyAccessFeature(ctx context.Context, projectID ProjectID, f CreateEarlyAccessFeatureRequest) (*EarlyAccessFeature, error) {
+	var res *EarlyAccessFeature
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/early_access_feature",
+		Input:        f,
+		ExpectedCode: http.StatusCreated,
+		Output:       &res,
+	})
+	return res, err
+}
+
+// UpdateEarlyAccessFeature updates the name, description, stage and
+// documentation URL of an early access feature. The linked feature flag can't
+// be changed.
+func (c *Client) UpdateEarlyAccessFeature(ctx context.Context, projectID ProjectID, f EarlyAccessFeature) (*EarlyAccessFeature, error) {
+	f.FeatureFlag = nil
+
+	var res *EarlyAccessFeature
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/early_access_feature/" + url.PathEscape(f.ID.String()),
+		Input:        f,
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) GetEarlyAccessFeature(ctx context.Context, projectID ProjectID, featureID EarlyAccessFeatureID) (*EarlyAccessFeature, error) {
+	var res *EarlyAccessFeature
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/early_access_feature/" + url.PathEscape(featureID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
+
+func (c *Client) DeleteEarlyAccessFeature(ctx context.Context, projectID ProjectID, featureID EarlyAccessFeatureID) error {
+	err := c.do(ctx, apiRequest{
+		Method:       "DELETE",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/early_access_feature/" + url.PathEscape(featureID.String()),
+		ExpectedCode: http.StatusNoContent,
+	})
+	return err
+}
@@ -0,0 +1,364 @@
+package posthog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ErrorTrackingRuleID is the UUID of an error tracking grouping, suppression
+// or assignment rule.
+type ErrorTrackingRuleID string
+
+func (i ErrorTrackingRuleID) String() string {
+	return string(i)
+}
+
+func ErrorTrackingRuleIDFromString(s string) (ErrorTrackingRuleID, error) {
+	if s == "" {
+		return "", errors.New("empty error tracking rule ID")
+	}
+
+	return ErrorTrackingRuleID(s), nil
+}
+
+// ErrorTrackingFilters selects the exceptions a rule applies to: exceptions
+// matching all the property filters (or any of them for OR groups). Like
+// recording filters, the web UI stores them as a group containing a single
+// group of filters.
+type ErrorTrackingFilters struct {
+	Type       PropertyGroupType
+	Properties []PropertyFilter
+}
+
+type rawErrorTrackingFilters struct {
+	Type   PropertyGroupType `json:"type"`
+	Values []json.RawMessage `json:"values"`
+}
+
+func (f ErrorTrackingFilters) MarshalJSON() ([]byte, error) {
+	groupType := f.Type
+	if groupType == "" {
+		groupType = PropertyGroupTypeAnd
+	}
+
+	values := make([]json.RawMessage, 0, len(f.Properties))
+
+	for _, p := range f.Properties {
+		v, err := json.Marshal(p)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, v)
+	}
+
+	inner, err := json.Marshal(rawErrorTrackingFilters{Type: groupType, Values: values})
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(rawErrorTrackingFilters{Type: groupType, Values: []json.RawMessage{inner}})
+}
+
+func (f *ErrorTrackingFilters) UnmarshalJSON(b []byte) error {
+	*f = ErrorTrackingFilters{}
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	res, err := decodeErrorTrackingFilterGroup(b)
+	if err != nil {
+		return err
+	}
+
+	*f = res
+
+	return nil
+}
+
+// decodeErrorTrackingFilterGroup decodes a (possibly nested) group of filters.
+// Nested groups are flattened when that doesn't change their meaning, that is
+// when they have the same type as their parent, or when either of them has a
+// single value. Other combinations of AND and OR groups are not supported.
+func decodeErrorTrackingFilterGroup(b []byte) (ErrorTrackingFilters, error) {
+	var raw rawErrorTrackingFilters
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return ErrorTrackingFilters{}, fmt.Errorf("error decoding error tracking filters: %w", err)
+	}
+
+	if raw.Type != PropertyGroupTypeAnd && raw.Type != PropertyGroupTypeOr {
+		return ErrorTrackingFilters{}, fmt.Errorf("unsupported error tracking filter group type %q", raw.Type)
+	}
+
+	res := ErrorTrackingFilters{Type: raw.Type}
+
+	for _, v := range raw.Values {
+		var header struct {
+			Type string `json:"type"`
+		}
+
+		if err := json.Unmarshal(v, &header); err != nil {
+			return ErrorTrackingFilters{}, fmt.Errorf("error decoding error tracking filter: %w", err)
+		}
+
+		if header.Type != string(PropertyGroupTypeAnd) && header.Type != string(PropertyGroupTypeOr) {
+			var p PropertyFilter
+			if err := json.Unmarshal(v, &p); err != nil {
+				return ErrorTrackingFilters{}, fmt.Errorf("error decoding error tracking filter: %w", err)
+			}
+
+			res.Properties = append(res.Properties, p)
+			continue
+		}
+
+		group, err := decodeErrorTrackingFilterGroup(v)
+		if err != nil {
+			return ErrorTrackingFilters{}, err
+		}
+
+		if len(raw.Values) == 1 {
+			// The type of a group with a single value doesn't matter
+			return group, nil
+		}
+
+		if len(group.Properties) > 1 && group.Type != raw.Type {
+			return ErrorTrackingFilters{}, fmt.Errorf("unsupported error tracking filters: %s group nested in an %s group", group.Type, raw.Type)
+		}
+
+		res.Properties = append(res.Properties, group.Properties...)
+	}
+
+	return res, nil
+}
+
+type ErrorTrackingAssigneeType string
+
+const (
+	ErrorTrackingAssigneeTypeUser ErrorTrackingAssigneeType = "user"
+	ErrorTrackingAssigneeTypeRole ErrorTrackingAssigneeType = "role"
+)
+
+// ErrorTrackingAssignee is the user or role issues get assigned to. The ID
+// is the numeric ID for users, and the UUID for roles.
+type ErrorTrackingAssignee struct {
+	Type ErrorTrackingAssigneeType
+	ID   string
+}
+
+type rawErrorTrackingAssignee struct {
+	Type ErrorTrackingAssigneeType `json:"type"`
+	ID   any                       `json:"id"`
+}
+
+func (a ErrorTrackingAssignee) MarshalJSON() ([]byte, error) {
+	if a.Type != ErrorTrackingAssigneeTypeUser {
+		return json.Marshal(rawErrorTrackingAssignee{Type: a.Type, ID: a.ID})
+	}
+
+	userID, err := UserIDFromString(a.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID %q: %w", a.ID, err)
+	}
+
+	return json.Marshal(rawErrorTrackingAssignee{Type: a.Type, ID: userID})
+}
+
+func (a *ErrorTrackingAssignee) UnmarshalJSON(b []byte) error {
+	var raw rawErrorTrackingAssignee
+
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	id, err := entityIDFromJSON(raw.ID)
+	if err != nil {
+		return err
+	}
+
+	*a = ErrorTrackingAssignee{Type: raw.Type, ID: id}
+
+	return nil
+}
+
+type CreateErrorTrackingGroupingRuleRequest struct {
+	Filters     ErrorTrackingFilters   `json:"filters"`
+	Assignee    *ErrorTrackingAssignee `json:"assignee"`
+	Description string                 `json:"description"`
+}
+
+// ErrorTrackingGroupingRule groups all the exceptions matching its filters
+// into a single issue, instead of grouping them by fingerprint. Issues
+// created by the rule are assigned to Assignee, if set.
+type ErrorTrackingGroupingRule struct {
+	ID          ErrorTrackingRuleID    `json:"id"`
+	Filters     ErrorTrackingFilters   `json:"filters"`
+	Assignee    *ErrorTrackingAssignee `json:"assignee"`
+	Description string                 `json:"description"`
+	OrderKey    int64                  `json:"order_key"`
+}
+
+func (c *Client) CreateErrorTrackingGroupingRule(ctx context.Context, projectID ProjectID, r CreateErrorTrackingGroupingRuleRequest) (*ErrorTrackingGroupingRule, error) {
+	var res *ErrorTrackingGroupingRule
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/error_tracking/grouping_rules",
+		Input:        r,
+		ExpectedCode: http.StatusCreated,
+		Output:       &res,
+	})
+	return res, err
+}
+
+// UpdateErrorTrackingGroupingRule updates a grouping rule. The API doesn't
+// return the updated rule.
+func (c *Client) UpdateErrorTrackingGroupingRule(ctx context.Context, projectID ProjectID, r ErrorTrackingGroupingRule) error {
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/error_tracking/grouping_rules/" + url.PathEscape(r.ID.String()),
+		Input:        r,
+		ExpectedCode: http.StatusNoContent,
+	})
+	return err
+}
+
+func (c *Client) GetErrorTrackingGroupingRule(ctx context.Context, projectID ProjectID, ruleID ErrorTrackingRuleID) (*ErrorTrackingGroupingRule, error) {
+	var res *ErrorTrackingGroupingRule
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/error_tracking/grouping_rules/" + url.PathEscape(ruleID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
+
+func (c *Client) DeleteErrorTrackingGroupingRule(ctx context.Context, projectID ProjectID, ruleID ErrorTrackingRuleID) error {
+	err := c.do(ctx, apiRequest{
+		Method:       "DELETE",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/error_tracking/grouping_rules/" + url.PathEscape(ruleID.String()),
+		ExpectedCode: http.StatusNoContent,
+	})
+	return err
+}
+
+type CreateErrorTrackingSuppressionRuleRequest struct {
+	Filters ErrorTrackingFilters `json:"filters"`
+}
+
+// ErrorTrackingSuppressionRule drops the exceptions matching its filters,
+// they don't create or update issues.
+type ErrorTrackingSuppressionRule struct {
+	ID       ErrorTrackingRuleID  `json:"id"`
+	Filters  ErrorTrackingFilters `json:"filters"`
+	OrderKey int64                `json:"order_key"`
+}
+
+func (c *Client) CreateErrorTrackingSuppressionRule(ctx context.Context, projectID ProjectID, r CreateErrorTrackingSuppressionRuleRequest) (*ErrorTrackingSuppressionRule, error) {
+	var res *ErrorTrackingSuppressionRule
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/error_tracking/suppression_rules",
+		Input:        r,
+		ExpectedCode: http.StatusCreated,
+		Output:       &res,
+	})
+	return res, err
+}
+
+// UpdateErrorTrackingSuppressionRule updates a suppression rule. The API
+// doesn't return the updated rule.
+func (c *Client) UpdateErrorTrackingSuppressionRule(ctx context.Context, projectID ProjectID, r ErrorTrackingSuppressionRule) error {
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/error_tracking/suppression_rules/" + url.PathEscape(r.ID.String()),
+		Input:        r,
+		ExpectedCode: http.StatusNoContent,
+	})
+	return err
+}
+
+func (c *Client) GetErrorTrackingSuppressionRule(ctx context.Context, projectID ProjectID, ruleID ErrorTrackingRuleID) (*ErrorTrackingSuppressionRule, error) {
+	var res *ErrorTrackingSuppressionRule
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/error_tracking/suppression_rules/" + url.PathEscape(ruleID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
+
+func (c *Client) DeleteErrorTrackingSuppressionRule(ctx context.Context, projectID ProjectID, ruleID ErrorTrackingRuleID) error {
+	err := c.do(ctx, apiRequest{
+		Method:       "DELETE",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/error_tracking/suppression_rules/" + url.PathEscape(ruleID.String()),
+		ExpectedCode: http.StatusNoContent,
+	})
+	return err
+}
+
+type CreateErrorTrackingAssignmentRuleRequest struct {
+	Filters  ErrorTrackingFilters  `json:"filters"`
+	Assignee ErrorTrackingAssignee `json:"assignee"`
+}
+
+// ErrorTrackingAssignmentRule assigns the new issues whose first exception
+// matches its filters to a user or a role.
+type ErrorTrackingAssignmentRule struct {
+	ID       ErrorTrackingRuleID   `json:"id"`
+	Filters  ErrorTrackingFilters  `json:"filters"`
+	Assignee ErrorTrackingAssignee `json:"assignee"`
+	OrderKey int64                 `json:"order_key"`
+}
+
+func (c *Client) CreateErrorTrackingAssignmentRule(ctx context.Context, projectID ProjectID, r CreateErrorTrackingAssignmentRuleRequest) (*ErrorTrackingAssignmentRule, error) {
+	var res *ErrorTrackingAssignmentRule
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/error_tracking/assignment_rules",
+		Input:        r,
+		ExpectedCode: http.StatusCreated,
+		Output:       &res,
+	})
+	return res, err
+}
+
+// UpdateErrorTrackingAssignmentRule updates an assignment rule. The API
+// doesn't return the updated rule.
+func (c *Client) UpdateErrorTrackingAssignmentRule(ctx context.Context, projectID ProjectID, r ErrorTrackingAssignmentRule) error {
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/error_tracking/assignment_rules/" + url.PathEscape(r.ID.String()),
+		Input:        r,
+		ExpectedCode: http.StatusNoContent,
+	})
+	return err
+}
+
+func (c *Client) GetErrorTrackingAssignmentRule(ctx context.Context, projectID ProjectID, ruleID ErrorTrackingRuleID) (*ErrorTrackingAssignmentRule, error) {
+	var res *ErrorTrackingAssignmentRule
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/error_tracking/assignment_rules/" + url.PathEscape(ruleID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
+
+func (c *Client) DeleteErrorTrackingAssignmentRule(ctx context.Context, projectID ProjectID, ruleID ErrorTrackingRuleID) error {
+	err := c.do(ctx, apiRequest{
+		Method:       "DELETE",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/error_tracking/assignment_rules/" + url.PathEscape(ruleID.String()),
+		ExpectedCode: http.StatusNoContent,
+	})
+	return err
+}
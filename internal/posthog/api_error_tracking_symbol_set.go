@@ -0,0 +1,116 @@
+package posthog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ErrorTrackingSymbolSetID is the UUID of a symbol set.
+type ErrorTrackingSymbolSetID string
+
+func (i ErrorTrackingSymbolSetID) String() string {
+	return string(i)
+}
+
+func ErrorTrackingSymbolSetIDFromString(s string) (ErrorTrackingSymbolSetID, error) {
+	if s == "" {
+		return "", errors.New("empty symbol set ID")
+	}
+
+	return ErrorTrackingSymbolSetID(s), nil
+}
+
+// ErrorTrackingSymbolSet holds the data used to resolve the stack traces of
+// exceptions (eg. a source map and its minified file), as bundled by the
+// PostHog CLI. Ref is the chunk ID the CLI injected in the minified file,
+// Release the optional ID of the release the symbol set belongs to.
+type ErrorTrackingSymbolSet struct {
+	ID            ErrorTrackingSymbolSetID `json:"id"`
+	Ref           string                   `json:"ref"`
+	Release       *string                  `json:"release"`
+	FailureReason *string                  `json:"failure_reason"`
+	CreatedAt     time.Time                `json:"created_at"`
+}
+
+// UploadErrorTrackingSymbolSet uploads a symbol set. The API doesn't return
+// the created symbol set, use GetErrorTrackingSymbolSetByRef to find it.
+func (c *Client) UploadErrorTrackingSymbolSet(ctx context.Context, projectID ProjectID, chunkID string, releaseID string, data []byte) error {
+	var buf bytes.Buffer
+
+	w := multipart.NewWriter(&buf)
+
+	fw, err := w.CreateFormFile("file", chunkID)
+	if err != nil {
+		return fmt.Errorf("error creating symbol set form field: %w", err)
+	}
+
+	if _, err := fw.Write(data); err != nil {
+		return fmt.Errorf("error writing symbol set: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("error finalizing form: %w", err)
+	}
+
+	query := url.Values{"chunk_id": {chunkID}}
+	if releaseID != "" {
+		query.Set("release_id", releaseID)
+	}
+
+	err = c.do(ctx, apiRequest{
+		Method:              "POST",
+		Path:                "/projects/" + url.PathEscape(projectID.String()) + "/error_tracking/symbol_sets?" + query.Encode(),
+		RawInput:            &buf,
+		RawInputContentType: w.FormDataContentType(),
+		ExpectedCode:        http.StatusCreated,
+	})
+	return err
+}
+
+func (c *Client) ListErrorTrackingSymbolSets(ctx context.Context, projectID ProjectID) ([]ErrorTrackingSymbolSet, error) {
+	return listAll[ErrorTrackingSymbolSet](ctx, c, "/projects/"+url.PathEscape(projectID.String())+"/error_tracking/symbol_sets")
+}
+
+// GetErrorTrackingSymbolSetByRef returns the symbol set with the given chunk
+// ID, or nil if there is none.
+func (c *Client) GetErrorTrackingSymbolSetByRef(ctx context.Context, projectID ProjectID, ref string) (*ErrorTrackingSymbolSet, error) {
+	symbolSets, err := c.ListErrorTrackingSymbolSets(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range symbolSets {
+		if s.Ref == ref {
+			return &s, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (c *Client) GetErrorTrackingSymbolSet(ctx context.Context, projectID ProjectID, symbolSetID ErrorTrackingSymbolSetID) (*ErrorTrackingSymbolSet, error) {
+	var res *ErrorTrackingSymbolSet
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/error_tracking/symbol_sets/" + url.PathEscape(symbolSetID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
+
+func (c *Client) DeleteErrorTrackingSymbolSet(ctx context.Context, projectID ProjectID, symbolSetID ErrorTrackingSymbolSetID) error {
+	err := c.do(ctx, apiRequest{
+		Method:       "DELETE",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/error_tracking/symbol_sets/" + url.PathEscape(symbolSetID.String()),
+		ExpectedCode: http.StatusNoContent,
+	})
+	return err
+}
@@ -0,0 +1,170 @@
+package posthog
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestErrorTrackingFiltersMarshalJSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		filters  ErrorTrackingFilters
+		expected string
+	}{
+		{
+			name:    "and",
+			filters: ErrorTrackingFilters{Type: PropertyGroupTypeAnd, Properties: []PropertyFilter{{Key: "$exception_types", Type: PropertyFilterTypeEvent, Operator: PropertyOperatorExact, Value: PropertyValue{"TypeError"}}}},
+			expected: `{"type":"AND","values":[{"type":"AND","values":[
+				{"key":"$exception_types","type":"event","operator":"exact","value":["TypeError"]}
+			]}]}`,
+		},
+		{
+			name: "or",
+			filters: ErrorTrackingFilters{Type: PropertyGroupTypeOr, Properties: []PropertyFilter{
+				{Key: "$exception_types", Type: PropertyFilterTypeEvent, Operator: PropertyOperatorExact, Value: PropertyValue{"TypeError"}},
+				{Key: "$exception_values", Type: PropertyFilterTypeEvent, Operator: PropertyOperatorIContains, Value: PropertyValue{"timeout"}},
+			}},
+			expected: `{"type":"OR","values":[{"type":"OR","values":[
+				{"key":"$exception_types","type":"event","operator":"exact","value":["TypeError"]},
+				{"key":"$exception_values","type":"event","operator":"icontains","value":"timeout"}
+			]}]}`,
+		},
+		{
+			name:     "default type",
+			filters:  ErrorTrackingFilters{},
+			expected: `{"type":"AND","values":[{"type":"AND","values":[]}]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := json.Marshal(tc.filters)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var expected bytes.Buffer
+			if err := json.Compact(&expected, []byte(tc.expected)); err != nil {
+				t.Fatalf("invalid expected JSON: %s", err)
+			}
+
+			if !bytes.Equal(got, expected.Bytes()) {
+				t.Errorf("unexpected JSON\nexpected: %s\n     got: %s", expected.String(), got)
+			}
+		})
+	}
+}
+
+func TestErrorTrackingFiltersUnmarshalJSON(t *testing.T) {
+	typeError := PropertyFilter{Key: "$exception_types", Type: PropertyFilterTypeEvent, Operator: PropertyOperatorExact, Value: PropertyValue{"TypeError"}}
+	timeout := PropertyFilter{Key: "$exception_values", Type: PropertyFilterTypeEvent, Operator: PropertyOperatorIContains, Value: PropertyValue{"timeout"}}
+
+	const (
+		typeErrorJSON = `{"key":"$exception_types","type":"event","operator":"exact","value":["TypeError"]}`
+		timeoutJSON   = `{"key":"$exception_values","type":"event","operator":"icontains","value":"timeout"}`
+	)
+
+	testCases := []struct {
+		name     string
+		json     string
+		expected ErrorTrackingFilters
+		err      string
+	}{
+		{
+			name:     "null",
+			json:     `null`,
+			expected: ErrorTrackingFilters{},
+		},
+		{
+			name:     "flat group",
+			json:     `{"type":"OR","values":[` + typeErrorJSON + `,` + timeoutJSON + `]}`,
+			expected: ErrorTrackingFilters{Type: PropertyGroupTypeOr, Properties: []PropertyFilter{typeError, timeout}},
+		},
+		{
+			name:     "web UI format",
+			json:     `{"type":"AND","values":[{"type":"OR","values":[` + typeErrorJSON + `,` + timeoutJSON + `]}]}`,
+			expected: ErrorTrackingFilters{Type: PropertyGroupTypeOr, Properties: []PropertyFilter{typeError, timeout}},
+		},
+		{
+			name:     "same nested types",
+			json:     `{"type":"AND","values":[{"type":"AND","values":[` + typeErrorJSON + `]},{"type":"AND","values":[` + timeoutJSON + `]}]}`,
+			expected: ErrorTrackingFilters{Type: PropertyGroupTypeAnd, Properties: []PropertyFilter{typeError, timeout}},
+		},
+		{
+			name:     "nested groups with a single value",
+			json:     `{"type":"AND","values":[{"type":"OR","values":[` + typeErrorJSON + `]},` + timeoutJSON + `]}`,
+			expected: ErrorTrackingFilters{Type: PropertyGroupTypeAnd, Properties: []PropertyFilter{typeError, timeout}},
+		},
+		{
+			name: "or of and groups",
+			json: `{"type":"OR","values":[{"type":"AND","values":[` + typeErrorJSON + `,` + timeoutJSON + `]},` + typeErrorJSON + `]}`,
+			err:  "AND group nested in an OR group",
+		},
+		{
+			name: "and of or groups",
+			json: `{"type":"AND","values":[{"type":"AND","values":[{"type":"OR","values":[` + typeErrorJSON + `,` + timeoutJSON + `]},` + timeoutJSON + `]}]}`,
+			err:  "OR group nested in an AND group",
+		},
+		{
+			name: "unsupported group type",
+			json: `{"type":"XOR","values":[]}`,
+			err:  `unsupported error tracking filter group type "XOR"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got ErrorTrackingFilters
+
+			err := json.Unmarshal([]byte(tc.json), &got)
+
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected error containing %q, got %v", tc.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("unexpected filters\nexpected: %+v\n     got: %+v", tc.expected, got)
+			}
+		})
+	}
+}
+
+// Filters sent to PostHog must be read back identically, or every plan would
+// show a difference.
+func TestErrorTrackingFiltersRoundTrip(t *testing.T) {
+	for _, filters := range []ErrorTrackingFilters{
+		{Type: PropertyGroupTypeAnd, Properties: []PropertyFilter{
+			{Key: "$exception_types", Type: PropertyFilterTypeEvent, Operator: PropertyOperatorExact, Value: PropertyValue{"TypeError", "RangeError"}},
+		}},
+		{Type: PropertyGroupTypeOr, Properties: []PropertyFilter{
+			{Key: "$exception_types", Type: PropertyFilterTypeEvent, Operator: PropertyOperatorExact, Value: PropertyValue{"TypeError"}},
+			{Key: "$exception_values", Type: PropertyFilterTypeEvent, Operator: PropertyOperatorRegex, Value: PropertyValue{"^timeout"}},
+			{Key: "$lib", Type: PropertyFilterTypeEvent, Operator: PropertyOperatorIsSet},
+		}},
+	} {
+		b, err := json.Marshal(filters)
+		if err != nil {
+			t.Fatalf("error marshaling filters: %s", err)
+		}
+
+		var decoded ErrorTrackingFilters
+		if err := json.Unmarshal(b, &decoded); err != nil {
+			t.Fatalf("error unmarshaling %s: %s", b, err)
+		}
+
+		if !reflect.DeepEqual(decoded, filters) {
+			t.Errorf("filters changed after a JSON round trip\nbefore: %+v\n after: %+v", filters, decoded)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package posthog
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// EventDefinitionID is the UUID of an event definition.
+type EventDefinitionID string
+
+func (i EventDefinitionID) String() string {
+	return string(i)
+}
+
+// EventDefinition holds the metadata of an event. Event definitions are
+// created by PostHog when ingesting the first event with a given name, they
+// can't be created through the API.
+type EventDefinition struct {
+	ID          EventDefinitionID `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Tags        []string          `json:"tags"`
+	Owner       *UserBasic        `json:"owner"`
+	Verified    bool              `json:"verified"`
+	Hidden      bool              `json:"hidden"`
+}
+
+type UpdateEventDefinitionRequest struct {
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+	Owner       *UserID  `json:"owner"`
+	Verified    bool     `json:"verified"`
+	Hidden      bool     `json:"hidden"`
+}
+
+func (c *Client) UpdateEventDefinition(ctx context.Context, projectID ProjectID, definitionID EventDefinitionID, d UpdateEventDefinitionRequest) (*EventDefinition, error) {
+	nilSliceToEmpty(&d.Tags)
+
+	var res *EventDefinition
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/event_definitions/" + url.PathEscape(definitionID.String()),
+		Input:        d,
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) GetEventDefinition(ctx context.Context, projectID ProjectID, definitionID EventDefinitionID) (*EventDefinition, error) {
+	var res *EventDefinition
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/event_definitions/" + url.PathEscape(definitionID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
+
+// GetEventDefinitionByName returns the definition of the event with the given
+// name, or nil if there is none.
+func (c *Client) GetEventDefinitionByName(ctx context.Context, projectID ProjectID, name string) (*EventDefinition, error) {
+	definitions, err := listAll[EventDefinition](ctx, c, "/projects/"+url.PathEscape(projectID.String())+"/event_definitions?search="+url.QueryEscape(name))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range definitions {
+		if d.Name == name {
+			return &d, nil
+		}
+	}
+
+	return nil, nil
+}
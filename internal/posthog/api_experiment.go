@@ -0,0 +1,143 @@
+package posthog
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type ExperimentID uint64
+
+func (i ExperimentID) String() string {
+	return strconv.FormatUint(uint64(i), 10)
+}
+
+func ExperimentIDFromString(s string) (ExperimentID, error) {
+	res, err := strconv.ParseUint(s, 10, 64)
+	return ExperimentID(res), err
+}
+
+type ExperimentHoldoutID uint64
+
+func (i ExperimentHoldoutID) String() string {
+	return strconv.FormatUint(uint64(i), 10)
+}
+
+func ExperimentHoldoutIDFromString(s string) (ExperimentHoldoutID, error) {
+	res, err := strconv.ParseUint(s, 10, 64)
+	return ExperimentHoldoutID(res), err
+}
+
+// ExperimentParameters holds the variants of the feature flag created for the
+// experiment, and the minimum detectable effect (in percents) used to compute
+// the recommended sample size.
+type ExperimentParameters struct {
+	FeatureFlagVariants     []ExperimentVariant `json:"feature_flag_variants,omitempty"`
+	MinimumDetectableEffect *int64              `json:"minimum_detectable_effect,omitempty"`
+}
+
+type ExperimentVariant struct {
+	Key               string `json:"key"`
+	RolloutPercentage int64  `json:"rollout_percentage"`
+}
+
+type ExperimentHoldout struct {
+	ID   ExperimentHoldoutID `json:"id"`
+	Name string              `json:"name"`
+}
+
+type ExperimentFeatureFlag struct {
+	ID  FeatureFlagID `json:"id"`
+	Key string        `json:"key"`
+}
+
+// CreateExperimentRequest creates an experiment, and its feature flag if no
+// flag exists with the given key. Metrics are queries, sent as raw JSON.
+type CreateExperimentRequest struct {
+	Name             string               `json:"name"`
+	Description      string               `json:"description"`
+	FeatureFlagKey   string               `json:"feature_flag_key"`
+	Parameters       ExperimentParameters `json:"parameters"`
+	Metrics          []json.RawMessage    `json:"metrics"`
+	MetricsSecondary []json.RawMessage    `json:"metrics_secondary"`
+	StartDate        *time.Time           `json:"start_date"`
+	EndDate          *time.Time           `json:"end_date"`
+	HoldoutID        *ExperimentHoldoutID `json:"holdout_id"`
+	Archived         bool                 `json:"archived"`
+}
+
+type Experiment struct {
+	ID               ExperimentID           `json:"id"`
+	Name             string                 `json:"name"`
+	Description      string                 `json:"description"`
+	FeatureFlagKey   string                 `json:"feature_flag_key,omitempty"`
+	FeatureFlag      *ExperimentFeatureFlag `json:"feature_flag,omitempty"`
+	Parameters       ExperimentParameters   `json:"parameters"`
+	Metrics          []json.RawMessage      `json:"metrics"`
+	MetricsSecondary []json.RawMessage      `json:"metrics_secondary"`
+	StartDate        *time.Time             `json:"start_date"`
+	EndDate          *time.Time             `json:"end_date"`
+	Holdout          *ExperimentHoldout     `json:"holdout,omitempty"`
+	HoldoutID        *ExperimentHoldoutID   `json:"holdout_id"`
+	Archived         bool                   `json:"archived"`
+	CreatedAt        time.Time              `json:"created_at"`
+}
+
+func (c *Client) CreateExperiment(ctx context.Context, projectID ProjectID, e CreateExperimentRequest) (*Experiment, error) {
+	nilSliceToEmpty(&e.Metrics)
+	nilSliceToEmpty(&e.MetricsSecondary)
+
+	var res *Experiment
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/experiments",
+		Input:        e,
+		ExpectedCode: http.StatusCreated,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) UpdateExperiment(ctx context.Context, projectID ProjectID, e Experiment) (*Experiment, error) {
+	nilSliceToEmpty(&e.Metrics)
+	nilSliceToEmpty(&e.MetricsSecondary)
+
+	// read only fields, the flag of an experiment can't be changed
+	e.FeatureFlagKey = ""
+	e.FeatureFlag = nil
+	e.Holdout = nil
+
+	var res *Experiment
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/experiments/" + url.PathEscape(e.ID.String()),
+		Input:        e,
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) GetExperiment(ctx context.Context, projectID ProjectID, experimentID ExperimentID) (*Experiment, error) {
+	var res *Experiment
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/experiments/" + url.PathEscape(experimentID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
+
+func (c *Client) DeleteExperiment(ctx context.Context, projectID ProjectID, experimentID ExperimentID) error {
+	err := c.do(ctx, apiRequest{
+		Method:       "DELETE",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/experiments/" + url.PathEscape(experimentID.String()),
+		ExpectedCode: http.StatusNoContent,
+	})
+	return err
+}
@@ -0,0 +1,172 @@
+package posthog
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type FeatureFlagID uint64
+
+func (i FeatureFlagID) String() string {
+	return strconv.FormatUint(uint64(i), 10)
+}
+
+func FeatureFlagIDFromString(s string) (FeatureFlagID, error) {
+	res, err := strconv.ParseUint(s, 10, 64)
+	return FeatureFlagID(res), err
+}
+
+type FeatureFlagFilters struct {
+	Groups                    []FeatureFlagGroup       `json:"groups"`
+	Multivariate              *FeatureFlagMultivariate `json:"multivariate"`
+	Payloads                  FeatureFlagPayloads      `json:"payloads"`
+	AggregationGroupTypeIndex *int64                   `json:"aggregation_group_type_index"`
+
+	// SuperGroups are the conditions added by PostHog when the flag is linked
+	// to an early access feature, enabling it for the users who opted in.
+	// They are not managed by the provider, but must be sent back as is when
+	// updating the filters.
+	SuperGroups json.RawMessage `json:"super_groups,omitempty"`
+}
+
+// FeatureFlagGroup is a release condition: users matching all the properties
+// get the flag enabled, subject to the rollout percentage.
+type FeatureFlagGroup struct {
+	Properties        []PropertyFilter `json:"properties"`
+	RolloutPercentage *float64         `json:"rollout_percentage"`
+	Variant           *string          `json:"variant"`
+}
+
+type FeatureFlagMultivariate struct {
+	Variants []FeatureFlagVariant `json:"variants"`
+}
+
+type FeatureFlagVariant struct {
+	Key               string `json:"key"`
+	Name              string `json:"name"`
+	RolloutPercentage int64  `json:"rollout_percentage"`
+}
+
+// FeatureFlagPayloads maps a variant key (or "true" for boolean flags) to a
+// JSON payload.
+//
+// Payloads are sent as JSON encoded strings, but older flags might store them
+// as raw JSON values.
+type FeatureFlagPayloads map[string]string
+
+func (p *FeatureFlagPayloads) UnmarshalJSON(b []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	if raw == nil {
+		*p = nil
+		return nil
+	}
+
+	res := make(FeatureFlagPayloads, len(raw))
+	for k, v := range raw {
+		var s string
+		if err := json.Unmarshal(v, &s); err == nil {
+			res[k] = s
+		} else {
+			res[k] = string(v)
+		}
+	}
+
+	*p = res
+	return nil
+}
+
+type CreateFeatureFlagRequest struct {
+	Key                        string             `json:"key"`
+	Name                       string             `json:"name"`
+	Filters                    FeatureFlagFilters `json:"filters"`
+	Active                     bool               `json:"active"`
+	EnsureExperienceContinuity bool               `json:"ensure_experience_continuity"`
+}
+
+type FeatureFlag struct {
+	ID                         FeatureFlagID      `json:"id"`
+	Key                        string             `json:"key"`
+	Name                       string             `json:"name"`
+	Filters                    FeatureFlagFilters `json:"filters"`
+	Active                     bool               `json:"active"`
+	EnsureExperienceContinuity bool               `json:"ensure_experience_continuity"`
+	Deleted                    bool               `json:"deleted"`
+	CreatedAt                  time.Time          `json:"created_at"`
+}
+
+func (f *FeatureFlagFilters) normalize() {
+	nilSliceToEmpty(&f.Groups)
+
+	for i := range f.Groups {
+		nilSliceToEmpty(&f.Groups[i].Properties)
+	}
+
+	if f.Payloads == nil {
+		f.Payloads = FeatureFlagPayloads{}
+	}
+}
+
+func (c *Client) CreateFeatureFlag(ctx context.Context, projectID ProjectID, f CreateFeatureFlagRequest) (*FeatureFlag, error) {
+	f.Filters.normalize()
+
+	var res *FeatureFlag
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/feature_flags",
+		Input:        f,
+		ExpectedCode: http.StatusCreated,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) UpdateFeatureFlag(ctx context.Context, projectID ProjectID, f FeatureFlag) (*FeatureFlag, error) {
+	f.Filters.normalize()
+
+	var res *FeatureFlag
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/feature_flags/" + url.PathEscape(f.ID.String()),
+		Input:        f,
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) GetFeatureFlag(ctx context.Context, projectID ProjectID, flagID FeatureFlagID) (*FeatureFlag, error) {
+	var res *FeatureFlag
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/feature_flags/" + url.PathEscape(flagID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
+
+// GetFeatureFlagByKey returns the (non deleted) feature flag with the given
+// key, or nil if there is none.
+func (c *Client) GetFeatureFlagByKey(ctx context.Context, projectID ProjectID, key string) (*FeatureFlag, error) {
+	flags, err := listAll[FeatureFlag](ctx, c, "/projects/"+url.PathEscape(projectID.String())+"/feature_flags?search="+url.QueryEscape(key))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range flags {
+		if f.Key == key && !f.Deleted {
+			return &f, nil
+		}
+	}
+
+	return nil, nil
+}
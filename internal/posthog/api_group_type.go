@@ -0,0 +1,70 @@
+package posthog
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// GroupType maps a group type name (eg. "company") to its index, used to
+// reference group types in queries and feature flags. Group types are created
+// by PostHog when the first event with a given group type is ingested, they
+// can't be created through the API.
+type GroupType struct {
+	GroupType      string  `json:"group_type"`
+	GroupTypeIndex int64   `json:"group_type_index"`
+	NameSingular   *string `json:"name_singular"`
+	NamePlural     *string `json:"name_plural"`
+}
+
+func (c *Client) ListGroupTypes(ctx context.Context, projectID ProjectID) ([]GroupType, error) {
+	var res []GroupType
+	err := c.do(ctx, apiRequest{
+		Method:       "GET",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/groups_types",
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	return res, err
+}
+
+// GetGroupTypeByName returns the group type with the given name, or nil if
+// there is none.
+func (c *Client) GetGroupTypeByName(ctx context.Context, projectID ProjectID, name string) (*GroupType, error) {
+	groupTypes, err := c.ListGroupTypes(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, g := range groupTypes {
+		if g.GroupType == name {
+			return &g, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// UpdateGroupTypeMetadata updates the display names of a group type,
+// identified by its index.
+func (c *Client) UpdateGroupTypeMetadata(ctx context.Context, projectID ProjectID, g GroupType) (*GroupType, error) {
+	var res []GroupType
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/groups_types/update_metadata",
+		Input:        []GroupType{g},
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range res {
+		if r.GroupTypeIndex == g.GroupTypeIndex {
+			return &r, nil
+		}
+	}
+
+	return &g, nil
+}
@@ -0,0 +1,174 @@
+package posthog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// HogFunctionID is the UUID of a Hog function.
+type HogFunctionID string
+
+func (i HogFunctionID) String() string {
+	return string(i)
+}
+
+func HogFunctionIDFromString(s string) (HogFunctionID, error) {
+	if s == "" {
+		return "", errors.New("empty Hog function ID")
+	}
+
+	return HogFunctionID(s), nil
+}
+
+type HogFunctionType string
+
+const (
+	HogFunctionTypeDestination     HogFunctionType = "destination"
+	HogFunctionTypeTransformation  HogFunctionType = "transformation"
+	HogFunctionTypeSiteDestination HogFunctionType = "site_destination"
+)
+
+// HogFunctionInputSchema describes an input of a Hog function. Secret inputs
+// are never returned by the API once set.
+type HogFunctionInputSchema struct {
+	Key         string          `json:"key"`
+	Type        string          `json:"type"`
+	Label       string          `json:"label,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Required    bool            `json:"required"`
+	Secret      bool            `json:"secret"`
+	Default     json.RawMessage `json:"default,omitempty"`
+}
+
+// HogFunctionInput is the value of an input. When reading secret inputs, the
+// value is not set and Secret is true instead.
+type HogFunctionInput struct {
+	Value  json.RawMessage `json:"value,omitempty"`
+	Secret bool            `json:"secret,omitempty"`
+}
+
+// HasValue tells whether the input is set to a non null value.
+func (i HogFunctionInput) HasValue() bool {
+	return len(i.Value) > 0 && string(i.Value) != "null"
+}
+
+// HogFunctionFilters selects the events a Hog function runs on: events
+// matching any of the events or actions, and all the properties.
+type HogFunctionFilters struct {
+	Events             []EntityFilter    `json:"events,omitempty"`
+	Actions            []EntityFilter    `json:"actions,omitempty"`
+	Properties         InsightProperties `json:"properties,omitempty"`
+	FilterTestAccounts bool              `json:"filter_test_accounts,omitempty"`
+}
+
+// HogFunctionMasking limits how often a Hog function runs: it runs at most
+// once per TTL (in seconds) for events with the same value of the Hash
+// expression. If Threshold is set, it runs again every Threshold events.
+type HogFunctionMasking struct {
+	TTL       int64  `json:"ttl"`
+	Threshold *int64 `json:"threshold"`
+	Hash      string `json:"hash"`
+}
+
+type CreateHogFunctionRequest struct {
+	Type           HogFunctionType             `json:"type"`
+	Name           string                      `json:"name"`
+	Description    string                      `json:"description"`
+	Enabled        bool                        `json:"enabled"`
+	TemplateID     string                      `json:"template_id,omitempty"`
+	Hog            string                      `json:"hog,omitempty"`
+	InputsSchema   []HogFunctionInputSchema    `json:"inputs_schema,omitempty"`
+	Inputs         map[string]HogFunctionInput `json:"inputs"`
+	Filters        *HogFunctionFilters         `json:"filters"`
+	Masking        *HogFunctionMasking         `json:"masking"`
+	ExecutionOrder *int64                      `json:"execution_order,omitempty"`
+}
+
+// HogFunction is a function written in Hog, PostHog's scripting language,
+// run on incoming events. Destinations send events to other services,
+// transformations modify events before they are ingested, site destinations
+// run in the browser.
+//
+// When the function is created from a template, the source code and inputs
+// schema are copied from the template if they are not set.
+type HogFunction struct {
+	ID             HogFunctionID               `json:"id"`
+	Type           HogFunctionType             `json:"type"`
+	Name           string                      `json:"name"`
+	Description    string                      `json:"description"`
+	Enabled        bool                        `json:"enabled"`
+	TemplateID     string                      `json:"template_id,omitempty"`
+	Hog            string                      `json:"hog,omitempty"`
+	InputsSchema   []HogFunctionInputSchema    `json:"inputs_schema,omitempty"`
+	Inputs         map[string]HogFunctionInput `json:"inputs"`
+	Filters        *HogFunctionFilters         `json:"filters"`
+	Masking        *HogFunctionMasking         `json:"masking"`
+	ExecutionOrder *int64                      `json:"execution_order,omitempty"`
+	Deleted        bool                        `json:"deleted"`
+}
+
+func (c *Client) CreateHogFunction(ctx context.Context, projectID ProjectID, f CreateHogFunctionRequest) (*HogFunction, error) {
+	var res *HogFunction
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/hog_functions",
+		Input:        f,
+		ExpectedCode: http.StatusCreated,
+		Output:       &res,
+	})
+	return res, err
+}
+
+// UpdateHogFunction updates a Hog function. The inputs schema is left
+// unchanged if f.InputsSchema is nil.
+func (c *Client) UpdateHogFunction(ctx context.Context, projectID ProjectID, f HogFunction) (*HogFunction, error) {
+	var res *HogFunction
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/hog_functions/" + url.PathEscape(f.ID.String()),
+		Input:        f,
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) GetHogFunction(ctx context.Context, projectID ProjectID, hogFunctionID HogFunctionID) (*HogFunction, error) {
+	var res *HogFunction
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/hog_functions/" + url.PathEscape(hogFunctionID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
+
+// HogFunctionTemplate is a predefined Hog function (eg. a Slack or webhook
+// destination) that Hog functions can be created from.
+type HogFunctionTemplate struct {
+	ID           string                   `json:"id"`
+	Name         string                   `json:"name"`
+	Description  string                   `json:"description"`
+	Type         HogFunctionType          `json:"type"`
+	Status       string                   `json:"status"`
+	Hog          string                   `json:"hog"`
+	InputsSchema []HogFunctionInputSchema `json:"inputs_schema"`
+	Filters      *HogFunctionFilters      `json:"filters"`
+}
+
+func (c *Client) GetHogFunctionTemplate(ctx context.Context, projectID ProjectID, templateID string) (*HogFunctionTemplate, error) {
+	var res *HogFunctionTemplate
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/hog_function_templates/" + url.PathEscape(templateID),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
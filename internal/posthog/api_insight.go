@@ -0,0 +1,115 @@
+package posthog
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type InsightID uint64
+
+func (i InsightID) String() string {
+	return strconv.FormatUint(uint64(i), 10)
+}
+
+func InsightIDFromString(s string) (InsightID, error) {
+	res, err := strconv.ParseUint(s, 10, 64)
+	return InsightID(res), err
+}
+
+type CreateInsightRequest struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Tags        []string        `json:"tags"`
+	Saved       bool            `json:"saved"`
+	Dashboards  []DashboardID   `json:"dashboards,omitempty"`
+	Query       json.RawMessage `json:"query"`
+}
+
+// Insight is a saved query and its visualization. The dashboards the insight
+// is on are not modified by UpdateInsight, use SetInsightDashboards instead.
+type Insight struct {
+	ID          InsightID       `json:"id"`
+	ShortID     string          `json:"short_id,omitempty"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Tags        []string        `json:"tags"`
+	Saved       bool            `json:"saved"`
+	Dashboards  []DashboardID   `json:"dashboards,omitempty"`
+	Query       json.RawMessage `json:"query,omitempty"`
+	Deleted     bool            `json:"deleted"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+func (c *Client) CreateInsight(ctx context.Context, projectID ProjectID, i CreateInsightRequest) (*Insight, error) {
+	nilSliceToEmpty(&i.Tags)
+
+	var res *Insight
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/insights",
+		Input:        i,
+		ExpectedCode: http.StatusCreated,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) UpdateInsight(ctx context.Context, projectID ProjectID, i Insight) (*Insight, error) {
+	nilSliceToEmpty(&i.Tags)
+	i.Dashboards = nil
+
+	var res *Insight
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/insights/" + url.PathEscape(i.ID.String()),
+		Input:        i,
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) GetInsight(ctx context.Context, projectID ProjectID, insightID InsightID) (*Insight, error) {
+	var res *Insight
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/insights/" + url.PathEscape(insightID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
+
+type insightDashboards struct {
+	Dashboards []DashboardID `json:"dashboards"`
+}
+
+// GetInsightDashboards returns the IDs of the dashboards an insight is on.
+func (c *Client) GetInsightDashboards(ctx context.Context, projectID ProjectID, insightID InsightID) ([]DashboardID, error) {
+	var res insightDashboards
+	err := c.do(ctx, apiRequest{
+		Method:       "GET",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/insights/" + url.PathEscape(insightID.String()),
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	return res.Dashboards, err
+}
+
+// SetInsightDashboards sets the list of dashboards an insight is on, adding
+// or removing the corresponding dashboard tiles.
+func (c *Client) SetInsightDashboards(ctx context.Context, projectID ProjectID, insightID InsightID, dashboardIDs []DashboardID) error {
+	nilSliceToEmpty(&dashboardIDs)
+
+	return c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/insights/" + url.PathEscape(insightID.String()),
+		Input:        insightDashboards{Dashboards: dashboardIDs},
+		ExpectedCode: http.StatusOK,
+	})
+}
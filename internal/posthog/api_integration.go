@@ -0,0 +1,92 @@
+package posthog
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type IntegrationID uint64
+
+func (i IntegrationID) String() string {
+	return strconv.FormatUint(uint64(i), 10)
+}
+
+func IntegrationIDFromString(s string) (IntegrationID, error) {
+	res, err := strconv.ParseUint(s, 10, 64)
+	return IntegrationID(res), err
+}
+
+type IntegrationKind string
+
+// Kinds of integrations connected through OAuth, which can only be created
+// from the web UI.
+const (
+	IntegrationKindSlack      IntegrationKind = "slack"
+	IntegrationKindSalesforce IntegrationKind = "salesforce"
+	IntegrationKindHubSpot    IntegrationKind = "hubspot"
+	IntegrationKindGoogleAds  IntegrationKind = "google-ads"
+	IntegrationKindLinkedIn   IntegrationKind = "linkedin-ads"
+	IntegrationKindSnapchat   IntegrationKind = "snapchat"
+	IntegrationKindIntercom   IntegrationKind = "intercom"
+	IntegrationKindLinear     IntegrationKind = "linear"
+	IntegrationKindGitHub     IntegrationKind = "github"
+)
+
+type CreateIntegrationRequest struct {
+	Kind            IntegrationKind `json:"kind"`
+	Config          json.RawMessage `json:"config"`
+	SensitiveConfig json.RawMessage `json:"sensitive_config,omitempty"`
+}
+
+// Integration connects a project to an external service (eg. a Slack
+// workspace), for use by Hog functions and subscriptions. The sensitive
+// config is never returned by the API. Integrations can't be updated.
+type Integration struct {
+	ID          IntegrationID   `json:"id"`
+	Kind        IntegrationKind `json:"kind"`
+	Config      json.RawMessage `json:"config"`
+	DisplayName string          `json:"display_name"`
+	Errors      string          `json:"errors"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+func (c *Client) CreateIntegration(ctx context.Context, projectID ProjectID, i CreateIntegrationRequest) (*Integration, error) {
+	var res *Integration
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/integrations",
+		Input:        i,
+		ExpectedCode: http.StatusCreated,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) ListIntegrations(ctx context.Context, projectID ProjectID) ([]Integration, error) {
+	return listAll[Integration](ctx, c, "/projects/"+url.PathEscape(projectID.String())+"/integrations")
+}
+
+func (c *Client) GetIntegration(ctx context.Context, projectID ProjectID, integrationID IntegrationID) (*Integration, error) {
+	var res *Integration
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/integrations/" + url.PathEscape(integrationID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
+
+func (c *Client) DeleteIntegration(ctx context.Context, projectID ProjectID, integrationID IntegrationID) error {
+	err := c.do(ctx, apiRequest{
+		Method:       "DELETE",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/integrations/" + url.PathEscape(integrationID.String()),
+		ExpectedCode: http.StatusNoContent,
+	})
+	return err
+}
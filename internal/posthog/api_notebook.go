@@ -0,0 +1,92 @@
+package posthog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// NotebookID is the short ID of a notebook, which is used in API paths and
+// web UI URLs instead of its UUID.
+type NotebookID string
+
+func (i NotebookID) String() string {
+	return string(i)
+}
+
+func NotebookIDFromString(s string) (NotebookID, error) {
+	if s == "" {
+		return "", errors.New("empty notebook ID")
+	}
+
+	return NotebookID(s), nil
+}
+
+// ErrNotebookConflict is returned by UpdateNotebook when the notebook was
+// modified since the version the update is based on.
+var ErrNotebookConflict = errors.New("the notebook was modified concurrently")
+
+type CreateNotebookRequest struct {
+	Title   string          `json:"title"`
+	Content json.RawMessage `json:"content"`
+}
+
+// Notebook is a document made of text and embedded PostHog objects, stored as
+// a ProseMirror document. Its version is incremented by each update, updates
+// must send the version they are based on.
+type Notebook struct {
+	ID          NotebookID      `json:"short_id"`
+	Title       string          `json:"title"`
+	Content     json.RawMessage `json:"content,omitempty"`
+	TextContent string          `json:"text_content,omitempty"`
+	Version     int64           `json:"version"`
+	Deleted     bool            `json:"deleted"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+func (c *Client) CreateNotebook(ctx context.Context, projectID ProjectID, n CreateNotebookRequest) (*Notebook, error) {
+	var res *Notebook
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/notebooks",
+		Input:        n,
+		ExpectedCode: http.StatusCreated,
+		Output:       &res,
+	})
+	return res, err
+}
+
+// UpdateNotebook updates a notebook, failing with ErrNotebookConflict if its
+// current version is not n.Version.
+func (c *Client) UpdateNotebook(ctx context.Context, projectID ProjectID, n Notebook) (*Notebook, error) {
+	var res *Notebook
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/notebooks/" + url.PathEscape(n.ID.String()),
+		Input:        n,
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+
+	var statusErr *StatusCodeError
+	if errors.As(err, &statusErr) && statusErr.Got == http.StatusConflict {
+		return nil, ErrNotebookConflict
+	}
+
+	return res, err
+}
+
+func (c *Client) GetNotebook(ctx context.Context, projectID ProjectID, notebookID NotebookID) (*Notebook, error) {
+	var res *Notebook
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/notebooks/" + url.PathEscape(notebookID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
@@ -0,0 +1,225 @@
+package posthog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// OrganizationID is the UUID of an organization, or OrganizationIDCurrent.
+type OrganizationID string
+
+// OrganizationIDCurrent designates the organization of the project the API
+// key belongs to.
+const OrganizationIDCurrent OrganizationID = "@current"
+
+func (i OrganizationID) String() string {
+	return string(i)
+}
+
+func OrganizationIDFromString(s string) (OrganizationID, error) {
+	if s == "" {
+		return "", errors.New("empty organization ID")
+	}
+
+	return OrganizationID(s), nil
+}
+
+// OrganizationMembershipLevel is the level of a member in an organization.
+// The member and admin levels are also used for the access to private
+// projects.
+type OrganizationMembershipLevel int64
+
+const (
+	OrganizationMembershipLevelMember OrganizationMembershipLevel = 1
+	OrganizationMembershipLevelAdmin  OrganizationMembershipLevel = 8
+	OrganizationMembershipLevelOwner  OrganizationMembershipLevel = 15
+)
+
+var organizationMembershipLevelNames = map[OrganizationMembershipLevel]string{
+	OrganizationMembershipLevelMember: "member",
+	OrganizationMembershipLevelAdmin:  "admin",
+	OrganizationMembershipLevelOwner:  "owner",
+}
+
+func (l OrganizationMembershipLevel) String() string {
+	if name, ok := organizationMembershipLevelNames[l]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("level %d", int64(l))
+}
+
+func OrganizationMembershipLevelFromString(s string) (OrganizationMembershipLevel, error) {
+	for level, name := range organizationMembershipLevelNames {
+		if name == s {
+			return level, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown membership level %q", s)
+}
+
+// OrganizationInviteID is the UUID of an organization invite.
+type OrganizationInviteID string
+
+func (i OrganizationInviteID) String() string {
+	return string(i)
+}
+
+func OrganizationInviteIDFromString(s string) (OrganizationInviteID, error) {
+	if s == "" {
+		return "", errors.New("empty organization invite ID")
+	}
+
+	return OrganizationInviteID(s), nil
+}
+
+type OrganizationInviteProjectAccess struct {
+	ID    ProjectID                   `json:"id"`
+	Level OrganizationMembershipLevel `json:"level"`
+}
+
+type CreateOrganizationInviteRequest struct {
+	TargetEmail          string                            `json:"target_email"`
+	Level                OrganizationMembershipLevel       `json:"level"`
+	Message              string                            `json:"message,omitempty"`
+	PrivateProjectAccess []OrganizationInviteProjectAccess `json:"private_project_access"`
+	SendEmail            bool                              `json:"send_email"`
+}
+
+// OrganizationInvite is a pending invite to join an organization. Invites
+// can't be updated, and are deleted once accepted.
+type OrganizationInvite struct {
+	ID                   OrganizationInviteID              `json:"id"`
+	TargetEmail          string                            `json:"target_email"`
+	Level                OrganizationMembershipLevel       `json:"level"`
+	Message              string                            `json:"message"`
+	PrivateProjectAccess []OrganizationInviteProjectAccess `json:"private_project_access"`
+	IsExpired            bool                              `json:"is_expired"`
+	CreatedAt            time.Time                         `json:"created_at"`
+}
+
+func (c *Client) CreateOrganizationInvite(ctx context.Context, organizationID OrganizationID, i CreateOrganizationInviteRequest) (*OrganizationInvite, error) {
+	nilSliceToEmpty(&i.PrivateProjectAccess)
+
+	var res *OrganizationInvite
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/organizations/" + url.PathEscape(organizationID.String()) + "/invites",
+		Input:        i,
+		ExpectedCode: http.StatusCreated,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) ListOrganizationInvites(ctx context.Context, organizationID OrganizationID) ([]OrganizationInvite, error) {
+	return listAll[OrganizationInvite](ctx, c, "/organizations/"+url.PathEscape(organizationID.String())+"/invites")
+}
+
+// GetOrganizationInvite returns nil if there is no pending invite with the
+// given ID, the API has no endpoint to get a single invite.
+func (c *Client) GetOrganizationInvite(ctx context.Context, organizationID OrganizationID, inviteID OrganizationInviteID) (*OrganizationInvite, error) {
+	invites, err := c.ListOrganizationInvites(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range invites {
+		if i.ID == inviteID {
+			return &i, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (c *Client) DeleteOrganizationInvite(ctx context.Context, organizationID OrganizationID, inviteID OrganizationInviteID) error {
+	err := c.do(ctx, apiRequest{
+		Method:       "DELETE",
+		Path:         "/organizations/" + url.PathEscape(organizationID.String()) + "/invites/" + url.PathEscape(inviteID.String()),
+		ExpectedCode: http.StatusNoContent,
+	})
+	return err
+}
+
+// OrganizationMember is the membership of a user in an organization.
+// Memberships can't be created through the API, users join organizations by
+// accepting invites.
+type OrganizationMember struct {
+	ID       string                      `json:"id"`
+	User     UserBasic                   `json:"user"`
+	Level    OrganizationMembershipLevel `json:"level"`
+	JoinedAt time.Time                   `json:"joined_at"`
+}
+
+type updateOrganizationMemberRequest struct {
+	Level OrganizationMembershipLevel `json:"level"`
+}
+
+func (c *Client) ListOrganizationMembers(ctx context.Context, organizationID OrganizationID) ([]OrganizationMember, error) {
+	return listAll[OrganizationMember](ctx, c, "/organizations/"+url.PathEscape(organizationID.String())+"/members")
+}
+
+// GetOrganizationMemberByEmail returns nil if no member of the organization
+// has the given email.
+func (c *Client) GetOrganizationMemberByEmail(ctx context.Context, organizationID OrganizationID, email string) (*OrganizationMember, error) {
+	members, err := c.ListOrganizationMembers(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range members {
+		if m.User.Email == email {
+			return &m, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// GetOrganizationMember returns the member with the given user UUID, or nil
+// if the user is not a member of the organization.
+func (c *Client) GetOrganizationMember(ctx context.Context, organizationID OrganizationID, userUUID string) (*OrganizationMember, error) {
+	members, err := c.ListOrganizationMembers(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range members {
+		if m.User.UUID == userUUID {
+			return &m, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// UpdateOrganizationMemberLevel changes the level of a member, identified by
+// the UUID of the user.
+func (c *Client) UpdateOrganizationMemberLevel(ctx context.Context, organizationID OrganizationID, userUUID string, level OrganizationMembershipLevel) (*OrganizationMember, error) {
+	var res *OrganizationMember
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/organizations/" + url.PathEscape(organizationID.String()) + "/members/" + url.PathEscape(userUUID),
+		Input:        updateOrganizationMemberRequest{Level: level},
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	return res, err
+}
+
+// DeleteOrganizationMember removes a user, identified by its UUID, from the
+// organization.
+func (c *Client) DeleteOrganizationMember(ctx context.Context, organizationID OrganizationID, userUUID string) error {
+	err := c.do(ctx, apiRequest{
+		Method:       "DELETE",
+		Path:         "/organizations/" + url.PathEscape(organizationID.String()) + "/members/" + url.PathEscape(userUUID),
+		ExpectedCode: http.StatusNoContent,
+	})
+	return err
+}
@@ -77,6 +77,7 @@ type Project struct {
 	RecordingDomains            []string                       `json:"recording_domains"`
 	AccessControl               bool                           `json:"access_control"`
 	APIToken                    string                         `json:"api_token"`
+	Organization                OrganizationID                 `json:"organization,omitempty"`
 	CompletedSnippetOnboarding  bool                           `json:"completed_snippet_onboarding"`
 	CreatedAt                   time.Time                      `json:"created_at"`
 	UpdatedAt                   time.Time                      `json:"updated_at"`
@@ -0,0 +1,107 @@
+package posthog
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// PropertyDefinitionID is the UUID of a property definition.
+type PropertyDefinitionID string
+
+func (i PropertyDefinitionID) String() string {
+	return string(i)
+}
+
+type PropertyDefinitionType string
+
+const (
+	PropertyDefinitionTypeEvent  PropertyDefinitionType = "event"
+	PropertyDefinitionTypePerson PropertyDefinitionType = "person"
+	PropertyDefinitionTypeGroup  PropertyDefinitionType = "group"
+)
+
+// PropertyType is the type of the values of a property, used to pick the
+// operators available when filtering on it.
+type PropertyType string
+
+const (
+	PropertyTypeString   PropertyType = "String"
+	PropertyTypeNumeric  PropertyType = "Numeric"
+	PropertyTypeBoolean  PropertyType = "Boolean"
+	PropertyTypeDateTime PropertyType = "DateTime"
+)
+
+// PropertyDefinition holds the metadata of an event, person or group
+// property. Like event definitions, they are created by PostHog during
+// ingestion.
+type PropertyDefinition struct {
+	ID           PropertyDefinitionID `json:"id"`
+	Name         string               `json:"name"`
+	Description  string               `json:"description"`
+	Tags         []string             `json:"tags"`
+	PropertyType *PropertyType        `json:"property_type"`
+	Verified     bool                 `json:"verified"`
+	Hidden       bool                 `json:"hidden"`
+}
+
+type UpdatePropertyDefinitionRequest struct {
+	Description  string        `json:"description"`
+	Tags         []string      `json:"tags"`
+	PropertyType *PropertyType `json:"property_type,omitempty"`
+	Verified     bool          `json:"verified"`
+	Hidden       bool          `json:"hidden"`
+}
+
+func (c *Client) UpdatePropertyDefinition(ctx context.Context, projectID ProjectID, definitionID PropertyDefinitionID, d UpdatePropertyDefinitionRequest) (*PropertyDefinition, error) {
+	nilSliceToEmpty(&d.Tags)
+
+	var res *PropertyDefinition
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/property_definitions/" + url.PathEscape(definitionID.String()),
+		Input:        d,
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) GetPropertyDefinition(ctx context.Context, projectID ProjectID, definitionID PropertyDefinitionID) (*PropertyDefinition, error) {
+	var res *PropertyDefinition
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/property_definitions/" + url.PathEscape(definitionID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
+
+// GetPropertyDefinitionByName returns the definition of the property with the
+// given name and type, or nil if there is none. groupTypeIndex is only used
+// for group properties.
+func (c *Client) GetPropertyDefinitionByName(ctx context.Context, projectID ProjectID, definitionType PropertyDefinitionType, groupTypeIndex int64, name string) (*PropertyDefinition, error) {
+	query := url.Values{}
+	query.Set("search", name)
+	query.Set("type", string(definitionType))
+
+	if definitionType == PropertyDefinitionTypeGroup {
+		query.Set("group_type_index", strconv.FormatInt(groupTypeIndex, 10))
+	}
+
+	definitions, err := listAll[PropertyDefinition](ctx, c, "/projects/"+url.PathEscape(projectID.String())+"/property_definitions?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range definitions {
+		if d.Name == name {
+			return &d, nil
+		}
+	}
+
+	return nil, nil
+}
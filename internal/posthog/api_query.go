@@ -0,0 +1,52 @@
+package posthog
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// HogQLQueryError is an error found in a HogQL query. Start and End are the
+// byte offsets of the faulty part of the query, if known.
+type HogQLQueryError struct {
+	Message string `json:"message"`
+	Start   *int64 `json:"start"`
+	End     *int64 `json:"end"`
+}
+
+type hogQLMetadataQuery struct {
+	Kind     string `json:"kind"`
+	Language string `json:"language"`
+	Query    string `json:"query"`
+}
+
+type queryRequest struct {
+	Query any `json:"query"`
+}
+
+type hogQLMetadataResponse struct {
+	IsValid bool              `json:"isValid"`
+	Errors  []HogQLQueryError `json:"errors"`
+}
+
+// ValidateHogQLQuery checks a HogQL query, and returns the errors found in it.
+// A valid query has no errors.
+func (c *Client) ValidateHogQLQuery(ctx context.Context, projectID ProjectID, query string) ([]HogQLQueryError, error) {
+	var res hogQLMetadataResponse
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/query",
+		Input:        queryRequest{Query: hogQLMetadataQuery{Kind: "HogQLMetadata", Language: "hogQL", Query: query}},
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.IsValid && len(res.Errors) == 0 {
+		return []HogQLQueryError{{Message: "invalid query"}}, nil
+	}
+
+	return res.Errors, nil
+}
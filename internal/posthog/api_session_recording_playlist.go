@@ -0,0 +1,222 @@
+package posthog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// SessionRecordingPlaylistID is the short ID of a playlist, which is used in
+// API paths and web UI URLs instead of its numeric ID.
+type SessionRecordingPlaylistID string
+
+func (i SessionRecordingPlaylistID) String() string {
+	return string(i)
+}
+
+func SessionRecordingPlaylistIDFromString(s string) (SessionRecordingPlaylistID, error) {
+	if s == "" {
+		return "", errors.New("empty session recording playlist ID")
+	}
+
+	return SessionRecordingPlaylistID(s), nil
+}
+
+const (
+	// PropertyFilterTypeRecording is the type of the duration filters of
+	// session recordings.
+	PropertyFilterTypeRecording PropertyFilterType = "recording"
+
+	// PropertyFilterTypeLogEntry is the type of the console log filters of
+	// session recordings, with the "level" and "message" keys.
+	PropertyFilterTypeLogEntry PropertyFilterType = "log_entry"
+)
+
+// SessionRecordingFilters selects the recordings of a playlist. The web UI
+// stores the event and property filters as a group containing a single group
+// of filters, see SessionRecordingFilterGroup.
+type SessionRecordingFilters struct {
+	DateFrom           string                           `json:"date_from,omitempty"`
+	DateTo             *string                          `json:"date_to"`
+	Duration           []SessionRecordingDurationFilter `json:"duration"`
+	FilterGroup        SessionRecordingFilterGroup      `json:"filter_group"`
+	FilterTestAccounts bool                             `json:"filter_test_accounts"`
+	Order              string                           `json:"order,omitempty"`
+}
+
+// SessionRecordingDurationFilter matches recordings longer (gt) or shorter
+// (lt) than Value seconds. Key is the duration the filter applies to:
+// "duration", "active_seconds" or "inactive_seconds".
+type SessionRecordingDurationFilter struct {
+	Key      string             `json:"key"`
+	Type     PropertyFilterType `json:"type"`
+	Operator PropertyOperator   `json:"operator"`
+	Value    int64              `json:"value"`
+}
+
+// SessionRecordingFilterGroup holds the event and property filters of a
+// playlist, recordings match all of them (or any of them for OR groups).
+type SessionRecordingFilterGroup struct {
+	Type       PropertyGroupType
+	Entities   []EntityFilter
+	Properties []PropertyFilter
+}
+
+type rawSessionRecordingFilterGroup struct {
+	Type   PropertyGroupType `json:"type"`
+	Values []json.RawMessage `json:"values"`
+}
+
+func (g SessionRecordingFilterGroup) MarshalJSON() ([]byte, error) {
+	groupType := g.Type
+	if groupType == "" {
+		groupType = PropertyGroupTypeAnd
+	}
+
+	values := make([]json.RawMessage, 0, len(g.Entities)+len(g.Properties))
+
+	for _, e := range g.Entities {
+		v, err := json.Marshal(e)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, v)
+	}
+
+	for _, p := range g.Properties {
+		v, err := json.Marshal(p)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, v)
+	}
+
+	inner, err := json.Marshal(rawSessionRecordingFilterGroup{Type: groupType, Values: values})
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(rawSessionRecordingFilterGroup{Type: groupType, Values: []json.RawMessage{inner}})
+}
+
+func (g *SessionRecordingFilterGroup) UnmarshalJSON(b []byte) error {
+	*g = SessionRecordingFilterGroup{}
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	return g.decode(b)
+}
+
+// decode adds the filters of the (possibly nested) group b to g. Nested
+// groups are flattened, using the type of the innermost group.
+func (g *SessionRecordingFilterGroup) decode(b []byte) error {
+	var raw rawSessionRecordingFilterGroup
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return fmt.Errorf("error decoding recording filters: %w", err)
+	}
+
+	if raw.Type != PropertyGroupTypeAnd && raw.Type != PropertyGroupTypeOr {
+		return fmt.Errorf("unsupported recording filter group type %q", raw.Type)
+	}
+
+	g.Type = raw.Type
+
+	for _, v := range raw.Values {
+		var header struct {
+			Type string `json:"type"`
+		}
+
+		if err := json.Unmarshal(v, &header); err != nil {
+			return fmt.Errorf("error decoding recording filter: %w", err)
+		}
+
+		switch header.Type {
+		case string(PropertyGroupTypeAnd), string(PropertyGroupTypeOr):
+			if err := g.decode(v); err != nil {
+				return err
+			}
+		case string(InsightEntityTypeEvents), string(InsightEntityTypeActions):
+			var e EntityFilter
+			if err := json.Unmarshal(v, &e); err != nil {
+				return fmt.Errorf("error decoding recording filter: %w", err)
+			}
+
+			g.Entities = append(g.Entities, e)
+		default:
+			var p PropertyFilter
+			if err := json.Unmarshal(v, &p); err != nil {
+				return fmt.Errorf("error decoding recording filter: %w", err)
+			}
+
+			g.Properties = append(g.Properties, p)
+		}
+	}
+
+	return nil
+}
+
+type CreateSessionRecordingPlaylistRequest struct {
+	Name        string                  `json:"name"`
+	DerivedName string                  `json:"derived_name"`
+	Description string                  `json:"description"`
+	Pinned      bool                    `json:"pinned"`
+	Filters     SessionRecordingFilters `json:"filters"`
+}
+
+// SessionRecordingPlaylist is a saved list of session recordings matching
+// filters. The web UI displays the derived name, a summary of the filters,
+// when the name is empty.
+type SessionRecordingPlaylist struct {
+	ID          SessionRecordingPlaylistID `json:"short_id"`
+	Name        string                     `json:"name"`
+	DerivedName string                     `json:"derived_name"`
+	Description string                     `json:"description"`
+	Pinned      bool                       `json:"pinned"`
+	Filters     SessionRecordingFilters    `json:"filters"`
+	Deleted     bool                       `json:"deleted"`
+	CreatedAt   time.Time                  `json:"created_at"`
+}
+
+func (c *Client) CreateSessionRecordingPlaylist(ctx context.Context, projectID ProjectID, p CreateSessionRecordingPlaylistRequest) (*SessionRecordingPlaylist, error) {
+	var res *SessionRecordingPlaylist
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/session_recording_playlists",
+		Input:        p,
+		ExpectedCode: http.StatusCreated,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) UpdateSessionRecordingPlaylist(ctx context.Context, projectID ProjectID, p SessionRecordingPlaylist) (*SessionRecordingPlaylist, error) {
+	var res *SessionRecordingPlaylist
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/session_recording_playlists/" + url.PathEscape(p.ID.String()),
+		Input:        p,
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) GetSessionRecordingPlaylist(ctx context.Context, projectID ProjectID, playlistID SessionRecordingPlaylistID) (*SessionRecordingPlaylist, error) {
+	var res *SessionRecordingPlaylist
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/session_recording_playlists/" + url.PathEscape(playlistID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
@@ -0,0 +1,114 @@
+package posthog
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type SubscriptionID uint64
+
+func (i SubscriptionID) String() string {
+	return strconv.FormatUint(uint64(i), 10)
+}
+
+func SubscriptionIDFromString(s string) (SubscriptionID, error) {
+	res, err := strconv.ParseUint(s, 10, 64)
+	return SubscriptionID(res), err
+}
+
+type SubscriptionTargetType string
+
+const (
+	SubscriptionTargetTypeEmail   SubscriptionTargetType = "email"
+	SubscriptionTargetTypeSlack   SubscriptionTargetType = "slack"
+	SubscriptionTargetTypeWebhook SubscriptionTargetType = "webhook"
+)
+
+type SubscriptionFrequency string
+
+const (
+	SubscriptionFrequencyDaily   SubscriptionFrequency = "daily"
+	SubscriptionFrequencyWeekly  SubscriptionFrequency = "weekly"
+	SubscriptionFrequencyMonthly SubscriptionFrequency = "monthly"
+	SubscriptionFrequencyYearly  SubscriptionFrequency = "yearly"
+)
+
+// CreateSubscriptionRequest describes a subscription. The schedule follows
+// the RRULE format (RFC 5545): the export is sent every Interval periods of
+// Frequency, on the days of ByWeekday. For monthly and yearly subscriptions,
+// BySetPos selects the occurrence of the day in the period (eg. 1 with
+// monday for the first monday of the month, -1 for the last one).
+type CreateSubscriptionRequest struct {
+	Dashboard     *DashboardID           `json:"dashboard"`
+	Insight       *InsightID             `json:"insight"`
+	Title         string                 `json:"title"`
+	TargetType    SubscriptionTargetType `json:"target_type"`
+	TargetValue   string                 `json:"target_value"`
+	Frequency     SubscriptionFrequency  `json:"frequency"`
+	Interval      int64                  `json:"interval"`
+	ByWeekday     []string               `json:"byweekday"`
+	BySetPos      *int64                 `json:"bysetpos"`
+	StartDate     time.Time              `json:"start_date"`
+	UntilDate     *time.Time             `json:"until_date"`
+	Integration   *IntegrationID         `json:"integration_id"`
+	InviteMessage string                 `json:"invite_message,omitempty"`
+}
+
+// Subscription periodically sends an export of a dashboard or an insight
+// to email addresses, a Slack channel or a webhook.
+type Subscription struct {
+	ID          SubscriptionID         `json:"id"`
+	Dashboard   *DashboardID           `json:"dashboard"`
+	Insight     *InsightID             `json:"insight"`
+	Title       string                 `json:"title"`
+	TargetType  SubscriptionTargetType `json:"target_type"`
+	TargetValue string                 `json:"target_value"`
+	Frequency   SubscriptionFrequency  `json:"frequency"`
+	Interval    int64                  `json:"interval"`
+	ByWeekday   []string               `json:"byweekday"`
+	BySetPos    *int64                 `json:"bysetpos"`
+	StartDate   time.Time              `json:"start_date"`
+	UntilDate   *time.Time             `json:"until_date"`
+	Integration *IntegrationID         `json:"integration_id"`
+	Summary     string                 `json:"summary,omitempty"`
+	Deleted     bool                   `json:"deleted"`
+}
+
+func (c *Client) CreateSubscription(ctx context.Context, projectID ProjectID, s CreateSubscriptionRequest) (*Subscription, error) {
+	var res *Subscription
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/subscriptions",
+		Input:        s,
+		ExpectedCode: http.StatusCreated,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) UpdateSubscription(ctx context.Context, projectID ProjectID, s Subscription) (*Subscription, error) {
+	var res *Subscription
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/subscriptions/" + url.PathEscape(s.ID.String()),
+		Input:        s,
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) GetSubscription(ctx context.Context, projectID ProjectID, subscriptionID SubscriptionID) (*Subscription, error) {
+	var res *Subscription
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/subscriptions/" + url.PathEscape(subscriptionID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
@@ -0,0 +1,248 @@
+package posthog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// SurveyID is the UUID of a survey.
+type SurveyID string
+
+func (i SurveyID) String() string {
+	return string(i)
+}
+
+func SurveyIDFromString(s string) (SurveyID, error) {
+	if s == "" {
+		return "", errors.New("empty survey ID")
+	}
+
+	return SurveyID(s), nil
+}
+
+type SurveyType string
+
+const (
+	SurveyTypePopover SurveyType = "popover"
+	SurveyTypeWidget  SurveyType = "widget"
+	SurveyTypeAPI     SurveyType = "api"
+)
+
+type SurveyQuestionType string
+
+const (
+	SurveyQuestionTypeOpen           SurveyQuestionType = "open"
+	SurveyQuestionTypeRating         SurveyQuestionType = "rating"
+	SurveyQuestionTypeSingleChoice   SurveyQuestionType = "single_choice"
+	SurveyQuestionTypeMultipleChoice SurveyQuestionType = "multiple_choice"
+	SurveyQuestionTypeLink           SurveyQuestionType = "link"
+)
+
+type SurveyBranchingType string
+
+const (
+	SurveyBranchingTypeNextQuestion     SurveyBranchingType = "next_question"
+	SurveyBranchingTypeEnd              SurveyBranchingType = "end"
+	SurveyBranchingTypeResponseBased    SurveyBranchingType = "response_based"
+	SurveyBranchingTypeSpecificQuestion SurveyBranchingType = "specific_question"
+)
+
+// SurveyQuestion is a question of a survey. Which fields are used depends on
+// the type of the question: Choices for single and multiple choice questions,
+// Display, Scale and the bound labels for ratings, Link for link questions.
+type SurveyQuestion struct {
+	ID              string             `json:"id,omitempty"`
+	Type            SurveyQuestionType `json:"type"`
+	Question        string             `json:"question"`
+	Description     string             `json:"description"`
+	Optional        bool               `json:"optional"`
+	ButtonText      string             `json:"buttonText,omitempty"`
+	Choices         []string           `json:"choices,omitempty"`
+	HasOpenChoice   bool               `json:"hasOpenChoice,omitempty"`
+	ShuffleOptions  bool               `json:"shuffleOptions,omitempty"`
+	Display         string             `json:"display,omitempty"`
+	Scale           int64              `json:"scale,omitempty"`
+	LowerBoundLabel string             `json:"lowerBoundLabel,omitempty"`
+	UpperBoundLabel string             `json:"upperBoundLabel,omitempty"`
+	Link            string             `json:"link,omitempty"`
+	Branching       *SurveyBranching   `json:"branching,omitempty"`
+}
+
+// SurveyBranching tells which question to show after a given one. For
+// response based branching, ResponseValues maps responses (the index of the
+// choice for single choice questions, a range of values like "negative" for
+// ratings) to the next step.
+type SurveyBranching struct {
+	Type           SurveyBranchingType           `json:"type"`
+	Index          *int64                        `json:"index,omitempty"`
+	ResponseValues map[string]SurveyBranchTarget `json:"responseValues,omitempty"`
+}
+
+// SurveyBranchTarget is the next step of a response based branching: either
+// the index of a question, or the end of the survey.
+type SurveyBranchTarget struct {
+	End   bool
+	Index int64
+}
+
+const surveyBranchTargetEnd = "end"
+
+func (t SurveyBranchTarget) MarshalJSON() ([]byte, error) {
+	if t.End {
+		return json.Marshal(surveyBranchTargetEnd)
+	}
+
+	return json.Marshal(t.Index)
+}
+
+func (t *SurveyBranchTarget) UnmarshalJSON(b []byte) error {
+	var raw any
+
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	switch v := raw.(type) {
+	case string:
+		if v == surveyBranchTargetEnd {
+			*t = SurveyBranchTarget{End: true}
+			return nil
+		}
+
+		index, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("unexpected branch target %q", v)
+		}
+
+		*t = SurveyBranchTarget{Index: index}
+	case float64:
+		*t = SurveyBranchTarget{Index: int64(v)}
+	default:
+		return fmt.Errorf("unexpected branch target %v", raw)
+	}
+
+	return nil
+}
+
+// SurveyAppearance customizes how popover and widget surveys are displayed.
+// Unset fields use the PostHog defaults.
+type SurveyAppearance struct {
+	BackgroundColor            string `json:"backgroundColor,omitempty"`
+	BorderColor                string `json:"borderColor,omitempty"`
+	TextColor                  string `json:"textColor,omitempty"`
+	SubmitButtonColor          string `json:"submitButtonColor,omitempty"`
+	SubmitButtonTextColor      string `json:"submitButtonTextColor,omitempty"`
+	Placeholder                string `json:"placeholder,omitempty"`
+	Position                   string `json:"position,omitempty"`
+	DisplayThankYouMessage     *bool  `json:"displayThankYouMessage,omitempty"`
+	ThankYouMessageHeader      string `json:"thankYouMessageHeader,omitempty"`
+	ThankYouMessageDescription string `json:"thankYouMessageDescription,omitempty"`
+	WhiteLabel                 *bool  `json:"whiteLabel,omitempty"`
+	ShuffleQuestions           *bool  `json:"shuffleQuestions,omitempty"`
+	SurveyPopupDelaySeconds    *int64 `json:"surveyPopupDelaySeconds,omitempty"`
+	WidgetType                 string `json:"widgetType,omitempty"`
+	WidgetLabel                string `json:"widgetLabel,omitempty"`
+	WidgetSelector             string `json:"widgetSelector,omitempty"`
+	WidgetColor                string `json:"widgetColor,omitempty"`
+}
+
+// SurveyConditions restricts when a survey is displayed. Users must also match
+// the linked feature flag of the survey, if any.
+type SurveyConditions struct {
+	URL                        string `json:"url,omitempty"`
+	URLMatchType               string `json:"urlMatchType,omitempty"`
+	Selector                   string `json:"selector,omitempty"`
+	SeenSurveyWaitPeriodInDays *int64 `json:"seenSurveyWaitPeriodInDays,omitempty"`
+}
+
+type CreateSurveyRequest struct {
+	Name                   string            `json:"name"`
+	Description            string            `json:"description"`
+	Type                   SurveyType        `json:"type"`
+	Questions              []SurveyQuestion  `json:"questions"`
+	Appearance             *SurveyAppearance `json:"appearance"`
+	Conditions             *SurveyConditions `json:"conditions"`
+	LinkedFlagID           *FeatureFlagID    `json:"linked_flag_id"`
+	StartDate              *time.Time        `json:"start_date"`
+	EndDate                *time.Time        `json:"end_date"`
+	ResponsesLimit         *int64            `json:"responses_limit"`
+	IterationCount         *int64            `json:"iteration_count"`
+	IterationFrequencyDays *int64            `json:"iteration_frequency_days"`
+	Archived               bool              `json:"archived"`
+}
+
+// Survey is a set of questions displayed to users (popover and widget types)
+// or whose responses are sent by custom code (API type). Setting StartDate
+// launches the survey, setting EndDate stops it.
+type Survey struct {
+	ID                     SurveyID          `json:"id"`
+	Name                   string            `json:"name"`
+	Description            string            `json:"description"`
+	Type                   SurveyType        `json:"type"`
+	Questions              []SurveyQuestion  `json:"questions"`
+	Appearance             *SurveyAppearance `json:"appearance"`
+	Conditions             *SurveyConditions `json:"conditions"`
+	LinkedFlagID           *FeatureFlagID    `json:"linked_flag_id"`
+	StartDate              *time.Time        `json:"start_date"`
+	EndDate                *time.Time        `json:"end_date"`
+	ResponsesLimit         *int64            `json:"responses_limit"`
+	IterationCount         *int64            `json:"iteration_count"`
+	IterationFrequencyDays *int64            `json:"iteration_frequency_days"`
+	Archived               bool              `json:"archived"`
+	CreatedAt              time.Time         `json:"created_at"`
+}
+
+func (c *Client) CreateSurvey(ctx context.Context, projectID ProjectID, s CreateSurveyRequest) (*Survey, error) {
+	nilSliceToEmpty(&s.Questions)
+
+	var res *Survey
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/surveys",
+		Input:        s,
+		ExpectedCode: http.StatusCreated,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) UpdateSurvey(ctx context.Context, projectID ProjectID, s Survey) (*Survey, error) {
+	nilSliceToEmpty(&s.Questions)
+
+	var res *Survey
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/surveys/" + url.PathEscape(s.ID.String()),
+		Input:        s,
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) GetSurvey(ctx context.Context, projectID ProjectID, surveyID SurveyID) (*Survey, error) {
+	var res *Survey
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/surveys/" + url.PathEscape(surveyID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
+
+func (c *Client) DeleteSurvey(ctx context.Context, projectID ProjectID, surveyID SurveyID) error {
+	err := c.do(ctx, apiRequest{
+		Method:       "DELETE",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/surveys/" + url.PathEscape(surveyID.String()),
+		ExpectedCode: http.StatusNoContent,
+	})
+	return err
+}
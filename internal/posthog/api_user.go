@@ -0,0 +1,26 @@
+package posthog
+
+import (
+	"strconv"
+)
+
+type UserID uint64
+
+func (i UserID) String() string {
+	return strconv.FormatUint(uint64(i), 10)
+}
+
+func UserIDFromString(s string) (UserID, error) {
+	res, err := strconv.ParseUint(s, 10, 64)
+	return UserID(res), err
+}
+
+// UserBasic is the summary of a user embedded in other objects, for example
+// as the owner or creator of an object.
+type UserBasic struct {
+	ID        UserID `json:"id"`
+	UUID      string `json:"uuid"`
+	Email     string `json:"email"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
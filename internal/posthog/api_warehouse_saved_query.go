@@ -0,0 +1,101 @@
+package posthog
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// WarehouseSavedQueryID is the UUID of a saved query.
+type WarehouseSavedQueryID string
+
+func (i WarehouseSavedQueryID) String() string {
+	return string(i)
+}
+
+func WarehouseSavedQueryIDFromString(s string) (WarehouseSavedQueryID, error) {
+	if s == "" {
+		return "", errors.New("empty saved query ID")
+	}
+
+	return WarehouseSavedQueryID(s), nil
+}
+
+type WarehouseSavedQueryQuery struct {
+	Kind  InsightQueryKind `json:"kind"`
+	Query string           `json:"query"`
+}
+
+// WarehouseColumn is a column of a data warehouse table or view.
+type WarehouseColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type CreateWarehouseSavedQueryRequest struct {
+	Name  string                   `json:"name"`
+	Query WarehouseSavedQueryQuery `json:"query"`
+}
+
+// WarehouseSavedQuery is a HogQL query saved as a view of the data warehouse,
+// which other queries can use like a table. The columns are computed by
+// PostHog from the query.
+type WarehouseSavedQuery struct {
+	ID        WarehouseSavedQueryID    `json:"id"`
+	Name      string                   `json:"name"`
+	Query     WarehouseSavedQueryQuery `json:"query"`
+	Columns   []WarehouseColumn        `json:"columns,omitempty"`
+	CreatedAt time.Time                `json:"created_at"`
+}
+
+func (c *Client) CreateWarehouseSavedQuery(ctx context.Context, projectID ProjectID, q CreateWarehouseSavedQueryRequest) (*WarehouseSavedQuery, error) {
+	q.Query.Kind = InsightQueryKindHogQL
+
+	var res *WarehouseSavedQuery
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/warehouse_saved_queries",
+		Input:        q,
+		ExpectedCode: http.StatusCreated,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) UpdateWarehouseSavedQuery(ctx context.Context, projectID ProjectID, q WarehouseSavedQuery) (*WarehouseSavedQuery, error) {
+	q.Query.Kind = InsightQueryKindHogQL
+	q.Columns = nil
+
+	var res *WarehouseSavedQuery
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/warehouse_saved_queries/" + url.PathEscape(q.ID.String()),
+		Input:        q,
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) GetWarehouseSavedQuery(ctx context.Context, projectID ProjectID, savedQueryID WarehouseSavedQueryID) (*WarehouseSavedQuery, error) {
+	var res *WarehouseSavedQuery
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/warehouse_saved_queries/" + url.PathEscape(savedQueryID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
+
+func (c *Client) DeleteWarehouseSavedQuery(ctx context.Context, projectID ProjectID, savedQueryID WarehouseSavedQueryID) error {
+	err := c.do(ctx, apiRequest{
+		Method:       "DELETE",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/warehouse_saved_queries/" + url.PathEscape(savedQueryID.String()),
+		ExpectedCode: http.StatusNoContent,
+	})
+	return err
+}
@@ -0,0 +1,170 @@
+package posthog
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// WarehouseSourceID is the UUID of a data warehouse source.
+type WarehouseSourceID string
+
+func (i WarehouseSourceID) String() string {
+	return string(i)
+}
+
+func WarehouseSourceIDFromString(s string) (WarehouseSourceID, error) {
+	if s == "" {
+		return "", errors.New("empty warehouse source ID")
+	}
+
+	return WarehouseSourceID(s), nil
+}
+
+// WarehouseSourceSchemaID is the UUID of a table synced by a source.
+type WarehouseSourceSchemaID string
+
+func (i WarehouseSourceSchemaID) String() string {
+	return string(i)
+}
+
+type WarehouseSourceType string
+
+const (
+	WarehouseSourceTypeStripe     WarehouseSourceType = "Stripe"
+	WarehouseSourceTypeHubspot    WarehouseSourceType = "Hubspot"
+	WarehouseSourceTypePostgres   WarehouseSourceType = "Postgres"
+	WarehouseSourceTypeMySQL      WarehouseSourceType = "MySQL"
+	WarehouseSourceTypeMSSQL      WarehouseSourceType = "MSSQL"
+	WarehouseSourceTypeSnowflake  WarehouseSourceType = "Snowflake"
+	WarehouseSourceTypeBigQuery   WarehouseSourceType = "BigQuery"
+	WarehouseSourceTypeZendesk    WarehouseSourceType = "Zendesk"
+	WarehouseSourceTypeSalesforce WarehouseSourceType = "Salesforce"
+	WarehouseSourceTypeVitally    WarehouseSourceType = "Vitally"
+)
+
+type WarehouseSyncType string
+
+const (
+	WarehouseSyncTypeFullRefresh WarehouseSyncType = "full_refresh"
+	WarehouseSyncTypeIncremental WarehouseSyncType = "incremental"
+)
+
+type WarehouseSyncFrequency string
+
+const (
+	WarehouseSyncFrequency5Min   WarehouseSyncFrequency = "5min"
+	WarehouseSyncFrequency30Min  WarehouseSyncFrequency = "30min"
+	WarehouseSyncFrequency1Hour  WarehouseSyncFrequency = "1hour"
+	WarehouseSyncFrequency6Hour  WarehouseSyncFrequency = "6hour"
+	WarehouseSyncFrequency12Hour WarehouseSyncFrequency = "12hour"
+	WarehouseSyncFrequency24Hour WarehouseSyncFrequency = "24hour"
+	WarehouseSyncFrequency7Day   WarehouseSyncFrequency = "7day"
+	WarehouseSyncFrequency30Day  WarehouseSyncFrequency = "30day"
+)
+
+// WarehouseSourceSchema is a table of a source. Only tables with ShouldSync
+// are imported into the data warehouse. Incremental syncs only import the rows
+// where IncrementalField increased since the previous sync.
+type WarehouseSourceSchema struct {
+	ID                   WarehouseSourceSchemaID `json:"id,omitempty"`
+	Name                 string                  `json:"name"`
+	ShouldSync           bool                    `json:"should_sync"`
+	SyncType             WarehouseSyncType       `json:"sync_type"`
+	IncrementalField     *string                 `json:"incremental_field"`
+	IncrementalFieldType *string                 `json:"incremental_field_type"`
+	SyncFrequency        WarehouseSyncFrequency  `json:"sync_frequency"`
+}
+
+// CreateWarehouseSourceRequest describes a source. The payload holds the
+// connection settings of the source, which depend on its type, and the
+// schemas to sync under the "schemas" key.
+type CreateWarehouseSourceRequest struct {
+	SourceType WarehouseSourceType `json:"source_type"`
+	Prefix     string              `json:"prefix,omitempty"`
+	Payload    map[string]any      `json:"payload"`
+}
+
+// WarehouseSource periodically imports the tables of an external service or
+// database into the data warehouse. The job inputs are the connection
+// settings of the source, secret settings are not returned by the API.
+type WarehouseSource struct {
+	ID          WarehouseSourceID       `json:"id"`
+	SourceType  WarehouseSourceType     `json:"source_type"`
+	Prefix      *string                 `json:"prefix"`
+	Status      string                  `json:"status"`
+	LatestError *string                 `json:"latest_error"`
+	JobInputs   map[string]any          `json:"job_inputs,omitempty"`
+	Schemas     []WarehouseSourceSchema `json:"schemas"`
+	CreatedAt   time.Time               `json:"created_at"`
+}
+
+type updateWarehouseSourceRequest struct {
+	JobInputs map[string]any `json:"job_inputs"`
+}
+
+func (c *Client) CreateWarehouseSource(ctx context.Context, projectID ProjectID, s CreateWarehouseSourceRequest) (*WarehouseSource, error) {
+	var res struct {
+		ID WarehouseSourceID `json:"id"`
+	}
+
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/external_data_sources",
+		Input:        s,
+		ExpectedCode: http.StatusCreated,
+		Output:       &res,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The creation reply doesn't contain the schemas
+	return c.GetWarehouseSource(ctx, projectID, res.ID)
+}
+
+// UpdateWarehouseSourceJobInputs updates the connection settings of a
+// source.
+func (c *Client) UpdateWarehouseSourceJobInputs(ctx context.Context, projectID ProjectID, sourceID WarehouseSourceID, jobInputs map[string]any) error {
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/external_data_sources/" + url.PathEscape(sourceID.String()),
+		Input:        updateWarehouseSourceRequest{JobInputs: jobInputs},
+		ExpectedCode: http.StatusOK,
+	})
+	return err
+}
+
+func (c *Client) GetWarehouseSource(ctx context.Context, projectID ProjectID, sourceID WarehouseSourceID) (*WarehouseSource, error) {
+	var res *WarehouseSource
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/external_data_sources/" + url.PathEscape(sourceID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
+
+func (c *Client) DeleteWarehouseSource(ctx context.Context, projectID ProjectID, sourceID WarehouseSourceID) error {
+	err := c.do(ctx, apiRequest{
+		Method:       "DELETE",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/external_data_sources/" + url.PathEscape(sourceID.String()),
+		ExpectedCode: http.StatusNoContent,
+	})
+	return err
+}
+
+// UpdateWarehouseSourceSchema updates how a table of a source is synced.
+func (c *Client) UpdateWarehouseSourceSchema(ctx context.Context, projectID ProjectID, s WarehouseSourceSchema) error {
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/external_data_schemas/" + url.PathEscape(s.ID.String()),
+		Input:        s,
+		ExpectedCode: http.StatusOK,
+	})
+	return err
+}
@@ -0,0 +1,108 @@
+package posthog
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// WarehouseTableID is the UUID of a data warehouse table.
+type WarehouseTableID string
+
+func (i WarehouseTableID) String() string {
+	return string(i)
+}
+
+func WarehouseTableIDFromString(s string) (WarehouseTableID, error) {
+	if s == "" {
+		return "", errors.New("empty warehouse table ID")
+	}
+
+	return WarehouseTableID(s), nil
+}
+
+type WarehouseTableFormat string
+
+const (
+	WarehouseTableFormatCSV            WarehouseTableFormat = "CSV"
+	WarehouseTableFormatCSVWithNames   WarehouseTableFormat = "CSVWithNames"
+	WarehouseTableFormatParquet        WarehouseTableFormat = "Parquet"
+	WarehouseTableFormatJSONEachRow    WarehouseTableFormat = "JSONEachRow"
+	WarehouseTableFormatDelta          WarehouseTableFormat = "Delta"
+	WarehouseTableFormatDeltaS3Wrapper WarehouseTableFormat = "DeltaS3Wrapper"
+)
+
+// WarehouseTableCredential holds the keys used to read the files of a table.
+// They are never returned by the API.
+type WarehouseTableCredential struct {
+	AccessKey    string `json:"access_key"`
+	AccessSecret string `json:"access_secret"`
+}
+
+type CreateWarehouseTableRequest struct {
+	Name       string                   `json:"name"`
+	Format     WarehouseTableFormat     `json:"format"`
+	URLPattern string                   `json:"url_pattern"`
+	Credential WarehouseTableCredential `json:"credential"`
+}
+
+// WarehouseTable is a table of the data warehouse reading files from object
+// storage (eg. S3). The columns are detected by PostHog from the files.
+type WarehouseTable struct {
+	ID         WarehouseTableID          `json:"id"`
+	Name       string                    `json:"name"`
+	Format     WarehouseTableFormat      `json:"format"`
+	URLPattern string                    `json:"url_pattern"`
+	Credential *WarehouseTableCredential `json:"credential,omitempty"`
+	Columns    []WarehouseColumn         `json:"columns,omitempty"`
+	CreatedAt  time.Time                 `json:"created_at"`
+}
+
+func (c *Client) CreateWarehouseTable(ctx context.Context, projectID ProjectID, t CreateWarehouseTableRequest) (*WarehouseTable, error) {
+	var res *WarehouseTable
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/warehouse_tables",
+		Input:        t,
+		ExpectedCode: http.StatusCreated,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) UpdateWarehouseTable(ctx context.Context, projectID ProjectID, t WarehouseTable) (*WarehouseTable, error) {
+	t.Columns = nil
+
+	var res *WarehouseTable
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/warehouse_tables/" + url.PathEscape(t.ID.String()),
+		Input:        t,
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) GetWarehouseTable(ctx context.Context, projectID ProjectID, tableID WarehouseTableID) (*WarehouseTable, error) {
+	var res *WarehouseTable
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/warehouse_tables/" + url.PathEscape(tableID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
+
+func (c *Client) DeleteWarehouseTable(ctx context.Context, projectID ProjectID, tableID WarehouseTableID) error {
+	err := c.do(ctx, apiRequest{
+		Method:       "DELETE",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/warehouse_tables/" + url.PathEscape(tableID.String()),
+		ExpectedCode: http.StatusNoContent,
+	})
+	return err
+}
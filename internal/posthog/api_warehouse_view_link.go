@@ -0,0 +1,92 @@
+package posthog
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// WarehouseViewLinkID is the UUID of a view link.
+type WarehouseViewLinkID string
+
+func (i WarehouseViewLinkID) String() string {
+	return string(i)
+}
+
+func WarehouseViewLinkIDFromString(s string) (WarehouseViewLinkID, error) {
+	if s == "" {
+		return "", errors.New("empty view link ID")
+	}
+
+	return WarehouseViewLinkID(s), nil
+}
+
+type CreateWarehouseViewLinkRequest struct {
+	SourceTableName  string `json:"source_table_name"`
+	SourceTableKey   string `json:"source_table_key"`
+	JoiningTableName string `json:"joining_table_name"`
+	JoiningTableKey  string `json:"joining_table_key"`
+	FieldName        string `json:"field_name"`
+}
+
+// WarehouseViewLink joins two tables of the data warehouse (or a warehouse
+// table and the persons or events tables): the rows of the joining table
+// whose joining key equals the source key of a row of the source table are
+// available under FieldName when querying the source table. The keys are
+// HogQL expressions (eg. "properties.email").
+type WarehouseViewLink struct {
+	ID               WarehouseViewLinkID `json:"id"`
+	SourceTableName  string              `json:"source_table_name"`
+	SourceTableKey   string              `json:"source_table_key"`
+	JoiningTableName string              `json:"joining_table_name"`
+	JoiningTableKey  string              `json:"joining_table_key"`
+	FieldName        string              `json:"field_name"`
+	CreatedAt        time.Time           `json:"created_at"`
+}
+
+func (c *Client) CreateWarehouseViewLink(ctx context.Context, projectID ProjectID, l CreateWarehouseViewLinkRequest) (*WarehouseViewLink, error) {
+	var res *WarehouseViewLink
+	err := c.do(ctx, apiRequest{
+		Method:       "POST",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/warehouse_view_links",
+		Input:        l,
+		ExpectedCode: http.StatusCreated,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) UpdateWarehouseViewLink(ctx context.Context, projectID ProjectID, l WarehouseViewLink) (*WarehouseViewLink, error) {
+	var res *WarehouseViewLink
+	err := c.do(ctx, apiRequest{
+		Method:       "PATCH",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/warehouse_view_links/" + url.PathEscape(l.ID.String()),
+		Input:        l,
+		ExpectedCode: http.StatusOK,
+		Output:       &res,
+	})
+	return res, err
+}
+
+func (c *Client) GetWarehouseViewLink(ctx context.Context, projectID ProjectID, viewLinkID WarehouseViewLinkID) (*WarehouseViewLink, error) {
+	var res *WarehouseViewLink
+	err := c.do(ctx, apiRequest{
+		Method:         "GET",
+		Path:           "/projects/" + url.PathEscape(projectID.String()) + "/warehouse_view_links/" + url.PathEscape(viewLinkID.String()),
+		ExpectedCode:   http.StatusOK,
+		Output:         &res,
+		OutputNilIf404: true,
+	})
+	return res, err
+}
+
+func (c *Client) DeleteWarehouseViewLink(ctx context.Context, projectID ProjectID, viewLinkID WarehouseViewLinkID) error {
+	err := c.do(ctx, apiRequest{
+		Method:       "DELETE",
+		Path:         "/projects/" + url.PathEscape(projectID.String()) + "/warehouse_view_links/" + url.PathEscape(viewLinkID.String()),
+		ExpectedCode: http.StatusNoContent,
+	})
+	return err
+}
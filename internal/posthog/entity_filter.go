@@ -0,0 +1,62 @@
+package posthog
+
+import (
+	"encoding/json"
+)
+
+// EntityFilter is an event or an action in the filters of Hog functions and
+// session recording playlists. The ID is the event name for events, and the
+// action ID for actions.
+type EntityFilter struct {
+	ID         string
+	Type       InsightEntityType
+	Name       string
+	Order      int64
+	Properties InsightProperties
+}
+
+type rawEntityFilter struct {
+	ID         any               `json:"id"`
+	Type       InsightEntityType `json:"type"`
+	Name       string            `json:"name,omitempty"`
+	Order      int64             `json:"order"`
+	Properties InsightProperties `json:"properties,omitempty"`
+}
+
+func (e EntityFilter) MarshalJSON() ([]byte, error) {
+	id, err := entityIDToJSON(e.Type, e.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(rawEntityFilter{
+		ID:         id,
+		Type:       e.Type,
+		Name:       e.Name,
+		Order:      e.Order,
+		Properties: e.Properties,
+	})
+}
+
+func (e *EntityFilter) UnmarshalJSON(b []byte) error {
+	var raw rawEntityFilter
+
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	id, err := entityIDFromJSON(raw.ID)
+	if err != nil {
+		return err
+	}
+
+	*e = EntityFilter{
+		ID:         id,
+		Type:       raw.Type,
+		Name:       raw.Name,
+		Order:      raw.Order,
+		Properties: raw.Properties,
+	}
+
+	return nil
+}
@@ -0,0 +1,274 @@
+package posthog
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInconclusiveMatch is returned when a flag can't be evaluated locally, for
+// example because it depends on cohorts or on properties that were not
+// provided.
+var ErrInconclusiveMatch = errors.New("flag cannot be evaluated locally")
+
+// longScale is the maximum value of the 15 hex digits hash used for bucketing.
+const longScale = float64(0xfffffffffffffff)
+
+type FeatureFlagEvaluation struct {
+	Enabled bool
+	Variant string // empty for boolean flags
+	Payload *string
+}
+
+// Evaluate computes the value of the flag for the given distinct ID and person
+// properties, using the same algorithm as the PostHog server and SDKs.
+//
+// For flags aggregated by group, distinctID should be the group key.
+func (f *FeatureFlag) Evaluate(distinctID string, properties map[string]string) (FeatureFlagEvaluation, error) {
+	if !f.Active || f.Deleted {
+		return FeatureFlagEvaluation{}, nil
+	}
+
+	// Flags with experience continuity depend on the previous values
+	// stored by PostHog for each person
+	if f.EnsureExperienceContinuity {
+		return FeatureFlagEvaluation{}, ErrInconclusiveMatch
+	}
+
+	// Conditions with a variant override are evaluated first
+	groups := append([]FeatureFlagGroup(nil), f.Filters.Groups...)
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Variant != nil && groups[j].Variant == nil
+	})
+
+	isInconclusive := false
+
+	for _, g := range groups {
+		matches, err := f.matchesGroup(g, distinctID, properties)
+		if errors.Is(err, ErrInconclusiveMatch) {
+			isInconclusive = true
+			continue
+		} else if err != nil {
+			return FeatureFlagEvaluation{}, err
+		}
+
+		if !matches {
+			continue
+		}
+
+		res := FeatureFlagEvaluation{Enabled: true}
+
+		if g.Variant != nil && f.hasVariant(*g.Variant) {
+			res.Variant = *g.Variant
+		} else {
+			res.Variant = f.matchingVariant(distinctID)
+		}
+
+		payloadKey := res.Variant
+		if payloadKey == "" {
+			payloadKey = "true"
+		}
+
+		if p, ok := f.Filters.Payloads[payloadKey]; ok {
+			res.Payload = &p
+		}
+
+		return res, nil
+	}
+
+	if isInconclusive {
+		return FeatureFlagEvaluation{}, ErrInconclusiveMatch
+	}
+
+	return FeatureFlagEvaluation{}, nil
+}
+
+// flagHash returns a deterministic number between 0 and 1 for the given
+// identifier.
+func (f *FeatureFlag) flagHash(distinctID string, salt string) float64 {
+	// SHA1 is what PostHog uses for bucketing, this is not security sensitive
+	sum := sha1.Sum([]byte(f.Key + "." + distinctID + salt))
+	value, _ := strconv.ParseUint(hex.EncodeToString(sum[:])[:15], 16, 64)
+	return float64(value) / longScale
+}
+
+func (f *FeatureFlag) matchesGroup(g FeatureFlagGroup, distinctID string, properties map[string]string) (bool, error) {
+	for _, p := range g.Properties {
+		matches, err := matchProperty(p, properties)
+		if err != nil {
+			return false, err
+		}
+
+		if !matches {
+			return false, nil
+		}
+	}
+
+	if g.RolloutPercentage != nil && f.flagHash(distinctID, "") > *g.RolloutPercentage/100 {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (f *FeatureFlag) hasVariant(key string) bool {
+	if f.Filters.Multivariate == nil {
+		return false
+	}
+
+	for _, v := range f.Filters.Multivariate.Variants {
+		if v.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f *FeatureFlag) matchingVariant(distinctID string) string {
+	if f.Filters.Multivariate == nil {
+		return ""
+	}
+
+	hash := f.flagHash(distinctID, "variant")
+	valueMin := 0.0
+
+	for _, v := range f.Filters.Multivariate.Variants {
+		valueMax := valueMin + float64(v.RolloutPercentage)/100
+		if hash >= valueMin && hash < valueMax {
+			return v.Key
+		}
+
+		valueMin = valueMax
+	}
+
+	return ""
+}
+
+func matchProperty(p PropertyFilter, properties map[string]string) (bool, error) {
+	matches, err := matchPropertyValue(p, properties)
+	if err != nil {
+		return false, err
+	}
+
+	return matches != p.Negation, nil
+}
+
+func matchPropertyValue(p PropertyFilter, properties map[string]string) (bool, error) {
+	if p.Type == PropertyFilterTypeCohort || p.Operator == PropertyOperatorIsNotSet {
+		return false, ErrInconclusiveMatch
+	}
+
+	value, ok := properties[p.Key]
+	if !ok {
+		return false, ErrInconclusiveMatch
+	}
+
+	switch p.Operator {
+	case PropertyOperatorExact, "":
+		return p.matchesExactly(value), nil
+	case PropertyOperatorIsNot:
+		return !p.matchesExactly(value), nil
+	case PropertyOperatorIsSet:
+		return true, nil
+	}
+
+	if len(p.Value) == 0 {
+		return false, fmt.Errorf("property filter on %q has no value", p.Key)
+	}
+
+	expected := p.Value[0]
+
+	switch p.Operator {
+	case PropertyOperatorIContains:
+		return strings.Contains(strings.ToLower(value), strings.ToLower(expected)), nil
+	case PropertyOperatorNotIContains:
+		return !strings.Contains(strings.ToLower(value), strings.ToLower(expected)), nil
+	case PropertyOperatorRegex, PropertyOperatorNotRegex:
+		re, err := regexp.Compile(expected)
+		if err != nil {
+			// invalid regexes never match, like in PostHog
+			return false, nil
+		}
+
+		return re.MatchString(value) == (p.Operator == PropertyOperatorRegex), nil
+	case PropertyOperatorGT, PropertyOperatorGTE, PropertyOperatorLT, PropertyOperatorLTE:
+		return compareValues(value, expected, p.Operator), nil
+	case PropertyOperatorIsDateBefore, PropertyOperatorIsDateAfter:
+		valueDate, err := parseDate(value)
+		if err != nil {
+			return false, ErrInconclusiveMatch
+		}
+
+		expectedDate, err := parseDate(expected)
+		if err != nil {
+			// relative dates (eg. -7d) are not supported
+			return false, ErrInconclusiveMatch
+		}
+
+		if p.Operator == PropertyOperatorIsDateBefore {
+			return valueDate.Before(expectedDate), nil
+		}
+
+		return valueDate.After(expectedDate), nil
+	default:
+		return false, ErrInconclusiveMatch
+	}
+}
+
+func (p PropertyFilter) matchesExactly(value string) bool {
+	for _, v := range p.Value {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compareValues compares numerically if both values are numbers, and as
+// strings otherwise.
+func compareValues(value, expected string, operator PropertyOperator) bool {
+	var cmp int
+
+	valueNumber, valueErr := strconv.ParseFloat(value, 64)
+	expectedNumber, expectedErr := strconv.ParseFloat(expected, 64)
+
+	switch {
+	case valueErr == nil && expectedErr == nil && valueNumber < expectedNumber:
+		cmp = -1
+	case valueErr == nil && expectedErr == nil && valueNumber > expectedNumber:
+		cmp = 1
+	case valueErr == nil && expectedErr == nil:
+		cmp = 0
+	default:
+		cmp = strings.Compare(value, expected)
+	}
+
+	switch operator {
+	case PropertyOperatorGT:
+		return cmp > 0
+	case PropertyOperatorGTE:
+		return cmp >= 0
+	case PropertyOperatorLT:
+		return cmp < 0
+	default: // PropertyOperatorLTE
+		return cmp <= 0
+	}
+}
+
+func parseDate(s string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
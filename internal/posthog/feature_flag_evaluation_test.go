@@ -0,0 +1,322 @@
+package posthog
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// The expected values below come from the consistency tests shared by the
+// PostHog SDKs, which check that all implementations bucket users the same
+// way.
+
+func TestFeatureFlagEvaluateSimpleFlagConsistency(t *testing.T) {
+	flag := FeatureFlag{
+		Key:    "simple-flag",
+		Active: true,
+		Filters: FeatureFlagFilters{
+			Groups: []FeatureFlagGroup{{RolloutPercentage: ptr(45.0)}},
+		},
+	}
+
+	expected := []bool{
+		false, true, true, false, true, false, false, true, false, true,
+		false, true, true, false, true,
+	}
+
+	for i, want := range expected {
+		distinctID := fmt.Sprintf("distinct_id_%d", i)
+
+		res, err := flag.Evaluate(distinctID, nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", distinctID, err)
+		}
+
+		if res.Enabled != want {
+			t.Errorf("%s: expected enabled=%v, got %v", distinctID, want, res.Enabled)
+		}
+	}
+}
+
+func TestFeatureFlagEvaluateMultivariateFlagConsistency(t *testing.T) {
+	flag := FeatureFlag{
+		Key:    "multivariate-flag",
+		Active: true,
+		Filters: FeatureFlagFilters{
+			Groups: []FeatureFlagGroup{{RolloutPercentage: ptr(55.0)}},
+			Multivariate: &FeatureFlagMultivariate{
+				Variants: []FeatureFlagVariant{
+					{Key: "first-variant", RolloutPercentage: 50},
+					{Key: "second-variant", RolloutPercentage: 20},
+					{Key: "third-variant", RolloutPercentage: 20},
+					{Key: "fourth-variant", RolloutPercentage: 5},
+					{Key: "fifth-variant", RolloutPercentage: 5},
+				},
+			},
+		},
+	}
+
+	// empty means that the flag is disabled
+	expected := []string{
+		"second-variant", "second-variant", "first-variant", "", "",
+		"second-variant", "first-variant", "", "", "",
+		"first-variant", "third-variant", "", "first-variant", "second-variant",
+		"first-variant", "", "", "fourth-variant", "first-variant",
+	}
+
+	for i, want := range expected {
+		distinctID := fmt.Sprintf("distinct_id_%d", i)
+
+		res, err := flag.Evaluate(distinctID, nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", distinctID, err)
+		}
+
+		if res.Enabled != (want != "") || res.Variant != want {
+			t.Errorf("%s: expected variant %q, got enabled=%v variant=%q", distinctID, want, res.Enabled, res.Variant)
+		}
+	}
+}
+
+func TestFeatureFlagHash(t *testing.T) {
+	flag := FeatureFlag{Key: "simple-flag"}
+
+	for i := 0; i < 100; i++ {
+		distinctID := fmt.Sprintf("distinct_id_%d", i)
+
+		hash := flag.flagHash(distinctID, "")
+		if hash < 0 || hash > 1 {
+			t.Fatalf("%s: hash %f out of range", distinctID, hash)
+		}
+
+		if again := flag.flagHash(distinctID, ""); again != hash {
+			t.Fatalf("%s: hash is not deterministic (%f != %f)", distinctID, hash, again)
+		}
+
+		if flag.flagHash(distinctID, "variant") == hash {
+			t.Fatalf("%s: salt is not taken into account", distinctID)
+		}
+	}
+}
+
+func TestFeatureFlagMatchingVariant(t *testing.T) {
+	flag := FeatureFlag{
+		Key: "multivariate-flag",
+		Filters: FeatureFlagFilters{
+			Multivariate: &FeatureFlagMultivariate{
+				Variants: []FeatureFlagVariant{
+					{Key: "first-variant", RolloutPercentage: 50},
+					{Key: "second-variant", RolloutPercentage: 20},
+					{Key: "third-variant", RolloutPercentage: 30},
+				},
+			},
+		},
+	}
+
+	for i := 0; i < 100; i++ {
+		distinctID := fmt.Sprintf("distinct_id_%d", i)
+		hash := flag.flagHash(distinctID, "variant")
+
+		var want string
+
+		switch {
+		case hash < 0.5:
+			want = "first-variant"
+		case hash < 0.7:
+			want = "second-variant"
+		default:
+			want = "third-variant"
+		}
+
+		if got := flag.matchingVariant(distinctID); got != want {
+			t.Errorf("%s: hash %f, expected variant %q, got %q", distinctID, hash, want, got)
+		}
+	}
+
+	if got := (&FeatureFlag{Key: "boolean-flag"}).matchingVariant("distinct_id_0"); got != "" {
+		t.Errorf("expected no variant for a boolean flag, got %q", got)
+	}
+}
+
+func TestMatchProperty(t *testing.T) {
+	properties := map[string]string{
+		"email":      "Jane@Example.com",
+		"plan":       "enterprise",
+		"age":        "42",
+		"version":    "1.10",
+		"name":       "jane",
+		"signed_up":  "2024-03-15T10:00:00Z",
+		"not_a_date": "yesterday",
+	}
+
+	testCases := []struct {
+		name     string
+		filter   PropertyFilter
+		expected bool
+		err      error
+	}{
+		{"exact", PropertyFilter{Key: "plan", Operator: PropertyOperatorExact, Value: PropertyValue{"enterprise"}}, true, nil},
+		{"exact is case insensitive", PropertyFilter{Key: "plan", Operator: PropertyOperatorExact, Value: PropertyValue{"Enterprise"}}, true, nil},
+		{"exact with several values", PropertyFilter{Key: "plan", Operator: PropertyOperatorExact, Value: PropertyValue{"free", "enterprise"}}, true, nil},
+		{"exact without operator", PropertyFilter{Key: "plan", Value: PropertyValue{"free"}}, false, nil},
+		{"is_not", PropertyFilter{Key: "plan", Operator: PropertyOperatorIsNot, Value: PropertyValue{"free"}}, true, nil},
+		{"is_not matching", PropertyFilter{Key: "plan", Operator: PropertyOperatorIsNot, Value: PropertyValue{"enterprise"}}, false, nil},
+		{"is_set", PropertyFilter{Key: "plan", Operator: PropertyOperatorIsSet}, true, nil},
+		{"icontains", PropertyFilter{Key: "email", Operator: PropertyOperatorIContains, Value: PropertyValue{"example.COM"}}, true, nil},
+		{"not_icontains", PropertyFilter{Key: "email", Operator: PropertyOperatorNotIContains, Value: PropertyValue{"example.com"}}, false, nil},
+		{"regex", PropertyFilter{Key: "email", Operator: PropertyOperatorRegex, Value: PropertyValue{"@Example\\.com$"}}, true, nil},
+		{"not_regex", PropertyFilter{Key: "email", Operator: PropertyOperatorNotRegex, Value: PropertyValue{"@posthog\\.com$"}}, true, nil},
+		{"invalid regex", PropertyFilter{Key: "email", Operator: PropertyOperatorRegex, Value: PropertyValue{"(["}}, false, nil},
+		{"gt numeric", PropertyFilter{Key: "age", Operator: PropertyOperatorGT, Value: PropertyValue{"9"}}, true, nil},
+		{"gte numeric", PropertyFilter{Key: "age", Operator: PropertyOperatorGTE, Value: PropertyValue{"42"}}, true, nil},
+		{"lt numeric", PropertyFilter{Key: "age", Operator: PropertyOperatorLT, Value: PropertyValue{"42"}}, false, nil},
+		{"lte numeric", PropertyFilter{Key: "age", Operator: PropertyOperatorLTE, Value: PropertyValue{"42.0"}}, true, nil},
+		{"gt string", PropertyFilter{Key: "name", Operator: PropertyOperatorGT, Value: PropertyValue{"alice"}}, true, nil},
+		{"lt decimal", PropertyFilter{Key: "version", Operator: PropertyOperatorLT, Value: PropertyValue{"1.9"}}, true, nil},
+		{"is_date_before", PropertyFilter{Key: "signed_up", Operator: PropertyOperatorIsDateBefore, Value: PropertyValue{"2024-04-01"}}, true, nil},
+		{"is_date_after", PropertyFilter{Key: "signed_up", Operator: PropertyOperatorIsDateAfter, Value: PropertyValue{"2024-04-01 00:00:00"}}, false, nil},
+		{"relative date", PropertyFilter{Key: "signed_up", Operator: PropertyOperatorIsDateAfter, Value: PropertyValue{"-7d"}}, false, ErrInconclusiveMatch},
+		{"invalid date value", PropertyFilter{Key: "not_a_date", Operator: PropertyOperatorIsDateAfter, Value: PropertyValue{"2024-04-01"}}, false, ErrInconclusiveMatch},
+		{"missing property", PropertyFilter{Key: "country", Operator: PropertyOperatorExact, Value: PropertyValue{"FR"}}, false, ErrInconclusiveMatch},
+		{"is_not_set", PropertyFilter{Key: "plan", Operator: PropertyOperatorIsNotSet}, false, ErrInconclusiveMatch},
+		{"cohort", PropertyFilter{Key: "id", Type: PropertyFilterTypeCohort, Value: PropertyValue{"12"}}, false, ErrInconclusiveMatch},
+		{"unsupported operator", PropertyFilter{Key: "plan", Operator: PropertyOperatorIn, Value: PropertyValue{"free"}}, false, ErrInconclusiveMatch},
+		{"negated exact", PropertyFilter{Key: "plan", Operator: PropertyOperatorExact, Value: PropertyValue{"enterprise"}, Negation: true}, false, nil},
+		{"negated icontains", PropertyFilter{Key: "email", Operator: PropertyOperatorIContains, Value: PropertyValue{"posthog"}, Negation: true}, true, nil},
+		{"negated missing property", PropertyFilter{Key: "country", Operator: PropertyOperatorExact, Value: PropertyValue{"FR"}, Negation: true}, false, ErrInconclusiveMatch},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			matches, err := matchProperty(tc.filter, properties)
+
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected error %v, got %v", tc.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if matches != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, matches)
+			}
+		})
+	}
+}
+
+func TestFeatureFlagEvaluate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		flag       FeatureFlag
+		properties map[string]string
+		expected   FeatureFlagEvaluation
+		err        error
+	}{
+		{
+			name:     "inactive",
+			flag:     FeatureFlag{Key: "flag", Active: false, Filters: FeatureFlagFilters{Groups: []FeatureFlagGroup{{}}}},
+			expected: FeatureFlagEvaluation{},
+		},
+		{
+			name:     "deleted",
+			flag:     FeatureFlag{Key: "flag", Active: true, Deleted: true, Filters: FeatureFlagFilters{Groups: []FeatureFlagGroup{{}}}},
+			expected: FeatureFlagEvaluation{},
+		},
+		{
+			name:     "no rollout percentage",
+			flag:     FeatureFlag{Key: "flag", Active: true, Filters: FeatureFlagFilters{Groups: []FeatureFlagGroup{{}}}},
+			expected: FeatureFlagEvaluation{Enabled: true},
+		},
+		{
+			name: "experience continuity",
+			flag: FeatureFlag{Key: "flag", Active: true, EnsureExperienceContinuity: true, Filters: FeatureFlagFilters{Groups: []FeatureFlagGroup{{}}}},
+			err:  ErrInconclusiveMatch,
+		},
+		{
+			name: "payload",
+			flag: FeatureFlag{Key: "flag", Active: true, Filters: FeatureFlagFilters{
+				Groups:   []FeatureFlagGroup{{}},
+				Payloads: FeatureFlagPayloads{"true": `{"a":1}`},
+			}},
+			expected: FeatureFlagEvaluation{Enabled: true, Payload: ptr(`{"a":1}`)},
+		},
+		{
+			name: "property match",
+			flag: FeatureFlag{Key: "flag", Active: true, Filters: FeatureFlagFilters{Groups: []FeatureFlagGroup{
+				{Properties: []PropertyFilter{{Key: "plan", Type: PropertyFilterTypePerson, Operator: PropertyOperatorExact, Value: PropertyValue{"free"}}}},
+				{Properties: []PropertyFilter{{Key: "plan", Type: PropertyFilterTypePerson, Operator: PropertyOperatorExact, Value: PropertyValue{"enterprise"}}}},
+			}}},
+			properties: map[string]string{"plan": "enterprise"},
+			expected:   FeatureFlagEvaluation{Enabled: true},
+		},
+		{
+			name: "inconclusive group and no match",
+			flag: FeatureFlag{Key: "flag", Active: true, Filters: FeatureFlagFilters{Groups: []FeatureFlagGroup{
+				{Properties: []PropertyFilter{{Key: "country", Type: PropertyFilterTypePerson, Operator: PropertyOperatorExact, Value: PropertyValue{"FR"}}}},
+				{Properties: []PropertyFilter{{Key: "plan", Type: PropertyFilterTypePerson, Operator: PropertyOperatorExact, Value: PropertyValue{"free"}}}},
+			}}},
+			properties: map[string]string{"plan": "enterprise"},
+			err:        ErrInconclusiveMatch,
+		},
+		{
+			name: "inconclusive group and match",
+			flag: FeatureFlag{Key: "flag", Active: true, Filters: FeatureFlagFilters{Groups: []FeatureFlagGroup{
+				{Properties: []PropertyFilter{{Key: "country", Type: PropertyFilterTypePerson, Operator: PropertyOperatorExact, Value: PropertyValue{"FR"}}}},
+				{Properties: []PropertyFilter{{Key: "plan", Type: PropertyFilterTypePerson, Operator: PropertyOperatorExact, Value: PropertyValue{"enterprise"}}}},
+			}}},
+			properties: map[string]string{"plan": "enterprise"},
+			expected:   FeatureFlagEvaluation{Enabled: true},
+		},
+		{
+			name: "variant override",
+			flag: FeatureFlag{Key: "flag", Active: true, Filters: FeatureFlagFilters{
+				Groups: []FeatureFlagGroup{
+					{},
+					{Properties: []PropertyFilter{{Key: "plan", Type: PropertyFilterTypePerson, Operator: PropertyOperatorExact, Value: PropertyValue{"enterprise"}}}, Variant: ptr("test")},
+				},
+				Multivariate: &FeatureFlagMultivariate{Variants: []FeatureFlagVariant{
+					{Key: "control", RolloutPercentage: 100},
+					{Key: "test", RolloutPercentage: 0},
+				}},
+				Payloads: FeatureFlagPayloads{"test": `"test payload"`},
+			}},
+			properties: map[string]string{"plan": "enterprise"},
+			expected:   FeatureFlagEvaluation{Enabled: true, Variant: "test", Payload: ptr(`"test payload"`)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := tc.flag.Evaluate("distinct_id_0", tc.properties)
+
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected error %v, got %v", tc.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if res.Enabled != tc.expected.Enabled || res.Variant != tc.expected.Variant {
+				t.Errorf("expected enabled=%v variant=%q, got enabled=%v variant=%q", tc.expected.Enabled, tc.expected.Variant, res.Enabled, res.Variant)
+			}
+
+			if (res.Payload == nil) != (tc.expected.Payload == nil) || (res.Payload != nil && *res.Payload != *tc.expected.Payload) {
+				t.Errorf("unexpected payload %v", res.Payload)
+			}
+		})
+	}
+}
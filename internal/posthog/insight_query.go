@@ -0,0 +1,243 @@
+package posthog
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+type InsightQueryKind string
+
+const (
+	InsightQueryKindInsightViz        InsightQueryKind = "InsightVizNode"
+	InsightQueryKindDataVisualization InsightQueryKind = "DataVisualizationNode"
+	InsightQueryKindTrends            InsightQueryKind = "TrendsQuery"
+	InsightQueryKindFunnels           InsightQueryKind = "FunnelsQuery"
+	InsightQueryKindRetention         InsightQueryKind = "RetentionQuery"
+	InsightQueryKindPaths             InsightQueryKind = "PathsQuery"
+	InsightQueryKindStickiness        InsightQueryKind = "StickinessQuery"
+	InsightQueryKindLifecycle         InsightQueryKind = "LifecycleQuery"
+	InsightQueryKindHogQL             InsightQueryKind = "HogQLQuery"
+	InsightQueryKindEvents            InsightQueryKind = "EventsNode"
+	InsightQueryKindActions           InsightQueryKind = "ActionsNode"
+)
+
+// InsightQuery is the query of an insight: a visualization node wrapping the
+// query that computes the results of the insight.
+type InsightQuery struct {
+	Kind   InsightQueryKind   `json:"kind"`
+	Source InsightQuerySource `json:"source"`
+}
+
+// InsightQuerySource holds the fields of all the supported query kinds. Only
+// the fields relevant to Kind should be set.
+type InsightQuerySource struct {
+	Kind               InsightQueryKind        `json:"kind"`
+	Series             []InsightSeries         `json:"series,omitempty"`
+	Interval           string                  `json:"interval,omitempty"`
+	DateRange          *InsightDateRange       `json:"dateRange,omitempty"`
+	Properties         InsightProperties       `json:"properties,omitempty"`
+	FilterTestAccounts *bool                   `json:"filterTestAccounts,omitempty"`
+	BreakdownFilter    *InsightBreakdownFilter `json:"breakdownFilter,omitempty"`
+	TrendsFilter       *InsightTrendsFilter    `json:"trendsFilter,omitempty"`
+	FunnelsFilter      *InsightFunnelsFilter   `json:"funnelsFilter,omitempty"`
+	RetentionFilter    *InsightRetentionFilter `json:"retentionFilter,omitempty"`
+	PathsFilter        *InsightPathsFilter     `json:"pathsFilter,omitempty"`
+
+	// HogQL queries
+	Query string `json:"query,omitempty"`
+}
+
+// InsightSeries is an events or actions node, used for the series of trends
+// and the steps of funnels. Events nodes without an event match all events.
+type InsightSeries struct {
+	Kind         InsightQueryKind  `json:"kind"`
+	Event        *string           `json:"event,omitempty"`
+	ID           *ActionID         `json:"id,omitempty"`
+	Name         string            `json:"name,omitempty"`
+	CustomName   string            `json:"custom_name,omitempty"`
+	Math         string            `json:"math,omitempty"`
+	MathProperty string            `json:"math_property,omitempty"`
+	Properties   InsightProperties `json:"properties,omitempty"`
+}
+
+type InsightDateRange struct {
+	DateFrom string `json:"date_from,omitempty"`
+	DateTo   string `json:"date_to,omitempty"`
+}
+
+type InsightBreakdownFilter struct {
+	Breakdown     string             `json:"breakdown"`
+	BreakdownType PropertyFilterType `json:"breakdown_type"`
+}
+
+type InsightTrendsFilter struct {
+	Display string `json:"display,omitempty"`
+	Formula string `json:"formula,omitempty"`
+}
+
+type InsightFunnelsFilter struct {
+	FunnelOrderType          string `json:"funnelOrderType,omitempty"`
+	FunnelVizType            string `json:"funnelVizType,omitempty"`
+	FunnelWindowInterval     *int64 `json:"funnelWindowInterval,omitempty"`
+	FunnelWindowIntervalUnit string `json:"funnelWindowIntervalUnit,omitempty"`
+}
+
+type InsightRetentionFilter struct {
+	TargetEntity    *InsightEntity `json:"targetEntity,omitempty"`
+	ReturningEntity *InsightEntity `json:"returningEntity,omitempty"`
+	RetentionType   string         `json:"retentionType,omitempty"`
+	TotalIntervals  *int64         `json:"totalIntervals,omitempty"`
+	Period          string         `json:"period,omitempty"`
+}
+
+type InsightEntityType string
+
+const (
+	InsightEntityTypeEvents  InsightEntityType = "events"
+	InsightEntityTypeActions InsightEntityType = "actions"
+)
+
+// InsightEntity is an event or an action, as used in retention queries. The
+// ID is the event name for events, and the action ID for actions.
+type InsightEntity struct {
+	ID   string            `json:"id"`
+	Type InsightEntityType `json:"type"`
+	Name string            `json:"name,omitempty"`
+}
+
+func (e InsightEntity) MarshalJSON() ([]byte, error) {
+	type rawInsightEntity struct {
+		ID   any               `json:"id"`
+		Type InsightEntityType `json:"type"`
+		Name string            `json:"name,omitempty"`
+	}
+
+	id, err := entityIDToJSON(e.Type, e.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(rawInsightEntity{ID: id, Type: e.Type, Name: e.Name})
+}
+
+func (e *InsightEntity) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		ID   any               `json:"id"`
+		Type InsightEntityType `json:"type"`
+		Name string            `json:"name"`
+	}
+
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	id, err := entityIDFromJSON(raw.ID)
+	if err != nil {
+		return err
+	}
+
+	e.ID = id
+	e.Type = raw.Type
+	e.Name = raw.Name
+
+	return nil
+}
+
+// entityIDToJSON returns the value to send as the ID of an entity: action IDs
+// are numbers, while event IDs are event names.
+func entityIDToJSON(t InsightEntityType, id string) (any, error) {
+	if t != InsightEntityTypeActions {
+		return id, nil
+	}
+
+	actionID, err := ActionIDFromString(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid action ID %q: %w", id, err)
+	}
+
+	return actionID, nil
+}
+
+// entityIDFromJSON is the reverse of entityIDToJSON.
+func entityIDFromJSON(v any) (string, error) {
+	switch id := v.(type) {
+	case string:
+		return id, nil
+	case float64:
+		return strconv.FormatFloat(id, 'f', -1, 64), nil
+	case nil:
+		return "", nil
+	default:
+		return "", fmt.Errorf("unexpected entity ID %v", v)
+	}
+}
+
+type InsightPathsFilter struct {
+	IncludeEventTypes []string `json:"includeEventTypes,omitempty"`
+	StartPoint        string   `json:"startPoint,omitempty"`
+	EndPoint          string   `json:"endPoint,omitempty"`
+	StepLimit         *int64   `json:"stepLimit,omitempty"`
+}
+
+// InsightProperties are the property filters of a query. The web UI sometimes
+// stores them as groups of filters: those are flattened when decoding, as
+// long as all the groups are AND groups.
+type InsightProperties []PropertyFilter
+
+func (p *InsightProperties) UnmarshalJSON(b []byte) error {
+	var filters []PropertyFilter
+	if err := json.Unmarshal(b, &filters); err == nil {
+		*p = filters
+		return nil
+	}
+
+	var group propertyFilterGroup
+	if err := json.Unmarshal(b, &group); err != nil {
+		return fmt.Errorf("error decoding property filters: %w", err)
+	}
+
+	res, err := group.flatten()
+	if err != nil {
+		return err
+	}
+
+	*p = res
+
+	return nil
+}
+
+type propertyFilterGroup struct {
+	Type   PropertyGroupType `json:"type"`
+	Values []json.RawMessage `json:"values"`
+}
+
+func (g propertyFilterGroup) flatten() ([]PropertyFilter, error) {
+	if g.Type != PropertyGroupTypeAnd && len(g.Values) > 1 {
+		return nil, fmt.Errorf("unsupported %s property group", g.Type)
+	}
+
+	var res []PropertyFilter
+
+	for _, v := range g.Values {
+		var nested propertyFilterGroup
+		if err := json.Unmarshal(v, &nested); err == nil && (nested.Type == PropertyGroupTypeAnd || nested.Type == PropertyGroupTypeOr) {
+			filters, err := nested.flatten()
+			if err != nil {
+				return nil, err
+			}
+
+			res = append(res, filters...)
+			continue
+		}
+
+		var filter PropertyFilter
+		if err := json.Unmarshal(v, &filter); err != nil {
+			return nil, fmt.Errorf("error decoding property filter: %w", err)
+		}
+
+		res = append(res, filter)
+	}
+
+	return res, nil
+}
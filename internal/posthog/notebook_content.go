@@ -0,0 +1,275 @@
+package posthog
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// NotebookNode is a node of the ProseMirror document of a notebook.
+type NotebookNode struct {
+	Type    string         `json:"type"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+	Content []NotebookNode `json:"content,omitempty"`
+	Text    string         `json:"text,omitempty"`
+	Marks   []NotebookMark `json:"marks,omitempty"`
+}
+
+type NotebookMark struct {
+	Type  string         `json:"type"`
+	Attrs map[string]any `json:"attrs,omitempty"`
+}
+
+// NotebookEmbedKinds lists the kinds of objects that can be embedded in
+// Markdown notebooks, see NotebookContentFromMarkdown.
+var NotebookEmbedKinds = []string{"insight", "feature_flag", "cohort", "experiment", "survey", "recording"}
+
+var (
+	notebookHeadingRe = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	notebookBulletRe  = regexp.MustCompile(`^[-*+]\s+(.*)$`)
+	notebookOrderedRe = regexp.MustCompile(`^\d+[.)]\s+(.*)$`)
+	notebookRuleRe    = regexp.MustCompile(`^(-{3,}|\*{3,}|_{3,})$`)
+	notebookEmbedRe   = regexp.MustCompile(`^\{\{\s*(\w+)\s+([^\s}]+)\s*\}\}$`)
+)
+
+const notebookCodeFenceLine = "```"
+
+// NotebookContentFromMarkdown converts a simplified Markdown document to the
+// ProseMirror document of a notebook, starting with the title as a level 1
+// heading like in notebooks created from the web UI.
+//
+// Supported blocks are headings, paragraphs, bullet and ordered lists (not
+// nested), block quotes, code blocks and horizontal rules. Supported inline
+// formatting is bold, italic, inline code and links. A line of the form
+// {{KIND ID}} embeds a PostHog object, where KIND is one of
+// NotebookEmbedKinds (eg. {{insight aBcD1234}} embeds the insight with the
+// short ID aBcD1234).
+func NotebookContentFromMarkdown(title string, markdown string) (NotebookNode, error) {
+	doc := NotebookNode{
+		Type: "doc",
+		Content: []NotebookNode{
+			{Type: "heading", Attrs: map[string]any{"level": 1}, Content: notebookText(title, nil)},
+		},
+	}
+
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+
+	var paragraph []string
+	var list *NotebookNode
+	var quote []string
+
+	flush := func() {
+		if len(paragraph) > 0 {
+			doc.Content = append(doc.Content, NotebookNode{Type: "paragraph", Content: parseNotebookInline(strings.Join(paragraph, " "), nil)})
+			paragraph = nil
+		}
+
+		if list != nil {
+			doc.Content = append(doc.Content, *list)
+			list = nil
+		}
+
+		if len(quote) > 0 {
+			doc.Content = append(doc.Content, NotebookNode{
+				Type:    "blockquote",
+				Content: []NotebookNode{{Type: "paragraph", Content: parseNotebookInline(strings.Join(quote, " "), nil)}},
+			})
+			quote = nil
+		}
+	}
+
+	addListItem := func(listType string, text string) {
+		if list != nil && list.Type != listType {
+			flush()
+		}
+
+		if list == nil {
+			flush()
+			list = &NotebookNode{Type: listType}
+		}
+
+		list.Content = append(list.Content, NotebookNode{
+			Type:    "listItem",
+			Content: []NotebookNode{{Type: "paragraph", Content: parseNotebookInline(text, nil)}},
+		})
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flush()
+		case strings.HasPrefix(trimmed, notebookCodeFenceLine):
+			flush()
+
+			language := strings.TrimSpace(strings.TrimPrefix(trimmed, notebookCodeFenceLine))
+			start := i
+
+			var code []string
+			for i++; i < len(lines) && strings.TrimSpace(lines[i]) != notebookCodeFenceLine; i++ {
+				code = append(code, lines[i])
+			}
+
+			if i == len(lines) {
+				return NotebookNode{}, fmt.Errorf("line %d: unterminated code block", start+1)
+			}
+
+			node := NotebookNode{Type: "codeBlock", Content: notebookText(strings.Join(code, "\n"), nil)}
+			if language != "" {
+				node.Attrs = map[string]any{"language": language}
+			}
+
+			doc.Content = append(doc.Content, node)
+		case notebookEmbedRe.MatchString(trimmed):
+			flush()
+
+			m := notebookEmbedRe.FindStringSubmatch(trimmed)
+
+			node, err := notebookEmbed(m[1], m[2])
+			if err != nil {
+				return NotebookNode{}, fmt.Errorf("line %d: %w", i+1, err)
+			}
+
+			doc.Content = append(doc.Content, node)
+		case notebookHeadingRe.MatchString(trimmed):
+			flush()
+
+			m := notebookHeadingRe.FindStringSubmatch(trimmed)
+			doc.Content = append(doc.Content, NotebookNode{
+				Type:    "heading",
+				Attrs:   map[string]any{"level": len(m[1])},
+				Content: parseNotebookInline(m[2], nil),
+			})
+		case notebookRuleRe.MatchString(trimmed):
+			flush()
+			doc.Content = append(doc.Content, NotebookNode{Type: "horizontalRule"})
+		case notebookBulletRe.MatchString(trimmed):
+			addListItem("bulletList", notebookBulletRe.FindStringSubmatch(trimmed)[1])
+		case notebookOrderedRe.MatchString(trimmed):
+			addListItem("orderedList", notebookOrderedRe.FindStringSubmatch(trimmed)[1])
+		case strings.HasPrefix(trimmed, ">"):
+			if len(quote) == 0 {
+				flush()
+			}
+
+			quote = append(quote, strings.TrimSpace(strings.TrimPrefix(trimmed, ">")))
+		default:
+			if list != nil || len(quote) > 0 {
+				flush()
+			}
+
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+
+	flush()
+
+	return doc, nil
+}
+
+// notebookEmbed returns the node embedding the object of the given kind.
+func notebookEmbed(kind string, id string) (NotebookNode, error) {
+	switch kind {
+	case "insight":
+		return NotebookNode{
+			Type:  "ph-query",
+			Attrs: map[string]any{"query": map[string]any{"kind": "SavedInsightNode", "shortId": id}},
+		}, nil
+	case "feature_flag", "cohort", "experiment":
+		numericID, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return NotebookNode{}, fmt.Errorf("invalid %s ID %q: %w", kind, id, err)
+		}
+
+		return NotebookNode{Type: "ph-" + strings.ReplaceAll(kind, "_", "-"), Attrs: map[string]any{"id": numericID}}, nil
+	case "survey", "recording":
+		return NotebookNode{Type: "ph-" + kind, Attrs: map[string]any{"id": id}}, nil
+	default:
+		return NotebookNode{}, fmt.Errorf("unsupported embedded object %q, expected one of %s", kind, strings.Join(NotebookEmbedKinds, ", "))
+	}
+}
+
+// notebookText returns a text node, or no node at all for empty text since
+// ProseMirror doesn't allow empty text nodes.
+func notebookText(text string, marks []NotebookMark) []NotebookNode {
+	if text == "" {
+		return nil
+	}
+
+	return []NotebookNode{{Type: "text", Text: text, Marks: marks}}
+}
+
+// parseNotebookInline converts inline Markdown formatting to marked text
+// nodes. Unterminated formatting is kept as is.
+func parseNotebookInline(s string, marks []NotebookMark) []NotebookNode {
+	var res []NotebookNode
+	var text strings.Builder
+
+	withMark := func(mark NotebookMark) []NotebookMark {
+		return append(append([]NotebookMark(nil), marks...), mark)
+	}
+
+	flushText := func() {
+		res = append(res, notebookText(text.String(), marks)...)
+		text.Reset()
+	}
+
+	for i := 0; i < len(s); {
+		rest := s[i:]
+
+		switch {
+		case strings.HasPrefix(rest, "`"):
+			if end := strings.Index(rest[1:], "`"); end > 0 {
+				flushText()
+				res = append(res, notebookText(rest[1:1+end], withMark(NotebookMark{Type: "code"}))...)
+				i += end + 2
+				continue
+			}
+		case strings.HasPrefix(rest, "**"):
+			if end := strings.Index(rest[2:], "**"); end > 0 {
+				flushText()
+				res = append(res, parseNotebookInline(rest[2:2+end], withMark(NotebookMark{Type: "bold"}))...)
+				i += end + 4
+				continue
+			}
+		case strings.HasPrefix(rest, "*") || (strings.HasPrefix(rest, "_") && !isNotebookWordByte(s, i-1)):
+			// underscores within words (eg. snake_case) are not formatting
+			if end := strings.Index(rest[1:], rest[:1]); end > 0 {
+				flushText()
+				res = append(res, parseNotebookInline(rest[1:1+end], withMark(NotebookMark{Type: "italic"}))...)
+				i += end + 2
+				continue
+			}
+		case strings.HasPrefix(rest, "["):
+			if textEnd := strings.Index(rest, "]("); textEnd > 0 {
+				if urlEnd := strings.Index(rest[textEnd+2:], ")"); urlEnd > 0 {
+					href := rest[textEnd+2 : textEnd+2+urlEnd]
+					flushText()
+					res = append(res, parseNotebookInline(rest[1:textEnd], withMark(NotebookMark{Type: "link", Attrs: map[string]any{"href": href}}))...)
+					i += textEnd + 2 + urlEnd + 1
+					continue
+				}
+			}
+		}
+
+		text.WriteByte(s[i])
+		i++
+	}
+
+	flushText()
+
+	return res
+}
+
+func isNotebookWordByte(s string, i int) bool {
+	if i < 0 || i >= len(s) {
+		return false
+	}
+
+	c := s[i]
+
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
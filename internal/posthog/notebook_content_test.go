@@ -0,0 +1,282 @@
+package posthog
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const notebookTestTitle = `{"type":"heading","attrs":{"level":1},"content":[{"type":"text","text":"Title"}]}`
+
+func TestNotebookContentFromMarkdown(t *testing.T) {
+	testCases := []struct {
+		name     string
+		markdown string
+		expected string // content after the title heading
+	}{
+		{
+			name:     "empty",
+			markdown: "",
+			expected: ``,
+		},
+		{
+			name:     "paragraphs",
+			markdown: "First line\nsame paragraph\n\nSecond paragraph",
+			expected: `{"type":"paragraph","content":[{"type":"text","text":"First line same paragraph"}]},
+				{"type":"paragraph","content":[{"type":"text","text":"Second paragraph"}]}`,
+		},
+		{
+			name:     "headings",
+			markdown: "## Level 2\n###### Level 6\n####### Not a heading",
+			expected: `{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"Level 2"}]},
+				{"type":"heading","attrs":{"level":6},"content":[{"type":"text","text":"Level 6"}]},
+				{"type":"paragraph","content":[{"type":"text","text":"####### Not a heading"}]}`,
+		},
+		{
+			name:     "heading with formatting",
+			markdown: "# A **bold** heading",
+			expected: `{"type":"heading","attrs":{"level":1},"content":[{"type":"text","text":"A "},{"type":"text","text":"bold","marks":[{"type":"bold"}]},{"type":"text","text":" heading"}]}`,
+		},
+		{
+			name:     "bullet list",
+			markdown: "- one\n* two\n+ three",
+			expected: `{"type":"bulletList","content":[
+				{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"one"}]}]},
+				{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"two"}]}]},
+				{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"three"}]}]}
+			]}`,
+		},
+		{
+			name:     "ordered list followed by bullet list",
+			markdown: "1. one\n2) two\n- three",
+			expected: `{"type":"orderedList","content":[
+				{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"one"}]}]},
+				{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"two"}]}]}
+			]},
+			{"type":"bulletList","content":[
+				{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"three"}]}]}
+			]}`,
+		},
+		{
+			name:     "paragraph after list",
+			markdown: "- item\nnot an item",
+			expected: `{"type":"bulletList","content":[
+				{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"item"}]}]}
+			]},
+			{"type":"paragraph","content":[{"type":"text","text":"not an item"}]}`,
+		},
+		{
+			name:     "block quote",
+			markdown: "> quoted\n> text\nafter",
+			expected: `{"type":"blockquote","content":[{"type":"paragraph","content":[{"type":"text","text":"quoted text"}]}]},
+				{"type":"paragraph","content":[{"type":"text","text":"after"}]}`,
+		},
+		{
+			name:     "code block",
+			markdown: "```sql\nSELECT *\n\n  FROM events\n```",
+			expected: `{"type":"codeBlock","attrs":{"language":"sql"},"content":[{"type":"text","text":"SELECT *\n\n  FROM events"}]}`,
+		},
+		{
+			name:     "code block without language",
+			markdown: "```\n**not bold**\n```",
+			expected: `{"type":"codeBlock","content":[{"type":"text","text":"**not bold**"}]}`,
+		},
+		{
+			name:     "empty code block",
+			markdown: "```\n```",
+			expected: `{"type":"codeBlock"}`,
+		},
+		{
+			name:     "horizontal rule",
+			markdown: "before\n\n---\n\nafter",
+			expected: `{"type":"paragraph","content":[{"type":"text","text":"before"}]},
+				{"type":"horizontalRule"},
+				{"type":"paragraph","content":[{"type":"text","text":"after"}]}`,
+		},
+		{
+			name:     "inline marks",
+			markdown: "**bold** *italic* _also italic_ `code`",
+			expected: `{"type":"paragraph","content":[
+				{"type":"text","text":"bold","marks":[{"type":"bold"}]},
+				{"type":"text","text":" "},
+				{"type":"text","text":"italic","marks":[{"type":"italic"}]},
+				{"type":"text","text":" "},
+				{"type":"text","text":"also italic","marks":[{"type":"italic"}]},
+				{"type":"text","text":" "},
+				{"type":"text","text":"code","marks":[{"type":"code"}]}
+			]}`,
+		},
+		{
+			name:     "nested marks",
+			markdown: "**bold and *italic* text**",
+			expected: `{"type":"paragraph","content":[
+				{"type":"text","text":"bold and ","marks":[{"type":"bold"}]},
+				{"type":"text","text":"italic","marks":[{"type":"bold"},{"type":"italic"}]},
+				{"type":"text","text":" text","marks":[{"type":"bold"}]}
+			]}`,
+		},
+		{
+			name:     "code is not formatted",
+			markdown: "`**raw**`",
+			expected: `{"type":"paragraph","content":[{"type":"text","text":"**raw**","marks":[{"type":"code"}]}]}`,
+		},
+		{
+			name:     "link",
+			markdown: "See [the **docs**](https://posthog.com/docs).",
+			expected: `{"type":"paragraph","content":[
+				{"type":"text","text":"See "},
+				{"type":"text","text":"the ","marks":[{"type":"link","attrs":{"href":"https://posthog.com/docs"}}]},
+				{"type":"text","text":"docs","marks":[{"type":"link","attrs":{"href":"https://posthog.com/docs"}},{"type":"bold"}]},
+				{"type":"text","text":"."}
+			]}`,
+		},
+		{
+			name:     "intraword underscores",
+			markdown: "the snake_case_name property",
+			expected: `{"type":"paragraph","content":[{"type":"text","text":"the snake_case_name property"}]}`,
+		},
+		{
+			name:     "unterminated formatting",
+			markdown: "**not bold and [not a link",
+			expected: `{"type":"paragraph","content":[{"type":"text","text":"**not bold and [not a link"}]}`,
+		},
+		{
+			name:     "embeds",
+			markdown: "{{insight aBcD1234}}\n{{ feature_flag 12 }}\n{{cohort 3}}\n{{experiment 4}}\n{{survey 0190-abcd}}\n{{recording 0190-ef01}}",
+			expected: `{"type":"ph-query","attrs":{"query":{"kind":"SavedInsightNode","shortId":"aBcD1234"}}},
+				{"type":"ph-feature-flag","attrs":{"id":12}},
+				{"type":"ph-cohort","attrs":{"id":3}},
+				{"type":"ph-experiment","attrs":{"id":4}},
+				{"type":"ph-survey","attrs":{"id":"0190-abcd"}},
+				{"type":"ph-recording","attrs":{"id":"0190-ef01"}}`,
+		},
+		{
+			name:     "windows line endings",
+			markdown: "## Heading\r\ntext\r\n",
+			expected: `{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"Heading"}]},
+				{"type":"paragraph","content":[{"type":"text","text":"text"}]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := NotebookContentFromMarkdown("Title", tc.markdown)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			got, err := json.Marshal(doc)
+			if err != nil {
+				t.Fatalf("error marshaling document: %s", err)
+			}
+
+			content := notebookTestTitle
+			if tc.expected != "" {
+				content += "," + tc.expected
+			}
+
+			var expected bytes.Buffer
+			if err := json.Compact(&expected, []byte(`{"type":"doc","content":[`+content+`]}`)); err != nil {
+				t.Fatalf("invalid expected document: %s", err)
+			}
+
+			if !bytes.Equal(got, expected.Bytes()) {
+				t.Errorf("unexpected document\nexpected: %s\n     got: %s", expected.String(), got)
+			}
+		})
+	}
+}
+
+func TestNotebookContentFromMarkdownErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		markdown string
+		expected string
+	}{
+		{"unterminated code block", "text\n\n```go\nfunc main() {}", "line 3: unterminated code block"},
+		{"unsupported embed", "{{dashboard 12}}", `line 1: unsupported embedded object "dashboard"`},
+		{"invalid numeric ID", "\n{{cohort abc}}", `line 2: invalid cohort ID "abc"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NotebookContentFromMarkdown("Title", tc.markdown)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+
+			if !strings.HasPrefix(err.Error(), tc.expected) {
+				t.Errorf("expected error starting with %q, got %q", tc.expected, err)
+			}
+		})
+	}
+}
+
+// The document is sent to PostHog as JSON and stored in the state, so the
+// conversion must give the same output every time, and decoding then encoding
+// it again must not change it.
+func TestNotebookContentFromMarkdownStability(t *testing.T) {
+	markdown := strings.Join([]string{
+		"# Overview",
+		"",
+		"Some **bold**, *italic*, `code` and a [link](https://posthog.com).",
+		"",
+		"- one",
+		"- two",
+		"",
+		"1. first",
+		"2. second",
+		"",
+		"> a quote",
+		"",
+		"```python",
+		"print('hello')",
+		"```",
+		"",
+		"---",
+		"",
+		"{{insight aBcD1234}}",
+		"{{feature_flag 12}}",
+	}, "\n")
+
+	doc, err := NotebookContentFromMarkdown("Title", markdown)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	first, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("error marshaling document: %s", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		doc, err := NotebookContentFromMarkdown("Title", markdown)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		again, err := json.Marshal(doc)
+		if err != nil {
+			t.Fatalf("error marshaling document: %s", err)
+		}
+
+		if !bytes.Equal(first, again) {
+			t.Fatalf("conversion is not stable\nfirst: %s\nagain: %s", first, again)
+		}
+	}
+
+	var decoded NotebookNode
+	if err := json.Unmarshal(first, &decoded); err != nil {
+		t.Fatalf("error unmarshaling document: %s", err)
+	}
+
+	reencoded, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatalf("error marshaling decoded document: %s", err)
+	}
+
+	if !bytes.Equal(first, reencoded) {
+		t.Errorf("document changed after a JSON round trip\nbefore: %s\n after: %s", first, reencoded)
+	}
+}
@@ -0,0 +1,161 @@
+package posthog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+type PropertyFilterType string
+
+const (
+	PropertyFilterTypePerson  PropertyFilterType = "person"
+	PropertyFilterTypeEvent   PropertyFilterType = "event"
+	PropertyFilterTypeGroup   PropertyFilterType = "group"
+	PropertyFilterTypeElement PropertyFilterType = "element"
+	PropertyFilterTypeSession PropertyFilterType = "session"
+	PropertyFilterTypeCohort  PropertyFilterType = "cohort"
+	PropertyFilterTypeHogQL   PropertyFilterType = "hogql"
+)
+
+type PropertyOperator string
+
+const (
+	PropertyOperatorExact        PropertyOperator = "exact"
+	PropertyOperatorIsNot        PropertyOperator = "is_not"
+	PropertyOperatorIContains    PropertyOperator = "icontains"
+	PropertyOperatorNotIContains PropertyOperator = "not_icontains"
+	PropertyOperatorRegex        PropertyOperator = "regex"
+	PropertyOperatorNotRegex     PropertyOperator = "not_regex"
+	PropertyOperatorGT           PropertyOperator = "gt"
+	PropertyOperatorGTE          PropertyOperator = "gte"
+	PropertyOperatorLT           PropertyOperator = "lt"
+	PropertyOperatorLTE          PropertyOperator = "lte"
+	PropertyOperatorIsSet        PropertyOperator = "is_set"
+	PropertyOperatorIsNotSet     PropertyOperator = "is_not_set"
+	PropertyOperatorIsDateBefore PropertyOperator = "is_date_before"
+	PropertyOperatorIsDateAfter  PropertyOperator = "is_date_after"
+	PropertyOperatorIn           PropertyOperator = "in"
+	PropertyOperatorNotIn        PropertyOperator = "not_in"
+	PropertyOperatorIsDateExact  PropertyOperator = "is_date_exact"
+)
+
+// PropertyValue holds the value(s) a property filter is compared against.
+//
+// The API accepts (and returns) either a scalar or a list depending on the
+// operator, PropertyValue normalizes both to a list of strings.
+type PropertyValue []string
+
+func (v *PropertyValue) UnmarshalJSON(b []byte) error {
+	var raw any
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+
+	switch x := raw.(type) {
+	case nil:
+		*v = nil
+	case []any:
+		res := make(PropertyValue, 0, len(x))
+		for _, item := range x {
+			s, err := propertyValueScalarString(item)
+			if err != nil {
+				return err
+			}
+			res = append(res, s)
+		}
+		*v = res
+	default:
+		s, err := propertyValueScalarString(x)
+		if err != nil {
+			return err
+		}
+		*v = PropertyValue{s}
+	}
+
+	return nil
+}
+
+func propertyValueScalarString(v any) (string, error) {
+	switch x := v.(type) {
+	case string:
+		return x, nil
+	case json.Number:
+		return x.String(), nil
+	case bool:
+		return strconv.FormatBool(x), nil
+	default:
+		return "", fmt.Errorf("unsupported property value %v", v)
+	}
+}
+
+type PropertyFilter struct {
+	Key            string             `json:"key"`
+	Type           PropertyFilterType `json:"type"`
+	Operator       PropertyOperator   `json:"operator,omitempty"`
+	Value          PropertyValue      `json:"value,omitempty"`
+	GroupTypeIndex *int64             `json:"group_type_index,omitempty"`
+	Negation       bool               `json:"negation,omitempty"`
+}
+
+func (f PropertyFilter) MarshalJSON() ([]byte, error) {
+	type rawPropertyFilter struct {
+		Key            string             `json:"key"`
+		Type           PropertyFilterType `json:"type"`
+		Operator       PropertyOperator   `json:"operator,omitempty"`
+		Value          any                `json:"value,omitempty"`
+		GroupTypeIndex *int64             `json:"group_type_index,omitempty"`
+		Negation       bool               `json:"negation,omitempty"`
+	}
+
+	raw := rawPropertyFilter{
+		Key:            f.Key,
+		Type:           f.Type,
+		Operator:       f.Operator,
+		GroupTypeIndex: f.GroupTypeIndex,
+		Negation:       f.Negation,
+	}
+
+	switch {
+	case len(f.Value) == 0:
+		// no value, e.g. for is_set
+	case f.Type == PropertyFilterTypeCohort:
+		// cohort filters reference the cohort by its numeric ID
+		id, err := strconv.ParseUint(f.Value[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cohort ID %q: %w", f.Value[0], err)
+		}
+		raw.Value = id
+	case len(f.Value) > 1 || f.Operator == PropertyOperatorExact || f.Operator == PropertyOperatorIsNot || f.Operator == PropertyOperatorIn || f.Operator == PropertyOperatorNotIn:
+		raw.Value = []string(f.Value)
+	default:
+		raw.Value = f.Value[0]
+	}
+
+	return json.Marshal(raw)
+}
+
+func (f *PropertyFilter) UnmarshalJSON(b []byte) error {
+	type rawPropertyFilter PropertyFilter
+	if err := json.Unmarshal(b, (*rawPropertyFilter)(f)); err != nil {
+		return err
+	}
+
+	if f.Operator == PropertyOperatorIsSet || f.Operator == PropertyOperatorIsNotSet {
+		// The web UI stores the operator name as the value
+		f.Value = nil
+	}
+
+	return nil
+}
+
+type PropertyGroupType string
+
+const (
+	PropertyGroupTypeAnd PropertyGroupType = "AND"
+	PropertyGroupTypeOr  PropertyGroupType = "OR"
+)
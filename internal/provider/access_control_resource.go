@@ -0,0 +1,484 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+var _ resource.Resource = &accessControlResource{}
+var _ resource.ResourceWithImportState = &accessControlResource{}
+var _ resource.ResourceWithConfigValidators = &accessControlResource{}
+var _ resource.ResourceWithValidateConfig = &accessControlResource{}
+
+func newAccessControlResource() resource.Resource {
+	return &accessControlResource{}
+}
+
+type accessControlResource struct {
+	client *posthog.Client
+}
+
+type accessControlResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	ProjectID   types.String `tfsdk:"project_id"`
+	Resource    types.String `tfsdk:"resource"`
+	ResourceID  types.String `tfsdk:"resource_id"`
+	RoleID      types.String `tfsdk:"role_id"`
+	UserUUID    types.String `tfsdk:"user_uuid"`
+	AccessLevel types.String `tfsdk:"access_level"`
+}
+
+var (
+	projectAccessLevels = []string{
+		string(posthog.AccessLevelNone),
+		string(posthog.AccessLevelMember),
+		string(posthog.AccessLevelAdmin),
+	}
+	resourceAccessLevels = []string{
+		string(posthog.AccessLevelNone),
+		string(posthog.AccessLevelViewer),
+		string(posthog.AccessLevelEditor),
+	}
+)
+
+func (r *accessControlResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_control"
+}
+
+func (r *accessControlResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Access Control, granting a role or an organization member access to a project, to a single resource of a project, or to all the resources of a type in a project. Access control must be enabled on the project (see `enable_access_control` in `posthog_project`).",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the access control",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the access control",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"resource": schema.StringAttribute{
+				MarkdownDescription: "Type of the resource access is granted to: `project`, `dashboard`, `feature_flag`, `insight` or `notebook`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(posthog.AccessControlResourceProject),
+						string(posthog.AccessControlResourceDashboard),
+						string(posthog.AccessControlResourceFeatureFlag),
+						string(posthog.AccessControlResourceInsight),
+						string(posthog.AccessControlResourceNotebook),
+					),
+				},
+			},
+			"resource_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the resource (the short ID for notebooks). When unset, access is granted to all the resources of that type in the project. Must be unset when `resource` is `project`.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the role access is granted to",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_uuid": schema.StringAttribute{
+				MarkdownDescription: "UUID of the organization member access is granted to, for example the `id` of a `posthog_organization_membership`",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"access_level": schema.StringAttribute{
+				MarkdownDescription: "Access granted: `none`, `member` or `admin` for projects, `none`, `viewer` or `editor` for other resources",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(posthog.AccessLevelNone),
+						string(posthog.AccessLevelViewer),
+						string(posthog.AccessLevelEditor),
+						string(posthog.AccessLevelMember),
+						string(posthog.AccessLevelAdmin),
+					),
+				},
+			},
+		},
+	}
+}
+
+func (r *accessControlResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("role_id"),
+			path.MatchRoot("user_uuid"),
+		),
+	}
+}
+
+func (r *accessControlResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *accessControlResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data accessControlResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Resource.IsUnknown() || data.Resource.IsNull() {
+		return
+	}
+
+	isProject := data.Resource.ValueString() == string(posthog.AccessControlResourceProject)
+
+	if isProject && !data.ResourceID.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("resource_id"), "Unexpected resource ID", "The resource ID must not be set for project access controls, the project is set in project_id.")
+	}
+
+	if data.AccessLevel.IsUnknown() || data.AccessLevel.IsNull() {
+		return
+	}
+
+	accessLevels := resourceAccessLevels
+	if isProject {
+		accessLevels = projectAccessLevels
+	}
+
+	if !slices.Contains(accessLevels, data.AccessLevel.ValueString()) {
+		resp.Diagnostics.AddAttributeError(path.Root("access_level"), "Invalid access level", fmt.Sprintf("The access level of %s access controls must be one of %v.", data.Resource.ValueString(), accessLevels))
+	}
+}
+
+// accessControlTarget holds the parsed identifiers of an access control.
+type accessControlTarget struct {
+	projectID  posthog.ProjectID
+	resource   posthog.AccessControlResource
+	resourceID string
+	request    posthog.SetAccessControlRequest
+
+	// notMember is true when the user is not (or no longer) a member of the
+	// organization of the project, request is not set then.
+	notMember bool
+}
+
+// target parses the identifiers of an access control, looking up the
+// organization membership of the user if needed. If the user is not a member
+// of the organization, notMember is set on the target instead of returning an
+// error: the access control was deleted when they left.
+func (r *accessControlResource) target(ctx context.Context, data accessControlResourceModel) (accessControlTarget, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		diags.AddError("Invalid project ID", err.Error())
+		return accessControlTarget{}, diags
+	}
+
+	t := accessControlTarget{
+		projectID:  projectID,
+		resource:   posthog.AccessControlResource(data.Resource.ValueString()),
+		resourceID: data.ResourceID.ValueString(),
+	}
+
+	if !data.RoleID.IsNull() {
+		roleID, err := posthog.RoleIDFromString(data.RoleID.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("role_id"), "Invalid role ID", err.Error())
+			return accessControlTarget{}, diags
+		}
+
+		t.request.Role = &roleID
+
+		return t, diags
+	}
+
+	// Access controls reference the organization membership, not the user
+
+	project, err := r.client.GetProject(ctx, projectID)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Error getting project %s: %s", projectID, err))
+		return accessControlTarget{}, diags
+	}
+
+	if project == nil {
+		diags.AddAttributeError(path.Root("project_id"), "Project not found", fmt.Sprintf("No project with ID %s.", projectID))
+		return accessControlTarget{}, diags
+	}
+
+	member, err := r.client.GetOrganizationMember(ctx, project.Organization, data.UserUUID.ValueString())
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Error getting organization member %s: %s", data.UserUUID, err))
+		return accessControlTarget{}, diags
+	}
+
+	if member == nil {
+		t.notMember = true
+		return t, diags
+	}
+
+	t.request.OrganizationMember = &member.ID
+
+	return t, diags
+}
+
+// matches tells whether an access control returned by the API is the one of
+// the target.
+func (t accessControlTarget) matches(a posthog.AccessControl) bool {
+	if a.Resource != t.resource {
+		return false
+	}
+
+	switch {
+	case t.resource == posthog.AccessControlResourceProject:
+		// The resource ID is the project ID
+	case t.resourceID == "":
+		if a.ResourceID != nil {
+			return false
+		}
+	default:
+		if a.ResourceID == nil || *a.ResourceID != t.resourceID {
+			return false
+		}
+	}
+
+	if t.request.Role != nil {
+		return a.Role != nil && *a.Role == *t.request.Role
+	}
+
+	return a.OrganizationMember != nil && *a.OrganizationMember == *t.request.OrganizationMember
+}
+
+func (r *accessControlResource) set(ctx context.Context, data *accessControlResourceModel) diag.Diagnostics {
+	t, diags := r.target(ctx, *data)
+	if diags.HasError() {
+		return diags
+	}
+
+	if t.notMember {
+		diags.AddAttributeError(path.Root("user_uuid"), "Not a member", fmt.Sprintf("User %s is not a member of the organization of project %s.", data.UserUUID, t.projectID))
+		return diags
+	}
+
+	accessLevel := posthog.AccessLevel(data.AccessLevel.ValueString())
+	t.request.AccessLevel = &accessLevel
+
+	res, err := r.client.SetAccessControl(ctx, t.projectID, t.resource, t.resourceID, t.request)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Error setting access control: %s", err))
+		return diags
+	}
+
+	data.ID = types.StringValue(res.ID)
+	data.AccessLevel = types.StringValue(string(res.AccessLevel))
+
+	return diags
+}
+
+func (r *accessControlResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data accessControlResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created access control", map[string]interface{}{"access_control_id": data.ID.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *accessControlResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data accessControlResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	t, diags := r.target(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if t.notMember {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	accessControls, err := r.client.GetAccessControls(ctx, t.projectID, t.resource, t.resourceID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting access controls: %s", err))
+		return
+	}
+
+	i := slices.IndexFunc(accessControls, t.matches)
+	if i == -1 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = types.StringValue(accessControls[i].ID)
+	data.AccessLevel = types.StringValue(string(accessControls[i].AccessLevel))
+
+	tflog.Trace(ctx, "read access control", map[string]interface{}{"access_control_id": data.ID.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *accessControlResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data accessControlResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated access control", map[string]interface{}{"access_control_id": data.ID.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *accessControlResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data accessControlResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	t, diags := r.target(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if t.notMember {
+		// The access controls of members are deleted when they leave
+		return
+	}
+
+	if err := r.client.DeleteAccessControl(ctx, t.projectID, t.resource, t.resourceID, t.request); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting access control %s: %s", data.ID, err))
+		return
+	}
+}
+
+// accessControlImportID is the RESOURCE[/RESOURCE_ID]/role/ROLE_ID or
+// RESOURCE[/RESOURCE_ID]/user/USER_UUID part of the import ID of access
+// controls.
+type accessControlImportID struct {
+	resource   string
+	resourceID string
+	roleID     string
+	userUUID   string
+}
+
+func accessControlImportIDFromString(s string) (accessControlImportID, error) {
+	tokens := strings.Split(s, "/")
+	if len(tokens) != 3 && len(tokens) != 4 {
+		return accessControlImportID{}, errors.New("ID not of the form RESOURCE[/RESOURCE_ID]/role/ROLE_ID or RESOURCE[/RESOURCE_ID]/user/USER_UUID")
+	}
+
+	res := accessControlImportID{resource: tokens[0]}
+	if len(tokens) == 4 {
+		res.resourceID = tokens[1]
+	}
+
+	kind, id := tokens[len(tokens)-2], tokens[len(tokens)-1]
+
+	switch {
+	case id == "":
+		return accessControlImportID{}, errors.New("empty role ID or user UUID")
+	case kind == "role":
+		res.roleID = id
+	case kind == "user":
+		res.userUUID = id
+	default:
+		return accessControlImportID{}, fmt.Errorf("unknown access control target %q, expected role or user", kind)
+	}
+
+	return res, nil
+}
+
+func (r *accessControlResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, accessControlID, err := parseImportID(req.ID, "access control", accessControlImportIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resource"), accessControlID.resource)...)
+
+	if accessControlID.resourceID != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resource_id"), accessControlID.resourceID)...)
+	}
+
+	if accessControlID.roleID != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_id"), accessControlID.roleID)...)
+	} else {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_uuid"), accessControlID.userUUID)...)
+	}
+}
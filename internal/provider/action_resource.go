@@ -7,7 +7,6 @@ import (
 	"context"
 	"fmt"
 	"sort"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -593,27 +592,8 @@ func (r *actionResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 }
 
-func parseImportID(s string) (posthog.ProjectID, posthog.ActionID, error) {
-	tokens := strings.SplitN(s, "/", 2)
-	if len(tokens) != 2 {
-		return posthog.ProjectID(0), posthog.ActionID(0), fmt.Errorf("ID not of the form PROJECT_ID/ACTION_ID")
-	}
-
-	projectID, err := posthog.ProjectIDFromString(tokens[0])
-	if err != nil {
-		return posthog.ProjectID(0), posthog.ActionID(0), fmt.Errorf("invalid project ID: %w", err)
-	}
-
-	actionID, err := posthog.ActionIDFromString(tokens[1])
-	if err != nil {
-		return posthog.ProjectID(0), posthog.ActionID(0), fmt.Errorf("invalid action ID: %w", err)
-	}
-
-	return projectID, actionID, nil
-}
-
 func (r *actionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	projectID, actionID, err := parseImportID(req.ID)
+	projectID, actionID, err := parseImportID(req.ID, "action", posthog.ActionIDFromString)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid import ID", err.Error())
 	}
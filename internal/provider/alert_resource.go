@@ -0,0 +1,506 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+var _ resource.Resource = &alertResource{}
+var _ resource.ResourceWithImportState = &alertResource{}
+var _ resource.ResourceWithValidateConfig = &alertResource{}
+
+func newAlertResource() resource.Resource {
+	return &alertResource{}
+}
+
+type alertResource struct {
+	client *posthog.Client
+}
+
+type alertResourceModel struct {
+	ID                   types.String    `tfsdk:"id"`
+	ProjectID            types.String    `tfsdk:"project_id"`
+	Name                 types.String    `tfsdk:"name"`
+	InsightID            types.String    `tfsdk:"insight_id"`
+	SeriesIndex          types.Int64     `tfsdk:"series_index"`
+	Condition            types.String    `tfsdk:"condition"`
+	Threshold            *alertThreshold `tfsdk:"threshold"`
+	CalculationInterval  types.String    `tfsdk:"calculation_interval"`
+	CheckOngoingInterval types.Bool      `tfsdk:"check_ongoing_interval"`
+	SubscribedUserIDs    types.Set       `tfsdk:"subscribed_user_ids"`
+	Enabled              types.Bool      `tfsdk:"enabled"`
+}
+
+type alertThreshold struct {
+	Type  types.String  `tfsdk:"type"`
+	Lower types.Float64 `tfsdk:"lower"`
+	Upper types.Float64 `tfsdk:"upper"`
+}
+
+func (r *alertResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alert"
+}
+
+func (r *alertResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Alert, notifying users when the value of a trends insight crosses a threshold",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the alert",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the alert",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the alert",
+				Required:            true,
+			},
+			"insight_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the insight the alert checks, which must be a trends insight",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"series_index": schema.Int64Attribute{
+				MarkdownDescription: "Index of the series of the insight the alert checks",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"condition": schema.StringAttribute{
+				MarkdownDescription: "What is compared to the threshold: `absolute_value` (the value of the series), `relative_increase` or `relative_decrease` (the change since the previous interval)",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(posthog.AlertConditionTypeAbsoluteValue)),
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(posthog.AlertConditionTypeAbsoluteValue),
+						string(posthog.AlertConditionTypeRelativeIncrease),
+						string(posthog.AlertConditionTypeRelativeDecrease),
+					),
+				},
+			},
+			"threshold": schema.SingleNestedAttribute{
+				MarkdownDescription: "Bounds outside of which the alert fires, at least one of `lower` or `upper` must be set",
+				Required:            true,
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						MarkdownDescription: "Whether the bounds are `absolute` values or `percentage`s (as fractions, 0.1 meaning 10%). Percentages can only be used with relative conditions.",
+						Optional:            true,
+						Computed:            true,
+						Default:             stringdefault.StaticString(string(posthog.AlertThresholdTypeAbsolute)),
+						Validators: []validator.String{
+							stringvalidator.OneOf(
+								string(posthog.AlertThresholdTypeAbsolute),
+								string(posthog.AlertThresholdTypePercentage),
+							),
+						},
+					},
+					"lower": schema.Float64Attribute{
+						MarkdownDescription: "The alert fires when the value goes below this bound",
+						Optional:            true,
+					},
+					"upper": schema.Float64Attribute{
+						MarkdownDescription: "The alert fires when the value goes above this bound",
+						Optional:            true,
+					},
+				},
+			},
+			"calculation_interval": schema.StringAttribute{
+				MarkdownDescription: "How often the alert is checked: `hourly`, `daily`, `weekly` or `monthly`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(posthog.AlertCalculationIntervalDaily)),
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(posthog.AlertCalculationIntervalHourly),
+						string(posthog.AlertCalculationIntervalDaily),
+						string(posthog.AlertCalculationIntervalWeekly),
+						string(posthog.AlertCalculationIntervalMonthly),
+					),
+				},
+			},
+			"check_ongoing_interval": schema.BoolAttribute{
+				MarkdownDescription: "Whether to also check the current interval before it is complete, so that the alert fires as soon as an upper bound is crossed",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"subscribed_user_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of the users notified when the alert fires",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				Default:             setdefault.StaticValue(types.SetValueMust(types.StringType, nil)),
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the alert is checked",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+func (r *alertResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *alertResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data alertResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	threshold := data.Threshold
+	if threshold == nil || threshold.Lower.IsUnknown() || threshold.Upper.IsUnknown() {
+		return
+	}
+
+	if threshold.Lower.IsNull() && threshold.Upper.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("threshold"), "Missing bound", "At least one of lower or upper must be set.")
+	}
+
+	if !threshold.Lower.IsNull() && !threshold.Upper.IsNull() && threshold.Lower.ValueFloat64() > threshold.Upper.ValueFloat64() {
+		resp.Diagnostics.AddAttributeError(path.Root("threshold").AtName("lower"), "Invalid bounds", "The lower bound is greater than the upper bound.")
+	}
+
+	if threshold.Type.ValueString() == string(posthog.AlertThresholdTypePercentage) && data.Condition.ValueString() == string(posthog.AlertConditionTypeAbsoluteValue) {
+		resp.Diagnostics.AddAttributeError(path.Root("threshold").AtName("type"), "Invalid threshold type", "Percentage thresholds can only be used with the relative_increase and relative_decrease conditions.")
+	}
+}
+
+func updateAlertModel(ctx context.Context, model *alertResourceModel, apiAlert *posthog.Alert) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(apiAlert.ID.String())
+	model.Name = types.StringValue(apiAlert.Name)
+	model.InsightID = types.StringValue(apiAlert.Insight.ID.String())
+	model.SeriesIndex = types.Int64Value(apiAlert.Config.SeriesIndex)
+	model.Condition = types.StringValue(string(apiAlert.Condition.Type))
+	model.Threshold = &alertThreshold{
+		Type:  types.StringValue(string(apiAlert.Threshold.Configuration.Type)),
+		Lower: types.Float64PointerValue(apiAlert.Threshold.Configuration.Bounds.Lower),
+		Upper: types.Float64PointerValue(apiAlert.Threshold.Configuration.Bounds.Upper),
+	}
+	model.CalculationInterval = types.StringValue(string(apiAlert.CalculationInterval))
+	model.CheckOngoingInterval = types.BoolValue(apiAlert.Config.CheckOngoingInterval)
+	model.Enabled = types.BoolValue(apiAlert.Enabled)
+
+	subscribedUserIDs := make([]string, len(apiAlert.SubscribedUsers))
+	for i, u := range apiAlert.SubscribedUsers {
+		subscribedUserIDs[i] = u.ID.String()
+	}
+
+	model.SubscribedUserIDs, diags = types.SetValueFrom(ctx, types.StringType, subscribedUserIDs)
+
+	return diags
+}
+
+func alertFromModel(ctx context.Context, data alertResourceModel) (posthog.CreateAlertRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	insightID, err := posthog.InsightIDFromString(data.InsightID.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("insight_id"), "Invalid insight ID", err.Error())
+		return posthog.CreateAlertRequest{}, diags
+	}
+
+	alert := posthog.CreateAlertRequest{
+		Name:    data.Name.ValueString(),
+		Insight: insightID,
+		Threshold: posthog.AlertThreshold{
+			Configuration: posthog.AlertThresholdConfiguration{
+				Type: posthog.AlertThresholdType(data.Threshold.Type.ValueString()),
+				Bounds: posthog.AlertBounds{
+					Lower: data.Threshold.Lower.ValueFloat64Pointer(),
+					Upper: data.Threshold.Upper.ValueFloat64Pointer(),
+				},
+			},
+		},
+		Condition:           posthog.AlertCondition{Type: posthog.AlertConditionType(data.Condition.ValueString())},
+		CalculationInterval: posthog.AlertCalculationInterval(data.CalculationInterval.ValueString()),
+		Enabled:             data.Enabled.ValueBool(),
+		Config: posthog.AlertConfig{
+			SeriesIndex:          data.SeriesIndex.ValueInt64(),
+			CheckOngoingInterval: data.CheckOngoingInterval.ValueBool(),
+		},
+	}
+
+	var subscribedUserIDs []string
+
+	diags.Append(data.SubscribedUserIDs.ElementsAs(ctx, &subscribedUserIDs, false)...)
+	if diags.HasError() {
+		return alert, diags
+	}
+
+	for _, s := range subscribedUserIDs {
+		userID, err := posthog.UserIDFromString(s)
+		if err != nil {
+			diags.AddAttributeError(path.Root("subscribed_user_ids"), "Invalid user ID", err.Error())
+			return alert, diags
+		}
+
+		alert.SubscribedUsers = append(alert.SubscribedUsers, userID)
+	}
+
+	return alert, diags
+}
+
+// checkAlertInsight makes sure that the insight of the alert is a trends
+// insight with the series the alert checks, PostHog only supports alerts on
+// trends.
+func (r *alertResource) checkAlertInsight(ctx context.Context, projectID posthog.ProjectID, alert posthog.CreateAlertRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	insight, err := r.client.GetInsight(ctx, projectID, alert.Insight)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Error getting insight %s: %s", alert.Insight, err))
+		return diags
+	}
+
+	if insight == nil {
+		diags.AddAttributeError(path.Root("insight_id"), "Insight not found", fmt.Sprintf("No insight with ID %s in project %s.", alert.Insight, projectID))
+		return diags
+	}
+
+	var query posthog.InsightQuery
+
+	if err := json.Unmarshal(insight.Query, &query); err != nil || query.Source.Kind != posthog.InsightQueryKindTrends {
+		diags.AddAttributeError(path.Root("insight_id"), "Not a trends insight", fmt.Sprintf("Insight %s is not a trends insight, alerts can only check trends.", alert.Insight))
+		return diags
+	}
+
+	if alert.Config.SeriesIndex >= int64(len(query.Source.Series)) {
+		diags.AddAttributeError(path.Root("series_index"), "Invalid series index", fmt.Sprintf("Insight %s only has %d series.", alert.Insight, len(query.Source.Series)))
+	}
+
+	return diags
+}
+
+func (r *alertResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data alertResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createAlertRequest, diags := alertFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(r.checkAlertInsight(ctx, projectID, createAlertRequest)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create the alert
+
+	res, err := r.client.CreateAlert(ctx, projectID, createAlertRequest)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating alert: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(updateAlertModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created alert", map[string]interface{}{"alert_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *alertResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data alertResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	alertID, err := posthog.AlertIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid alert ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetAlert(ctx, projectID, alertID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting alert %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(updateAlertModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read alert", map[string]interface{}{"alert_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *alertResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data alertResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	alert, diags := alertFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	alertID, err := posthog.AlertIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid alert ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(r.checkAlertInsight(ctx, projectID, alert)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, err := r.client.UpdateAlert(ctx, projectID, alertID, alert)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating alert %s: %s", alertID, err))
+		return
+	}
+
+	resp.Diagnostics.Append(updateAlertModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated alert", map[string]interface{}{"alert_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *alertResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data alertResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	alertID, err := posthog.AlertIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid alert ID", err.Error())
+		return
+	}
+
+	if err := r.client.DeleteAlert(ctx, projectID, alertID); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting alert %s: %s", data.ID, err))
+		return
+	}
+}
+
+func (r *alertResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, alertID, err := parseImportID(req.ID, "alert", posthog.AlertIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), alertID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
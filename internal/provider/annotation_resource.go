@@ -0,0 +1,360 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+	"github.com/abustany/terraform-provider-posthog/internal/typeutil"
+)
+
+var _ resource.Resource = &annotationResource{}
+var _ resource.ResourceWithImportState = &annotationResource{}
+var _ resource.ResourceWithValidateConfig = &annotationResource{}
+
+func newAnnotationResource() resource.Resource {
+	return &annotationResource{}
+}
+
+type annotationResource struct {
+	client *posthog.Client
+}
+
+type annotationResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	ProjectID  types.String `tfsdk:"project_id"`
+	Content    types.String `tfsdk:"content"`
+	DateMarker types.String `tfsdk:"date_marker"`
+	Scope      types.String `tfsdk:"scope"`
+	InsightID  types.String `tfsdk:"insight_id"`
+}
+
+func (r *annotationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_annotation"
+}
+
+func (r *annotationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Annotation",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the annotation",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the annotation",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "Text of the annotation",
+				Required:            true,
+			},
+			"date_marker": schema.StringAttribute{
+				MarkdownDescription: "Date and time the annotation is displayed at, in RFC 3339 format (eg. `2024-01-31T10:00:00Z`)",
+				Required:            true,
+			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "Where the annotation is displayed: `project` (all the insights of the project), `organization` (all the insights of the organization) or `dashboard_item` (only the insight set in `insight_id`)",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(posthog.AnnotationScopeProject)),
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(posthog.AnnotationScopeProject),
+						string(posthog.AnnotationScopeOrganization),
+						string(posthog.AnnotationScopeDashboardItem),
+					),
+				},
+			},
+			"insight_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the insight the annotation is displayed on, required when `scope` is `dashboard_item`",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *annotationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *annotationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data annotationResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.DateMarker.IsUnknown() && !data.DateMarker.IsNull() {
+		if _, err := time.Parse(time.RFC3339, data.DateMarker.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("date_marker"), "Invalid date marker", err.Error())
+		}
+	}
+
+	if data.Scope.IsUnknown() {
+		return
+	}
+
+	isDashboardItem := data.Scope.ValueString() == string(posthog.AnnotationScopeDashboardItem)
+
+	if isDashboardItem && data.InsightID.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("insight_id"), "Missing insight ID", "Annotations scoped to a dashboard item must set the ID of the insight.")
+	}
+
+	if !isDashboardItem && !data.InsightID.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("insight_id"), "Invalid insight ID", "Only annotations scoped to a dashboard item can set the ID of an insight.")
+	}
+}
+
+func updateAnnotationModel(model *annotationResourceModel, apiAnnotation *posthog.Annotation) {
+	model.ID = types.StringValue(apiAnnotation.ID.String())
+	model.Content = types.StringValue(apiAnnotation.Content)
+	model.Scope = types.StringValue(string(apiAnnotation.Scope))
+
+	model.DateMarker = typeutil.TimeValue(model.DateMarker, &apiAnnotation.DateMarker)
+
+	if apiAnnotation.DashboardItem != nil {
+		model.InsightID = types.StringValue(apiAnnotation.DashboardItem.String())
+	} else {
+		model.InsightID = types.StringNull()
+	}
+}
+
+func annotationFieldsFromModel(data annotationResourceModel) (time.Time, *posthog.InsightID, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	dateMarker, err := time.Parse(time.RFC3339, data.DateMarker.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("date_marker"), "Invalid date marker", err.Error())
+		return time.Time{}, nil, diags
+	}
+
+	if data.InsightID.IsNull() {
+		return dateMarker, nil, diags
+	}
+
+	insightID, err := posthog.InsightIDFromString(data.InsightID.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("insight_id"), "Invalid insight ID", err.Error())
+		return time.Time{}, nil, diags
+	}
+
+	return dateMarker, &insightID, diags
+}
+
+func (r *annotationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data annotationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dateMarker, insightID, diags := annotationFieldsFromModel(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createAnnotationRequest := posthog.CreateAnnotationRequest{
+		Content:       data.Content.ValueString(),
+		DateMarker:    dateMarker,
+		Scope:         posthog.AnnotationScope(data.Scope.ValueString()),
+		DashboardItem: insightID,
+	}
+
+	// Create the annotation
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	res, err := r.client.CreateAnnotation(ctx, projectID, createAnnotationRequest)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating annotation: %s", err))
+		return
+	}
+
+	updateAnnotationModel(&data, res)
+
+	tflog.Trace(ctx, "created annotation", map[string]interface{}{"annotation_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *annotationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data annotationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	annotationID, err := posthog.AnnotationIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid annotation ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetAnnotation(ctx, projectID, annotationID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting annotation %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil || res.Deleted {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	updateAnnotationModel(&data, res)
+
+	tflog.Trace(ctx, "read annotation", map[string]interface{}{"annotation_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func annotationFromModel(data annotationResourceModel) (posthog.ProjectID, posthog.Annotation, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		diags.AddError("Invalid project ID", err.Error())
+		return posthog.ProjectID(0), posthog.Annotation{}, diags
+	}
+
+	annotationID, err := posthog.AnnotationIDFromString(data.ID.ValueString())
+	if err != nil {
+		diags.AddError("Invalid annotation ID", err.Error())
+		return posthog.ProjectID(0), posthog.Annotation{}, diags
+	}
+
+	dateMarker, insightID, fieldDiags := annotationFieldsFromModel(data)
+	diags.Append(fieldDiags...)
+	if diags.HasError() {
+		return posthog.ProjectID(0), posthog.Annotation{}, diags
+	}
+
+	return projectID, posthog.Annotation{
+		ID:            annotationID,
+		Content:       data.Content.ValueString(),
+		DateMarker:    dateMarker,
+		Scope:         posthog.AnnotationScope(data.Scope.ValueString()),
+		DashboardItem: insightID,
+	}, diags
+}
+
+func (r *annotationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data annotationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, annotation, diags := annotationFromModel(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, err := r.client.UpdateAnnotation(ctx, projectID, annotation)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating annotation %s: %s", annotation.ID, err))
+		return
+	}
+
+	updateAnnotationModel(&data, res)
+
+	tflog.Trace(ctx, "updated annotation", map[string]interface{}{"annotation_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *annotationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data annotationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, annotation, diags := annotationFromModel(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Annotations are soft deleted, like in the web UI
+	annotation.Deleted = true
+
+	_, err := r.client.UpdateAnnotation(ctx, projectID, annotation)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting annotation %s: %s", annotation.ID, err))
+		return
+	}
+}
+
+func (r *annotationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, annotationID, err := parseImportID(req.ID, "annotation", posthog.AnnotationIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), annotationID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
@@ -0,0 +1,958 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+	"github.com/abustany/terraform-provider-posthog/internal/typeutil"
+)
+
+var _ resource.Resource = &batchExportResource{}
+var _ resource.ResourceWithImportState = &batchExportResource{}
+var _ resource.ResourceWithConfigValidators = &batchExportResource{}
+var _ resource.ResourceWithValidateConfig = &batchExportResource{}
+
+func newBatchExportResource() resource.Resource {
+	return &batchExportResource{}
+}
+
+type batchExportResource struct {
+	client *posthog.Client
+}
+
+type batchExportResourceModel struct {
+	ID            types.String          `tfsdk:"id"`
+	ProjectID     types.String          `tfsdk:"project_id"`
+	Name          types.String          `tfsdk:"name"`
+	Model         types.String          `tfsdk:"model"`
+	Interval      types.String          `tfsdk:"interval"`
+	Paused        types.Bool            `tfsdk:"paused"`
+	IncludeEvents types.List            `tfsdk:"include_events"`
+	ExcludeEvents types.List            `tfsdk:"exclude_events"`
+	S3            *batchExportS3        `tfsdk:"s3"`
+	BigQuery      *batchExportBigQuery  `tfsdk:"bigquery"`
+	Snowflake     *batchExportSnowflake `tfsdk:"snowflake"`
+	Postgres      *batchExportPostgres  `tfsdk:"postgres"`
+	Redshift      *batchExportRedshift  `tfsdk:"redshift"`
+	HTTP          *batchExportHTTP      `tfsdk:"http"`
+	Backfill      types.List            `tfsdk:"backfill"`
+}
+
+type batchExportS3 struct {
+	BucketName         types.String `tfsdk:"bucket_name"`
+	Region             types.String `tfsdk:"region"`
+	Prefix             types.String `tfsdk:"prefix"`
+	AWSAccessKeyID     types.String `tfsdk:"aws_access_key_id"`
+	AWSSecretAccessKey types.String `tfsdk:"aws_secret_access_key"`
+	Compression        types.String `tfsdk:"compression"`
+	Encryption         types.String `tfsdk:"encryption"`
+	KMSKeyID           types.String `tfsdk:"kms_key_id"`
+	EndpointURL        types.String `tfsdk:"endpoint_url"`
+	FileFormat         types.String `tfsdk:"file_format"`
+	MaxFileSizeMB      types.Int64  `tfsdk:"max_file_size_mb"`
+}
+
+type batchExportBigQuery struct {
+	ProjectID    types.String `tfsdk:"project_id"`
+	DatasetID    types.String `tfsdk:"dataset_id"`
+	TableID      types.String `tfsdk:"table_id"`
+	ClientEmail  types.String `tfsdk:"client_email"`
+	PrivateKey   types.String `tfsdk:"private_key"`
+	PrivateKeyID types.String `tfsdk:"private_key_id"`
+	TokenURI     types.String `tfsdk:"token_uri"`
+	UseJSONType  types.Bool   `tfsdk:"use_json_type"`
+}
+
+type batchExportSnowflake struct {
+	Account   types.String `tfsdk:"account"`
+	User      types.String `tfsdk:"user"`
+	Password  types.String `tfsdk:"password"`
+	Database  types.String `tfsdk:"database"`
+	Warehouse types.String `tfsdk:"warehouse"`
+	Schema    types.String `tfsdk:"schema"`
+	TableName types.String `tfsdk:"table_name"`
+	Role      types.String `tfsdk:"role"`
+}
+
+type batchExportPostgres struct {
+	Host              types.String `tfsdk:"host"`
+	Port              types.Int64  `tfsdk:"port"`
+	User              types.String `tfsdk:"user"`
+	Password          types.String `tfsdk:"password"`
+	Database          types.String `tfsdk:"database"`
+	Schema            types.String `tfsdk:"schema"`
+	TableName         types.String `tfsdk:"table_name"`
+	HasSelfSignedCert types.Bool   `tfsdk:"has_self_signed_cert"`
+}
+
+type batchExportRedshift struct {
+	Host               types.String `tfsdk:"host"`
+	Port               types.Int64  `tfsdk:"port"`
+	User               types.String `tfsdk:"user"`
+	Password           types.String `tfsdk:"password"`
+	Database           types.String `tfsdk:"database"`
+	Schema             types.String `tfsdk:"schema"`
+	TableName          types.String `tfsdk:"table_name"`
+	PropertiesDataType types.String `tfsdk:"properties_data_type"`
+}
+
+type batchExportHTTP struct {
+	URL   types.String `tfsdk:"url"`
+	Token types.String `tfsdk:"token"`
+}
+
+type batchExportBackfill struct {
+	StartAt types.String `tfsdk:"start_at"`
+	EndAt   types.String `tfsdk:"end_at"`
+}
+
+func (r *batchExportResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_batch_export"
+}
+
+func batchExportStringAttribute(description string, required bool) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: description,
+		Required:            required,
+		Optional:            !required,
+	}
+}
+
+func batchExportSecretAttribute(description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: description + ". PostHog never returns it, changes made outside of Terraform are not detected.",
+		Required:            true,
+		Sensitive:           true,
+	}
+}
+
+func batchExportBackfillSchema() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "Ranges of data to export again. A backfill is started when a range is added to the list, removing a range has no effect.",
+		Optional:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"start_at": schema.StringAttribute{
+					MarkdownDescription: "Start of the range, in RFC 3339 format",
+					Required:            true,
+				},
+				"end_at": schema.StringAttribute{
+					MarkdownDescription: "End of the range, in RFC 3339 format",
+					Required:            true,
+				},
+			},
+		},
+	}
+}
+
+func (r *batchExportResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Batch Export. Exactly one destination must be set.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the batch export",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the batch export",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the batch export",
+				Required:            true,
+			},
+			"model": schema.StringAttribute{
+				MarkdownDescription: "Data to export, one of `events`, `persons` or `sessions`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(posthog.BatchExportModelEvents)),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(posthog.BatchExportModelEvents),
+						string(posthog.BatchExportModelPersons),
+						string(posthog.BatchExportModelSessions),
+					),
+				},
+			},
+			"interval": schema.StringAttribute{
+				MarkdownDescription: "How often data is exported, one of `hour`, `day` or `every 5 minutes`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(posthog.BatchExportIntervalHour),
+						string(posthog.BatchExportIntervalDay),
+						string(posthog.BatchExportIntervalEvery5Minutes),
+					),
+				},
+			},
+			"paused": schema.BoolAttribute{
+				MarkdownDescription: "Whether the batch export is paused",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"include_events": schema.ListAttribute{
+				MarkdownDescription: "Only export these events",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"exclude_events": schema.ListAttribute{
+				MarkdownDescription: "Do not export these events",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"s3": schema.SingleNestedAttribute{
+				MarkdownDescription: "Exports to an S3 bucket",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"bucket_name":           batchExportStringAttribute("Name of the bucket", true),
+					"region":                batchExportStringAttribute("Region of the bucket", true),
+					"prefix":                batchExportStringAttribute("Prefix of the exported files, can contain placeholders like `{year}`, `{month}`, `{day}` or `{table}`", false),
+					"aws_access_key_id":     batchExportStringAttribute("ID of the AWS access key", true),
+					"aws_secret_access_key": batchExportSecretAttribute("AWS secret access key"),
+					"compression": schema.StringAttribute{
+						MarkdownDescription: "Compression of the exported files, one of `gzip`, `brotli`, `zstd`, `lz4` or `snappy`",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("gzip", "brotli", "zstd", "lz4", "snappy"),
+						},
+					},
+					"encryption": schema.StringAttribute{
+						MarkdownDescription: "Server side encryption of the exported files, `AES256` or `aws:kms`",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("AES256", "aws:kms"),
+						},
+					},
+					"kms_key_id":   batchExportStringAttribute("ID of the KMS key, for `aws:kms` encryption", false),
+					"endpoint_url": batchExportStringAttribute("URL of an S3 compatible service, AWS is used if unset", false),
+					"file_format": schema.StringAttribute{
+						MarkdownDescription: "Format of the exported files, `JSONLines` or `Parquet`",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("JSONLines", "Parquet"),
+						},
+					},
+					"max_file_size_mb": schema.Int64Attribute{
+						MarkdownDescription: "Maximum size of the exported files, in MB",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+				},
+			},
+			"bigquery": schema.SingleNestedAttribute{
+				MarkdownDescription: "Exports to a BigQuery table, using a service account",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"project_id":     batchExportStringAttribute("ID of the Google Cloud project", true),
+					"dataset_id":     batchExportStringAttribute("ID of the dataset", true),
+					"table_id":       batchExportStringAttribute("ID of the table, created if it does not exist", true),
+					"client_email":   batchExportStringAttribute("Email of the service account", true),
+					"private_key":    batchExportSecretAttribute("Private key of the service account"),
+					"private_key_id": batchExportStringAttribute("ID of the private key of the service account", true),
+					"token_uri":      batchExportStringAttribute("Token URI of the service account", true),
+					"use_json_type": schema.BoolAttribute{
+						MarkdownDescription: "Whether to use the JSON type for properties, instead of strings",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+					},
+				},
+			},
+			"snowflake": schema.SingleNestedAttribute{
+				MarkdownDescription: "Exports to a Snowflake table",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"account":    batchExportStringAttribute("Snowflake account identifier", true),
+					"user":       batchExportStringAttribute("User name", true),
+					"password":   batchExportSecretAttribute("Password of the user"),
+					"database":   batchExportStringAttribute("Name of the database", true),
+					"warehouse":  batchExportStringAttribute("Name of the warehouse", true),
+					"schema":     batchExportStringAttribute("Name of the schema", true),
+					"table_name": batchExportStringAttribute("Name of the table, created if it does not exist", true),
+					"role":       batchExportStringAttribute("Role used to export the data", false),
+				},
+			},
+			"postgres": schema.SingleNestedAttribute{
+				MarkdownDescription: "Exports to a Postgres table",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"host": batchExportStringAttribute("Host name of the database server", true),
+					"port": schema.Int64Attribute{
+						MarkdownDescription: "Port of the database server",
+						Optional:            true,
+						Computed:            true,
+						Default:             int64default.StaticInt64(5432),
+					},
+					"user":       batchExportStringAttribute("User name", true),
+					"password":   batchExportSecretAttribute("Password of the user"),
+					"database":   batchExportStringAttribute("Name of the database", true),
+					"schema":     batchExportStringAttribute("Name of the schema", true),
+					"table_name": batchExportStringAttribute("Name of the table, created if it does not exist", true),
+					"has_self_signed_cert": schema.BoolAttribute{
+						MarkdownDescription: "Whether the server uses a self signed certificate",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+					},
+				},
+			},
+			"redshift": schema.SingleNestedAttribute{
+				MarkdownDescription: "Exports to a Redshift table",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"host": batchExportStringAttribute("Host name of the Redshift cluster", true),
+					"port": schema.Int64Attribute{
+						MarkdownDescription: "Port of the Redshift cluster",
+						Optional:            true,
+						Computed:            true,
+						Default:             int64default.StaticInt64(5439),
+					},
+					"user":       batchExportStringAttribute("User name", true),
+					"password":   batchExportSecretAttribute("Password of the user"),
+					"database":   batchExportStringAttribute("Name of the database", true),
+					"schema":     batchExportStringAttribute("Name of the schema", true),
+					"table_name": batchExportStringAttribute("Name of the table, created if it does not exist", true),
+					"properties_data_type": schema.StringAttribute{
+						MarkdownDescription: "Type of the properties column, `varchar` or `super`",
+						Optional:            true,
+						Computed:            true,
+						Default:             stringdefault.StaticString("varchar"),
+						Validators: []validator.String{
+							stringvalidator.OneOf("varchar", "super"),
+						},
+					},
+				},
+			},
+			"http": schema.SingleNestedAttribute{
+				MarkdownDescription: "Posts the data to an HTTP endpoint",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"url":   batchExportStringAttribute("URL of the endpoint", true),
+					"token": batchExportSecretAttribute("Token sent along with the data"),
+				},
+			},
+			"backfill": batchExportBackfillSchema(),
+		},
+	}
+}
+
+func (r *batchExportResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("s3"),
+			path.MatchRoot("bigquery"),
+			path.MatchRoot("snowflake"),
+			path.MatchRoot("postgres"),
+			path.MatchRoot("redshift"),
+			path.MatchRoot("http"),
+		),
+	}
+}
+
+func (r *batchExportResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *batchExportResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data batchExportResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Backfill.IsNull() || data.Backfill.IsUnknown() {
+		return
+	}
+
+	var backfills []batchExportBackfill
+
+	resp.Diagnostics.Append(data.Backfill.ElementsAs(ctx, &backfills, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, b := range backfills {
+		if b.StartAt.IsUnknown() || b.EndAt.IsUnknown() {
+			continue
+		}
+
+		p := path.Root("backfill").AtListIndex(i)
+
+		if _, _, err := batchExportBackfillRange(b); err != nil {
+			resp.Diagnostics.AddAttributeError(p, "Invalid backfill", err.Error())
+		}
+	}
+}
+
+func batchExportBackfillRange(b batchExportBackfill) (time.Time, time.Time, error) {
+	startAt, err := time.Parse(time.RFC3339, b.StartAt.ValueString())
+	if err != nil {
+		return startAt, time.Time{}, fmt.Errorf("invalid start date: %w", err)
+	}
+
+	endAt, err := time.Parse(time.RFC3339, b.EndAt.ValueString())
+	if err != nil {
+		return startAt, endAt, fmt.Errorf("invalid end date: %w", err)
+	}
+
+	if !startAt.Before(endAt) {
+		return startAt, endAt, fmt.Errorf("start date %s is not before end date %s", b.StartAt.ValueString(), b.EndAt.ValueString())
+	}
+
+	return startAt, endAt, nil
+}
+
+// keptSecret returns the secret from the state, as secrets are not returned
+// by the API.
+func keptSecret(prior types.String, value string) types.String {
+	if !prior.IsNull() {
+		return prior
+	}
+
+	return typeutil.NullableStringValue(value)
+}
+
+func updateBatchExportModel(ctx context.Context, model *batchExportResourceModel, apiBatchExport *posthog.BatchExport) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+
+	model.ID = types.StringValue(apiBatchExport.ID.String())
+	model.Name = types.StringValue(apiBatchExport.Name)
+	model.Model = types.StringValue(string(apiBatchExport.Model))
+	model.Interval = types.StringValue(string(apiBatchExport.Interval))
+	model.Paused = types.BoolValue(apiBatchExport.Paused)
+
+	config := apiBatchExport.Destination.Config
+
+	model.IncludeEvents = types.ListNull(types.StringType)
+	if len(config.IncludeEvents) > 0 {
+		model.IncludeEvents, d = types.ListValueFrom(ctx, types.StringType, config.IncludeEvents)
+		diags.Append(d...)
+	}
+
+	model.ExcludeEvents = types.ListNull(types.StringType)
+	if len(config.ExcludeEvents) > 0 {
+		model.ExcludeEvents, d = types.ListValueFrom(ctx, types.StringType, config.ExcludeEvents)
+		diags.Append(d...)
+	}
+
+	prior := *model
+
+	model.S3 = nil
+	model.BigQuery = nil
+	model.Snowflake = nil
+	model.Postgres = nil
+	model.Redshift = nil
+	model.HTTP = nil
+
+	switch apiBatchExport.Destination.Type {
+	case posthog.BatchExportDestinationTypeS3:
+		model.S3 = &batchExportS3{
+			BucketName:         types.StringValue(config.BucketName),
+			Region:             types.StringValue(config.Region),
+			Prefix:             typeutil.NullableStringValue(config.Prefix),
+			AWSAccessKeyID:     types.StringValue(config.AWSAccessKeyID),
+			AWSSecretAccessKey: types.StringNull(),
+			Compression:        typeutil.NullableStringValue(config.Compression),
+			Encryption:         typeutil.NullableStringValue(config.Encryption),
+			KMSKeyID:           typeutil.NullableStringValue(config.KMSKeyID),
+			EndpointURL:        typeutil.NullableStringValue(config.EndpointURL),
+			FileFormat:         typeutil.NullableStringValue(config.FileFormat),
+			MaxFileSizeMB:      types.Int64PointerValue(config.MaxFileSizeMB),
+		}
+
+		if prior.S3 != nil {
+			model.S3.AWSSecretAccessKey = keptSecret(prior.S3.AWSSecretAccessKey, config.AWSSecretAccessKey)
+		}
+	case posthog.BatchExportDestinationTypeBigQuery:
+		model.BigQuery = &batchExportBigQuery{
+			ProjectID:    types.StringValue(config.ProjectID),
+			DatasetID:    types.StringValue(config.DatasetID),
+			TableID:      types.StringValue(config.TableID),
+			ClientEmail:  types.StringValue(config.ClientEmail),
+			PrivateKey:   types.StringNull(),
+			PrivateKeyID: types.StringValue(config.PrivateKeyID),
+			TokenURI:     types.StringValue(config.TokenURI),
+			UseJSONType:  types.BoolValue(config.UseJSONType),
+		}
+
+		if prior.BigQuery != nil {
+			model.BigQuery.PrivateKey = keptSecret(prior.BigQuery.PrivateKey, config.PrivateKey)
+		}
+	case posthog.BatchExportDestinationTypeSnowflake:
+		model.Snowflake = &batchExportSnowflake{
+			Account:   types.StringValue(config.Account),
+			User:      types.StringValue(config.User),
+			Password:  types.StringNull(),
+			Database:  types.StringValue(config.Database),
+			Warehouse: types.StringValue(config.Warehouse),
+			Schema:    types.StringValue(config.Schema),
+			TableName: types.StringValue(config.TableName),
+			Role:      typeutil.NullableStringValue(config.Role),
+		}
+
+		if prior.Snowflake != nil {
+			model.Snowflake.Password = keptSecret(prior.Snowflake.Password, config.Password)
+		}
+	case posthog.BatchExportDestinationTypePostgres:
+		model.Postgres = &batchExportPostgres{
+			Host:              types.StringValue(config.Host),
+			Port:              types.Int64Value(config.Port),
+			User:              types.StringValue(config.User),
+			Password:          types.StringNull(),
+			Database:          types.StringValue(config.Database),
+			Schema:            types.StringValue(config.Schema),
+			TableName:         types.StringValue(config.TableName),
+			HasSelfSignedCert: types.BoolValue(config.HasSelfSignedCert),
+		}
+
+		if prior.Postgres != nil {
+			model.Postgres.Password = keptSecret(prior.Postgres.Password, config.Password)
+		}
+	case posthog.BatchExportDestinationTypeRedshift:
+		model.Redshift = &batchExportRedshift{
+			Host:               types.StringValue(config.Host),
+			Port:               types.Int64Value(config.Port),
+			User:               types.StringValue(config.User),
+			Password:           types.StringNull(),
+			Database:           types.StringValue(config.Database),
+			Schema:             types.StringValue(config.Schema),
+			TableName:          types.StringValue(config.TableName),
+			PropertiesDataType: types.StringValue(config.PropertiesDataType),
+		}
+
+		if prior.Redshift != nil {
+			model.Redshift.Password = keptSecret(prior.Redshift.Password, config.Password)
+		}
+	case posthog.BatchExportDestinationTypeHTTP:
+		model.HTTP = &batchExportHTTP{
+			URL:   types.StringValue(config.URL),
+			Token: types.StringNull(),
+		}
+
+		if prior.HTTP != nil {
+			model.HTTP.Token = keptSecret(prior.HTTP.Token, config.Token)
+		}
+	default:
+		diags.AddError("Unsupported destination", fmt.Sprintf("Batch export %s has an unsupported destination type %s.", apiBatchExport.ID, apiBatchExport.Destination.Type))
+	}
+
+	return diags
+}
+
+func batchExportDestinationFromModel(ctx context.Context, data batchExportResourceModel) (posthog.BatchExportDestination, diag.Diagnostics) {
+	var (
+		diags       diag.Diagnostics
+		destination posthog.BatchExportDestination
+	)
+
+	if !data.IncludeEvents.IsNull() {
+		diags.Append(data.IncludeEvents.ElementsAs(ctx, &destination.Config.IncludeEvents, false)...)
+	}
+
+	if !data.ExcludeEvents.IsNull() {
+		diags.Append(data.ExcludeEvents.ElementsAs(ctx, &destination.Config.ExcludeEvents, false)...)
+	}
+
+	config := &destination.Config
+
+	switch {
+	case data.S3 != nil:
+		destination.Type = posthog.BatchExportDestinationTypeS3
+		config.BucketName = data.S3.BucketName.ValueString()
+		config.Region = data.S3.Region.ValueString()
+		config.Prefix = data.S3.Prefix.ValueString()
+		config.AWSAccessKeyID = data.S3.AWSAccessKeyID.ValueString()
+		config.AWSSecretAccessKey = data.S3.AWSSecretAccessKey.ValueString()
+		config.Compression = data.S3.Compression.ValueString()
+		config.Encryption = data.S3.Encryption.ValueString()
+		config.KMSKeyID = data.S3.KMSKeyID.ValueString()
+		config.EndpointURL = data.S3.EndpointURL.ValueString()
+		config.FileFormat = data.S3.FileFormat.ValueString()
+		config.MaxFileSizeMB = data.S3.MaxFileSizeMB.ValueInt64Pointer()
+	case data.BigQuery != nil:
+		destination.Type = posthog.BatchExportDestinationTypeBigQuery
+		config.ProjectID = data.BigQuery.ProjectID.ValueString()
+		config.DatasetID = data.BigQuery.DatasetID.ValueString()
+		config.TableID = data.BigQuery.TableID.ValueString()
+		config.ClientEmail = data.BigQuery.ClientEmail.ValueString()
+		config.PrivateKey = data.BigQuery.PrivateKey.ValueString()
+		config.PrivateKeyID = data.BigQuery.PrivateKeyID.ValueString()
+		config.TokenURI = data.BigQuery.TokenURI.ValueString()
+		config.UseJSONType = data.BigQuery.UseJSONType.ValueBool()
+	case data.Snowflake != nil:
+		destination.Type = posthog.BatchExportDestinationTypeSnowflake
+		config.Account = data.Snowflake.Account.ValueString()
+		config.User = data.Snowflake.User.ValueString()
+		config.Password = data.Snowflake.Password.ValueString()
+		config.Database = data.Snowflake.Database.ValueString()
+		config.Warehouse = data.Snowflake.Warehouse.ValueString()
+		config.Schema = data.Snowflake.Schema.ValueString()
+		config.TableName = data.Snowflake.TableName.ValueString()
+		config.Role = data.Snowflake.Role.ValueString()
+	case data.Postgres != nil:
+		destination.Type = posthog.BatchExportDestinationTypePostgres
+		config.Host = data.Postgres.Host.ValueString()
+		config.Port = data.Postgres.Port.ValueInt64()
+		config.User = data.Postgres.User.ValueString()
+		config.Password = data.Postgres.Password.ValueString()
+		config.Database = data.Postgres.Database.ValueString()
+		config.Schema = data.Postgres.Schema.ValueString()
+		config.TableName = data.Postgres.TableName.ValueString()
+		config.HasSelfSignedCert = data.Postgres.HasSelfSignedCert.ValueBool()
+	case data.Redshift != nil:
+		destination.Type = posthog.BatchExportDestinationTypeRedshift
+		config.Host = data.Redshift.Host.ValueString()
+		config.Port = data.Redshift.Port.ValueInt64()
+		config.User = data.Redshift.User.ValueString()
+		config.Password = data.Redshift.Password.ValueString()
+		config.Database = data.Redshift.Database.ValueString()
+		config.Schema = data.Redshift.Schema.ValueString()
+		config.TableName = data.Redshift.TableName.ValueString()
+		config.PropertiesDataType = data.Redshift.PropertiesDataType.ValueString()
+	case data.HTTP != nil:
+		destination.Type = posthog.BatchExportDestinationTypeHTTP
+		config.URL = data.HTTP.URL.ValueString()
+		config.Token = data.HTTP.Token.ValueString()
+	default:
+		diags.AddError("Missing destination", "Batch exports must have a destination.")
+	}
+
+	return destination, diags
+}
+
+// startBatchExportBackfills starts the backfills of the plan that are not in
+// the state. It returns the backfills of the plan that are started, which is
+// all of them unless starting one failed: those must be saved in the state
+// even on error, so that they are not started again by the next apply.
+func (r *batchExportResource) startBatchExportBackfills(ctx context.Context, projectID posthog.ProjectID, batchExportID posthog.BatchExportID, plan types.List, state types.List) (types.List, diag.Diagnostics) {
+	var (
+		diags         diag.Diagnostics
+		planBackfills []batchExportBackfill
+		started       []batchExportBackfill
+		seen          = map[batchExportBackfill]bool{}
+	)
+
+	if plan.IsNull() {
+		return plan, diags
+	}
+
+	if !state.IsNull() {
+		var stateBackfills []batchExportBackfill
+
+		diags.Append(state.ElementsAs(ctx, &stateBackfills, false)...)
+		if diags.HasError() {
+			return state, diags
+		}
+
+		for _, b := range stateBackfills {
+			seen[b] = true
+		}
+	}
+
+	diags.Append(plan.ElementsAs(ctx, &planBackfills, false)...)
+	if diags.HasError() {
+		return state, diags
+	}
+
+	startedList := func() types.List {
+		res, d := types.ListValueFrom(ctx, batchExportBackfillSchema().NestedObject.Type(), started)
+		diags.Append(d...)
+		return res
+	}
+
+	for i, b := range planBackfills {
+		if seen[b] {
+			started = append(started, b)
+			continue
+		}
+
+		startAt, endAt, err := batchExportBackfillRange(b)
+		if err != nil {
+			diags.AddAttributeError(path.Root("backfill").AtListIndex(i), "Invalid backfill", err.Error())
+			return startedList(), diags
+		}
+
+		err = r.client.CreateBatchExportBackfill(ctx, projectID, batchExportID, posthog.CreateBatchExportBackfillRequest{StartAt: startAt, EndAt: endAt})
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Error starting backfill of batch export %s: %s", batchExportID, err))
+			return startedList(), diags
+		}
+
+		tflog.Trace(ctx, "started batch export backfill", map[string]interface{}{"batch_export_id": batchExportID, "start_at": b.StartAt.ValueString(), "end_at": b.EndAt.ValueString()})
+
+		seen[b] = true
+		started = append(started, b)
+	}
+
+	return plan, diags
+}
+
+func (r *batchExportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data batchExportResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	destination, diags := batchExportDestinationFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createBatchExportRequest := posthog.CreateBatchExportRequest{
+		Name:        data.Name.ValueString(),
+		Model:       posthog.BatchExportModel(data.Model.ValueString()),
+		Destination: destination,
+		Interval:    posthog.BatchExportInterval(data.Interval.ValueString()),
+		Paused:      data.Paused.ValueBool(),
+	}
+
+	// Create the batch export
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	res, err := r.client.CreateBatchExport(ctx, projectID, createBatchExportRequest)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating batch export: %s", err))
+		return
+	}
+
+	backfill := data.Backfill
+
+	resp.Diagnostics.Append(updateBatchExportModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created batch export", map[string]interface{}{"batch_export_id": res.ID})
+
+	// Save the state before starting the backfills, the batch export is
+	// created even if they fail.
+	data.Backfill = types.ListNull(batchExportBackfillSchema().NestedObject.Type())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save the backfills that were started even if starting one failed
+	data.Backfill, diags = r.startBatchExportBackfills(ctx, projectID, res.ID, backfill, data.Backfill)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *batchExportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data batchExportResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	batchExportID, err := posthog.BatchExportIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid batch export ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetBatchExport(ctx, projectID, batchExportID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting batch export %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(updateBatchExportModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read batch export", map[string]interface{}{"batch_export_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *batchExportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state batchExportResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	destination, diags := batchExportDestinationFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	batchExport := posthog.BatchExport{
+		Name:        data.Name.ValueString(),
+		Model:       posthog.BatchExportModel(data.Model.ValueString()),
+		Destination: destination,
+		Interval:    posthog.BatchExportInterval(data.Interval.ValueString()),
+		Paused:      state.Paused.ValueBool(),
+	}
+
+	batchExport.ID, err = posthog.BatchExportIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid batch export ID", err.Error())
+		return
+	}
+
+	res, err := r.client.UpdateBatchExport(ctx, projectID, batchExport)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating batch export %s: %s", batchExport.ID, err))
+		return
+	}
+
+	if paused := data.Paused.ValueBool(); paused != res.Paused {
+		if paused {
+			err = r.client.PauseBatchExport(ctx, projectID, batchExport.ID)
+		} else {
+			err = r.client.UnpauseBatchExport(ctx, projectID, batchExport.ID)
+		}
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error pausing or unpausing batch export %s: %s", batchExport.ID, err))
+			return
+		}
+
+		res.Paused = paused
+	}
+
+	backfill := data.Backfill
+
+	resp.Diagnostics.Append(updateBatchExportModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated batch export", map[string]interface{}{"batch_export_id": res.ID})
+
+	data.Backfill = state.Backfill
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save the backfills that were started even if starting one failed
+	data.Backfill, diags = r.startBatchExportBackfills(ctx, projectID, res.ID, backfill, state.Backfill)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *batchExportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data batchExportResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	batchExportID, err := posthog.BatchExportIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid batch export ID", err.Error())
+		return
+	}
+
+	if err := r.client.DeleteBatchExport(ctx, projectID, batchExportID); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting batch export %s: %s", data.ID, err))
+		return
+	}
+}
+
+func (r *batchExportResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, batchExportID, err := parseImportID(req.ID, "batch export", posthog.BatchExportIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), batchExportID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
@@ -0,0 +1,839 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+var _ resource.Resource = &cohortResource{}
+var _ resource.ResourceWithImportState = &cohortResource{}
+var _ resource.ResourceWithValidateConfig = &cohortResource{}
+var _ resource.ResourceWithModifyPlan = &cohortResource{}
+
+func newCohortResource() resource.Resource {
+	return &cohortResource{}
+}
+
+type cohortResource struct {
+	client *posthog.Client
+}
+
+type cohortResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	ProjectID   types.String `tfsdk:"project_id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Match       types.String `tfsdk:"match"`
+	Groups      types.List   `tfsdk:"groups"`
+	IsStatic    types.Bool   `tfsdk:"is_static"`
+	DistinctIDs types.Set    `tfsdk:"distinct_ids"`
+	CSVFile     types.String `tfsdk:"csv_file"`
+	MembersHash types.String `tfsdk:"members_hash"`
+}
+
+type cohortGroup struct {
+	Match           string                 `tfsdk:"match"`
+	Properties      []propertyFilter       `tfsdk:"properties"`
+	PerformedEvents []cohortPerformedEvent `tfsdk:"performed_events"`
+	Cohorts         []cohortReference      `tfsdk:"cohorts"`
+}
+
+type cohortPerformedEvent struct {
+	Event         types.String `tfsdk:"event"`
+	ActionID      types.String `tfsdk:"action_id"`
+	Behavior      string       `tfsdk:"behavior"`
+	CountOperator types.String `tfsdk:"count_operator"`
+	Count         types.Int64  `tfsdk:"count"`
+	TimeValue     int64        `tfsdk:"time_value"`
+	TimeInterval  string       `tfsdk:"time_interval"`
+	Negate        bool         `tfsdk:"negate"`
+}
+
+type cohortReference struct {
+	CohortID string `tfsdk:"cohort_id"`
+	Negate   bool   `tfsdk:"negate"`
+}
+
+func (r *cohortResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cohort"
+}
+
+func matchAttribute(description string, defaultValue string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: description + ", must be `all` or `any`",
+		Optional:            true,
+		Computed:            true,
+		Default:             stringdefault.StaticString(defaultValue),
+		Validators: []validator.String{
+			stringvalidator.OneOf("all", "any"),
+		},
+	}
+}
+
+func matchToPropertyGroupType(match string) posthog.PropertyGroupType {
+	if match == "all" {
+		return posthog.PropertyGroupTypeAnd
+	}
+
+	return posthog.PropertyGroupTypeOr
+}
+
+func matchFromPropertyGroupType(t posthog.PropertyGroupType) string {
+	if t == posthog.PropertyGroupTypeAnd {
+		return "all"
+	}
+
+	return "any"
+}
+
+func cohortGroupsSchema() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "Groups of criteria defining the members of a dynamic cohort",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"match":      matchAttribute("Whether persons must match all or any of the criteria of the group", "all"),
+				"properties": propertyFiltersSchema("Person properties criteria", posthog.PropertyFilterTypePerson),
+				"performed_events": schema.ListNestedAttribute{
+					MarkdownDescription: "Behavioral criteria, matching persons who performed (or did not perform) an event or action",
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"event": schema.StringAttribute{
+								MarkdownDescription: "Name of the event, exclusive with `action_id`",
+								Optional:            true,
+								Validators: []validator.String{
+									stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("action_id")),
+								},
+							},
+							"action_id": schema.StringAttribute{
+								MarkdownDescription: "ID of the action, exclusive with `event`",
+								Optional:            true,
+							},
+							"behavior": schema.StringAttribute{
+								MarkdownDescription: "Behavior to match, one of `performed_event`, `performed_event_multiple` or `performed_event_first_time`",
+								Optional:            true,
+								Computed:            true,
+								Default:             stringdefault.StaticString(string(posthog.CohortBehaviorPerformedEvent)),
+								Validators: []validator.String{
+									stringvalidator.OneOf(
+										string(posthog.CohortBehaviorPerformedEvent),
+										string(posthog.CohortBehaviorPerformedEventMultiple),
+										string(posthog.CohortBehaviorPerformedEventFirstTime),
+									),
+								},
+							},
+							"count_operator": schema.StringAttribute{
+								MarkdownDescription: "For `performed_event_multiple`, how to compare the number of times the event was performed to `count`: `gte`, `lte` or `exact`",
+								Optional:            true,
+								Validators: []validator.String{
+									stringvalidator.OneOf("gte", "lte", "exact"),
+									stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("count")),
+								},
+							},
+							"count": schema.Int64Attribute{
+								MarkdownDescription: "For `performed_event_multiple`, the number of times the event must be performed",
+								Optional:            true,
+								Validators: []validator.Int64{
+									int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("count_operator")),
+								},
+							},
+							"time_value": schema.Int64Attribute{
+								MarkdownDescription: "Number of time intervals to look back",
+								Optional:            true,
+								Computed:            true,
+								Default:             int64default.StaticInt64(30),
+							},
+							"time_interval": schema.StringAttribute{
+								MarkdownDescription: "Unit of `time_value`: `day`, `week`, `month` or `year`",
+								Optional:            true,
+								Computed:            true,
+								Default:             stringdefault.StaticString("day"),
+								Validators: []validator.String{
+									stringvalidator.OneOf("day", "week", "month", "year"),
+								},
+							},
+							"negate": schema.BoolAttribute{
+								MarkdownDescription: "Match persons who did not perform the event instead",
+								Optional:            true,
+								Computed:            true,
+								Default:             booldefault.StaticBool(false),
+							},
+						},
+					},
+					Optional: true,
+				},
+				"cohorts": schema.ListNestedAttribute{
+					MarkdownDescription: "Criteria on the membership of other cohorts",
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"cohort_id": schema.StringAttribute{
+								MarkdownDescription: "ID of the cohort",
+								Required:            true,
+							},
+							"negate": schema.BoolAttribute{
+								MarkdownDescription: "Match persons who are not in the cohort instead",
+								Optional:            true,
+								Computed:            true,
+								Default:             booldefault.StaticBool(false),
+							},
+						},
+					},
+					Optional: true,
+				},
+			},
+		},
+		Optional: true,
+	}
+}
+
+func (r *cohortResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Cohort",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the cohort",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the cohort",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the cohort",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the cohort",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"match":  matchAttribute("Whether persons must match all or any of the groups", "any"),
+			"groups": cohortGroupsSchema(),
+			"is_static": schema.BoolAttribute{
+				MarkdownDescription: "Whether the cohort is static, ie. has a fixed list of members instead of criteria",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"distinct_ids": schema.SetAttribute{
+				MarkdownDescription: "Distinct IDs of the members of a static cohort, exclusive with `csv_file`. Added IDs are uploaded to the existing cohort, removing IDs recreates the cohort since PostHog can only add members.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"csv_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a CSV file listing the distinct IDs of the members of a static cohort in its first column, exclusive with `distinct_ids`. Changing the content of the file recreates the cohort since PostHog can only add members.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("distinct_ids")),
+				},
+			},
+			"members_hash": schema.StringAttribute{
+				MarkdownDescription: "Hash of the uploaded members of a static cohort",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *cohortResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *cohortResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data cohortResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.IsStatic.IsUnknown() {
+		return
+	}
+
+	if data.IsStatic.ValueBool() && !data.Groups.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("groups"), "Invalid static cohort", "Static cohorts cannot have groups of criteria.")
+	}
+
+	if !data.IsStatic.ValueBool() {
+		if !data.DistinctIDs.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("distinct_ids"), "Invalid dynamic cohort", "Members can only be listed for static cohorts.")
+		}
+
+		if !data.CSVFile.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("csv_file"), "Invalid dynamic cohort", "Members can only be listed for static cohorts.")
+		}
+	}
+}
+
+// cohortMembersCSV returns the CSV file listing the members of a static cohort,
+// or nil if the members are not set.
+func cohortMembersCSV(ctx context.Context, data cohortResourceModel) ([]byte, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !data.CSVFile.IsNull() {
+		csv, err := os.ReadFile(data.CSVFile.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("csv_file"), "Error reading CSV file", err.Error())
+		}
+
+		return csv, diags
+	}
+
+	if data.DistinctIDs.IsNull() {
+		return nil, diags
+	}
+
+	var distinctIDs []string
+
+	diags.Append(data.DistinctIDs.ElementsAs(ctx, &distinctIDs, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	sort.Strings(distinctIDs)
+
+	return []byte("distinct_id\n" + strings.Join(distinctIDs, "\n") + "\n"), diags
+}
+
+// cohortDistinctIDsDiff returns the distinct IDs added and removed between
+// the state and the plan. ok is false when the members are not both listed in
+// distinct_ids (eg. they come from a CSV file), and the difference can't be
+// computed.
+func cohortDistinctIDsDiff(ctx context.Context, state, plan cohortResourceModel) (added []string, removed []string, ok bool, diags diag.Diagnostics) {
+	if state.DistinctIDs.IsNull() || plan.DistinctIDs.IsNull() {
+		return nil, nil, false, diags
+	}
+
+	var before, after []string
+
+	diags.Append(state.DistinctIDs.ElementsAs(ctx, &before, false)...)
+	diags.Append(plan.DistinctIDs.ElementsAs(ctx, &after, false)...)
+	if diags.HasError() {
+		return nil, nil, false, diags
+	}
+
+	beforeSet := map[string]bool{}
+	for _, id := range before {
+		beforeSet[id] = true
+	}
+
+	afterSet := map[string]bool{}
+	for _, id := range after {
+		afterSet[id] = true
+
+		if !beforeSet[id] {
+			added = append(added, id)
+		}
+	}
+
+	for _, id := range before {
+		if !afterSet[id] {
+			removed = append(removed, id)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(removed)
+
+	return added, removed, true, diags
+}
+
+func cohortMembersHash(csv []byte) types.String {
+	if csv == nil {
+		return types.StringNull()
+	}
+
+	sum := sha256.Sum256(csv)
+	return types.StringValue(hex.EncodeToString(sum[:]))
+}
+
+func (r *cohortResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// destroy
+		return
+	}
+
+	var data cohortResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.DistinctIDs.IsUnknown() || data.CSVFile.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("members_hash"), types.StringUnknown())...)
+		return
+	}
+
+	csv, diags := cohortMembersCSV(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	membersHash := cohortMembersHash(csv)
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("members_hash"), membersHash)...)
+
+	if req.State.Raw.IsNull() || !data.IsStatic.ValueBool() {
+		return
+	}
+
+	var state cohortResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.MembersHash.IsNull() || state.MembersHash.Equal(membersHash) {
+		return
+	}
+
+	// Uploading members only adds them to a static cohort, removing members
+	// requires recreating the cohort
+	_, removed, ok, diags := cohortDistinctIDsDiff(ctx, state, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !ok || len(removed) > 0 {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("members_hash"))
+	}
+}
+
+func updateCohortModel(ctx context.Context, model *cohortResourceModel, apiCohort *posthog.Cohort) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(apiCohort.ID.String())
+	model.Name = types.StringValue(apiCohort.Name)
+	model.Description = types.StringValue(apiCohort.Description)
+	model.IsStatic = types.BoolValue(apiCohort.IsStatic)
+
+	// The members of static cohorts are not returned by the API, keep what is
+	// in the state.
+	if apiCohort.IsStatic || apiCohort.Filters == nil {
+		model.Groups = types.ListNull(cohortGroupsSchema().NestedObject.Type())
+		return diags
+	}
+
+	model.Match = types.StringValue(matchFromPropertyGroupType(apiCohort.Filters.Properties.Type))
+
+	var groups []cohortGroup
+
+	for _, g := range apiCohort.Filters.Properties.Values {
+		group := cohortGroup{Match: matchFromPropertyGroupType(g.Type)}
+
+		for _, c := range g.Values {
+			switch c.Type {
+			case posthog.CohortCriterionTypeBehavioral:
+				ev := cohortPerformedEvent{
+					Event:         types.StringNull(),
+					ActionID:      types.StringNull(),
+					CountOperator: types.StringNull(),
+					Count:         types.Int64PointerValue(c.OperatorValue),
+					TimeValue:     c.TimeValue,
+					TimeInterval:  c.TimeInterval,
+					Negate:        c.Negation,
+				}
+
+				if len(c.Value) > 0 {
+					ev.Behavior = c.Value[0]
+				}
+
+				if c.EventType == posthog.CohortEventTypeActions {
+					ev.ActionID = types.StringValue(c.Key)
+				} else {
+					ev.Event = types.StringValue(c.Key)
+				}
+
+				if c.OperatorValue != nil {
+					ev.CountOperator = types.StringValue(string(c.Operator))
+				}
+
+				group.PerformedEvents = append(group.PerformedEvents, ev)
+			case posthog.CohortCriterionTypeCohort:
+				ref := cohortReference{Negate: c.Negation}
+				if len(c.Value) > 0 {
+					ref.CohortID = c.Value[0]
+				}
+
+				group.Cohorts = append(group.Cohorts, ref)
+			default:
+				group.Properties = append(group.Properties, propertyFiltersToModel([]posthog.PropertyFilter{{
+					Key:      c.Key,
+					Type:     posthog.PropertyFilterType(c.Type),
+					Operator: c.Operator,
+					Value:    c.Value,
+				}})...)
+			}
+		}
+
+		groups = append(groups, group)
+	}
+
+	model.Groups, diags = types.ListValueFrom(ctx, cohortGroupsSchema().NestedObject.Type(), groups)
+	if diags.HasError() {
+		return diags
+	}
+
+	return diags
+}
+
+func cohortFiltersFromModel(ctx context.Context, data cohortResourceModel) (*posthog.CohortFilters, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if data.IsStatic.ValueBool() {
+		return nil, diags
+	}
+
+	var groups []cohortGroup
+
+	diags.Append(data.Groups.ElementsAs(ctx, &groups, true)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	filters := &posthog.CohortFilters{
+		Properties: posthog.CohortPropertyGroup{Type: matchToPropertyGroupType(data.Match.ValueString())},
+	}
+
+	for _, g := range groups {
+		group := posthog.CohortCriteriaGroup{Type: matchToPropertyGroupType(g.Match)}
+
+		for _, p := range propertyFiltersFromModel(g.Properties) {
+			group.Values = append(group.Values, posthog.CohortCriterion{
+				Type:     posthog.CohortCriterionType(p.Type),
+				Key:      p.Key,
+				Value:    p.Value,
+				Operator: p.Operator,
+			})
+		}
+
+		for _, ev := range g.PerformedEvents {
+			criterion := posthog.CohortCriterion{
+				Type:          posthog.CohortCriterionTypeBehavioral,
+				Value:         posthog.PropertyValue{ev.Behavior},
+				Negation:      ev.Negate,
+				TimeValue:     ev.TimeValue,
+				TimeInterval:  ev.TimeInterval,
+				OperatorValue: ev.Count.ValueInt64Pointer(),
+				Operator:      posthog.PropertyOperator(ev.CountOperator.ValueString()),
+			}
+
+			if !ev.ActionID.IsNull() {
+				criterion.Key = ev.ActionID.ValueString()
+				criterion.EventType = posthog.CohortEventTypeActions
+			} else {
+				criterion.Key = ev.Event.ValueString()
+				criterion.EventType = posthog.CohortEventTypeEvents
+			}
+
+			group.Values = append(group.Values, criterion)
+		}
+
+		for _, c := range g.Cohorts {
+			group.Values = append(group.Values, posthog.CohortCriterion{
+				Type:     posthog.CohortCriterionTypeCohort,
+				Key:      "id",
+				Value:    posthog.PropertyValue{c.CohortID},
+				Negation: c.Negate,
+			})
+		}
+
+		filters.Properties.Values = append(filters.Properties.Values, group)
+	}
+
+	return filters, diags
+}
+
+func (r *cohortResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data cohortResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	csv, diags := cohortMembersCSV(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var res *posthog.Cohort
+
+	if data.IsStatic.ValueBool() && csv != nil {
+		res, err = r.client.CreateStaticCohort(ctx, projectID, data.Name.ValueString(), data.Description.ValueString(), csv)
+	} else {
+		filters, diags := cohortFiltersFromModel(ctx, data)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		res, err = r.client.CreateCohort(ctx, projectID, posthog.CreateCohortRequest{
+			Name:        data.Name.ValueString(),
+			Description: data.Description.ValueString(),
+			Filters:     filters,
+			IsStatic:    data.IsStatic.ValueBool(),
+		})
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating cohort: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(updateCohortModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.MembersHash = cohortMembersHash(csv)
+
+	tflog.Trace(ctx, "created cohort", map[string]interface{}{"cohort_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *cohortResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data cohortResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	cohortID, err := posthog.CohortIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid cohort ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetCohort(ctx, projectID, cohortID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting cohort %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil || res.Deleted {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(updateCohortModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Match.IsNull() {
+		// static cohorts imported
+		data.Match = types.StringValue("any")
+	}
+
+	tflog.Trace(ctx, "read cohort", map[string]interface{}{"cohort_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func cohortFromModel(ctx context.Context, data cohortResourceModel) (posthog.ProjectID, posthog.Cohort, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		diags.AddError("Invalid project ID", err.Error())
+		return posthog.ProjectID(0), posthog.Cohort{}, diags
+	}
+
+	cohortID, err := posthog.CohortIDFromString(data.ID.ValueString())
+	if err != nil {
+		diags.AddError("Invalid cohort ID", err.Error())
+		return posthog.ProjectID(0), posthog.Cohort{}, diags
+	}
+
+	filters, diags := cohortFiltersFromModel(ctx, data)
+	if diags.HasError() {
+		return posthog.ProjectID(0), posthog.Cohort{}, diags
+	}
+
+	cohort := posthog.Cohort{
+		ID:          cohortID,
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueString(),
+		Filters:     filters,
+		IsStatic:    data.IsStatic.ValueBool(),
+	}
+
+	return projectID, cohort, diags
+}
+
+func (r *cohortResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state cohortResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, cohort, diags := cohortFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, err := r.client.UpdateCohort(ctx, projectID, cohort)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating cohort %s: %s", cohort.ID, err))
+		return
+	}
+
+	csv, diags := cohortMembersCSV(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	membersHash := cohortMembersHash(csv)
+
+	if cohort.IsStatic && csv != nil && !membersHash.Equal(state.MembersHash) {
+		// Members can only be added (see ModifyPlan), only upload the new ones
+		added, _, ok, diags := cohortDistinctIDsDiff(ctx, state, data)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if ok {
+			csv = []byte("distinct_id\n" + strings.Join(added, "\n") + "\n")
+		}
+
+		res, err = r.client.UploadStaticCohortMembers(ctx, projectID, cohort.ID, csv)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error uploading members of cohort %s: %s", cohort.ID, err))
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(updateCohortModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.MembersHash = membersHash
+
+	tflog.Trace(ctx, "updated cohort", map[string]interface{}{"cohort_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *cohortResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data cohortResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, cohort, diags := cohortFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Cohorts are soft deleted, like in the web UI
+	cohort.Deleted = true
+
+	_, err := r.client.UpdateCohort(ctx, projectID, cohort)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting cohort %s: %s", cohort.ID, err))
+		return
+	}
+}
+
+func (r *cohortResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, cohortID, err := parseImportID(req.ID, "cohort", posthog.CohortIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), cohortID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
@@ -0,0 +1,703 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+var _ resource.Resource = &dashboardResource{}
+var _ resource.ResourceWithImportState = &dashboardResource{}
+
+var dashboardRestrictionLevels = map[string]posthog.DashboardRestrictionLevel{
+	"everyone_can_edit":           posthog.DashboardRestrictionLevelEveryoneCanEdit,
+	"only_collaborators_can_edit": posthog.DashboardRestrictionLevelOnlyCollaboratorsCanEdit,
+}
+
+func newDashboardResource() resource.Resource {
+	return &dashboardResource{}
+}
+
+type dashboardResource struct {
+	client *posthog.Client
+}
+
+type dashboardResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	ProjectID           types.String `tfsdk:"project_id"`
+	Name                types.String `tfsdk:"name"`
+	Description         types.String `tfsdk:"description"`
+	Tags                types.List   `tfsdk:"tags"`
+	Pinned              types.Bool   `tfsdk:"pinned"`
+	RestrictionLevel    types.String `tfsdk:"restriction_level"`
+	Tiles               types.List   `tfsdk:"tiles"`
+	IgnoreLayoutChanges types.Bool   `tfsdk:"ignore_layout_changes"`
+}
+
+type dashboardTile struct {
+	InsightID types.String                   `tfsdk:"insight_id"`
+	Text      types.String                   `tfsdk:"text"`
+	Layouts   map[string]dashboardTileLayout `tfsdk:"layouts"`
+}
+
+type dashboardTileLayout struct {
+	X int64 `tfsdk:"x"`
+	Y int64 `tfsdk:"y"`
+	W int64 `tfsdk:"w"`
+	H int64 `tfsdk:"h"`
+}
+
+func (r *dashboardResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dashboard"
+}
+
+func dashboardTilesSchema() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "Tiles of the dashboard, either insights or text cards. Tiles are not managed by Terraform if unset, so that they can be added from the web UI or with the `dashboard_ids` of `posthog_insight`. When set, the dashboard has exactly these tiles, and `dashboard_ids` must not be set on the insights that are on this dashboard.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"insight_id": schema.StringAttribute{
+					MarkdownDescription: "ID of the insight displayed in the tile, exclusive with `text`",
+					Optional:            true,
+					Validators: []validator.String{
+						stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("text")),
+					},
+				},
+				"text": schema.StringAttribute{
+					MarkdownDescription: "Markdown content of a text card, exclusive with `insight_id`",
+					Optional:            true,
+				},
+				"layouts": schema.MapNestedAttribute{
+					MarkdownDescription: "Position of the tile for each breakpoint (`sm` for desktop, `xs` for mobile), in grid units. PostHog lays out the tile automatically if unset.",
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"x": schema.Int64Attribute{
+								MarkdownDescription: "Horizontal position of the tile",
+								Required:            true,
+							},
+							"y": schema.Int64Attribute{
+								MarkdownDescription: "Vertical position of the tile",
+								Required:            true,
+							},
+							"w": schema.Int64Attribute{
+								MarkdownDescription: "Width of the tile",
+								Required:            true,
+							},
+							"h": schema.Int64Attribute{
+								MarkdownDescription: "Height of the tile",
+								Required:            true,
+							},
+						},
+					},
+					Optional: true,
+				},
+			},
+		},
+		Optional: true,
+	}
+}
+
+func (r *dashboardResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Dashboard",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the dashboard",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the dashboard",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the dashboard",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the dashboard",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"tags": schema.ListAttribute{
+				MarkdownDescription: "Dashboard tags",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				Default:             listdefault.StaticValue(types.ListNull(types.StringType)),
+			},
+			"pinned": schema.BoolAttribute{
+				MarkdownDescription: "Whether the dashboard is pinned to the sidebar",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"restriction_level": schema.StringAttribute{
+				MarkdownDescription: "Who can edit the dashboard, `everyone_can_edit` or `only_collaborators_can_edit`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("everyone_can_edit"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("everyone_can_edit", "only_collaborators_can_edit"),
+				},
+			},
+			"tiles": dashboardTilesSchema(),
+			"ignore_layout_changes": schema.BoolAttribute{
+				MarkdownDescription: "Whether to ignore changes made to the tile layouts outside of Terraform (eg. when dragging tiles around in the web UI). Layouts are still applied when they change in the configuration.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *dashboardResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// liveDashboardTiles returns the tiles of a dashboard, without the deleted
+// ones.
+func liveDashboardTiles(d *posthog.Dashboard) []posthog.DashboardTile {
+	var res []posthog.DashboardTile
+
+	for _, t := range d.Tiles {
+		if t.Deleted || (t.Insight != nil && t.Insight.Deleted) || (t.Insight == nil && t.Text == nil) {
+			continue
+		}
+
+		res = append(res, t)
+	}
+
+	return res
+}
+
+// findDashboardTile returns the index of the first tile not already used
+// showing the same insight or text as the given tile, or -1.
+func findDashboardTile(tiles []posthog.DashboardTile, used []bool, t dashboardTile) int {
+	for i, candidate := range tiles {
+		if used[i] {
+			continue
+		}
+
+		if !t.InsightID.IsNull() && candidate.Insight != nil && candidate.Insight.ID.String() == t.InsightID.ValueString() {
+			return i
+		}
+
+		if !t.Text.IsNull() && candidate.Text != nil && candidate.Text.Body == t.Text.ValueString() {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func dashboardTileLayoutsFromModel(layouts map[string]dashboardTileLayout) map[string]posthog.DashboardTileLayout {
+	if layouts == nil {
+		return nil
+	}
+
+	res := make(map[string]posthog.DashboardTileLayout, len(layouts))
+	for k, l := range layouts {
+		res[k] = posthog.DashboardTileLayout{X: l.X, Y: l.Y, W: l.W, H: l.H}
+	}
+
+	return res
+}
+
+// dashboardTilesToModel maps the tiles of a dashboard to the model, keeping
+// the order of the tiles in the previous model. Tiles not in the previous
+// model are appended at the end.
+//
+// Layouts are only read for tiles that had a layout in the previous model, and
+// only for the breakpoints of the previous layout.
+func dashboardTilesToModel(prior []dashboardTile, apiDashboard *posthog.Dashboard, ignoreLayoutChanges bool) []dashboardTile {
+	tiles := liveDashboardTiles(apiDashboard)
+	used := make([]bool, len(tiles))
+
+	var res []dashboardTile
+
+	for _, p := range prior {
+		i := findDashboardTile(tiles, used, p)
+		if i == -1 {
+			// The tile was removed outside of Terraform
+			continue
+		}
+
+		used[i] = true
+
+		t := p
+		if p.Layouts != nil && !ignoreLayoutChanges {
+			t.Layouts = map[string]dashboardTileLayout{}
+
+			for breakpoint := range p.Layouts {
+				if l, ok := tiles[i].Layouts[breakpoint]; ok {
+					t.Layouts[breakpoint] = dashboardTileLayout{X: l.X, Y: l.Y, W: l.W, H: l.H}
+				}
+			}
+		}
+
+		res = append(res, t)
+	}
+
+	for i, t := range tiles {
+		if used[i] {
+			continue
+		}
+
+		tile := dashboardTile{InsightID: types.StringNull(), Text: types.StringNull()}
+
+		if t.Insight != nil {
+			tile.InsightID = types.StringValue(t.Insight.ID.String())
+		} else {
+			tile.Text = types.StringValue(t.Text.Body)
+		}
+
+		res = append(res, tile)
+	}
+
+	return res
+}
+
+// syncDashboardTiles adds and removes tiles so that the dashboard has the
+// given tiles, and updates their layouts.
+func (r *dashboardResource) syncDashboardTiles(ctx context.Context, projectID posthog.ProjectID, dashboardID posthog.DashboardID, desired []dashboardTile) (*posthog.Dashboard, error) {
+	current, err := r.client.GetDashboard(ctx, projectID, dashboardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if current == nil {
+		return nil, fmt.Errorf("dashboard %s not found", dashboardID)
+	}
+
+	tiles := liveDashboardTiles(current)
+	used := make([]bool, len(tiles))
+
+	var (
+		toAdd       []dashboardTile
+		tileChanges []posthog.DashboardTile
+	)
+
+	for _, t := range desired {
+		if i := findDashboardTile(tiles, used, t); i != -1 {
+			used[i] = true
+		} else {
+			toAdd = append(toAdd, t)
+		}
+	}
+
+	// Remove the tiles that are not in the configuration
+
+	for i, t := range tiles {
+		if used[i] {
+			continue
+		}
+
+		if t.Insight != nil {
+			if err := r.setInsightOnDashboard(ctx, projectID, t.Insight.ID, dashboardID, false); err != nil {
+				return nil, err
+			}
+		} else {
+			tileChanges = append(tileChanges, posthog.DashboardTile{ID: t.ID, Deleted: true})
+		}
+	}
+
+	// Add the missing tiles
+
+	for _, t := range toAdd {
+		if !t.InsightID.IsNull() {
+			insightID, err := posthog.InsightIDFromString(t.InsightID.ValueString())
+			if err != nil {
+				return nil, fmt.Errorf("invalid insight ID: %w", err)
+			}
+
+			if err := r.setInsightOnDashboard(ctx, projectID, insightID, dashboardID, true); err != nil {
+				return nil, err
+			}
+		} else {
+			tileChanges = append(tileChanges, posthog.DashboardTile{
+				Text:    &posthog.DashboardTileText{Body: t.Text.ValueString()},
+				Layouts: dashboardTileLayoutsFromModel(t.Layouts),
+			})
+		}
+	}
+
+	if len(tileChanges) > 0 {
+		if _, err := r.client.UpdateDashboardTiles(ctx, projectID, dashboardID, tileChanges); err != nil {
+			return nil, err
+		}
+	}
+
+	// Update the layouts, now that all tiles exist
+
+	current, err = r.client.GetDashboard(ctx, projectID, dashboardID)
+	if err != nil {
+		return nil, err
+	}
+
+	tiles = liveDashboardTiles(current)
+	used = make([]bool, len(tiles))
+	tileChanges = nil
+
+	for _, t := range desired {
+		i := findDashboardTile(tiles, used, t)
+		if i == -1 {
+			return nil, fmt.Errorf("tile for insight %s / text %q was not created", t.InsightID, t.Text.ValueString())
+		}
+
+		used[i] = true
+
+		if t.Layouts == nil {
+			continue
+		}
+
+		layouts := dashboardTileLayoutsFromModel(t.Layouts)
+
+		needsUpdate := false
+		for breakpoint, l := range layouts {
+			if current, ok := tiles[i].Layouts[breakpoint]; !ok || current != l {
+				needsUpdate = true
+			}
+		}
+
+		if needsUpdate {
+			tileChanges = append(tileChanges, posthog.DashboardTile{ID: tiles[i].ID, Layouts: layouts})
+		}
+	}
+
+	if len(tileChanges) > 0 {
+		return r.client.UpdateDashboardTiles(ctx, projectID, dashboardID, tileChanges)
+	}
+
+	return current, nil
+}
+
+func (r *dashboardResource) setInsightOnDashboard(ctx context.Context, projectID posthog.ProjectID, insightID posthog.InsightID, dashboardID posthog.DashboardID, onDashboard bool) error {
+	dashboardIDs, err := r.client.GetInsightDashboards(ctx, projectID, insightID)
+	if err != nil {
+		return fmt.Errorf("error getting dashboards of insight %s: %w", insightID, err)
+	}
+
+	dashboardIDs = slices.DeleteFunc(dashboardIDs, func(id posthog.DashboardID) bool { return id == dashboardID })
+	if onDashboard {
+		dashboardIDs = append(dashboardIDs, dashboardID)
+	}
+
+	if err := r.client.SetInsightDashboards(ctx, projectID, insightID, dashboardIDs); err != nil {
+		return fmt.Errorf("error updating dashboards of insight %s: %w", insightID, err)
+	}
+
+	return nil
+}
+
+func updateDashboardModel(ctx context.Context, model *dashboardResourceModel, apiDashboard *posthog.Dashboard) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(apiDashboard.ID.String())
+	model.Name = types.StringValue(apiDashboard.Name)
+	model.Description = types.StringValue(apiDashboard.Description)
+	model.Pinned = types.BoolValue(apiDashboard.Pinned)
+
+	model.Tags, diags = types.ListValueFrom(ctx, types.StringType, sortedStrings(apiDashboard.Tags))
+	if diags.HasError() {
+		return diags
+	}
+
+	for name, level := range dashboardRestrictionLevels {
+		if level == apiDashboard.RestrictionLevel {
+			model.RestrictionLevel = types.StringValue(name)
+		}
+	}
+
+	// Tiles are only managed when set in the configuration
+	if model.Tiles.IsNull() {
+		return diags
+	}
+
+	var prior []dashboardTile
+
+	diags.Append(model.Tiles.ElementsAs(ctx, &prior, true)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	model.Tiles, diags = types.ListValueFrom(ctx, dashboardTilesSchema().NestedObject.Type(), dashboardTilesToModel(prior, apiDashboard, model.IgnoreLayoutChanges.ValueBool()))
+	if diags.HasError() {
+		return diags
+	}
+
+	return diags
+}
+
+func (r *dashboardResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data dashboardResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createDashboardRequest := posthog.CreateDashboardRequest{
+		Name:             data.Name.ValueString(),
+		Description:      data.Description.ValueString(),
+		Pinned:           data.Pinned.ValueBool(),
+		RestrictionLevel: dashboardRestrictionLevels[data.RestrictionLevel.ValueString()],
+	}
+
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &createDashboardRequest.Tags, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tiles []dashboardTile
+
+	resp.Diagnostics.Append(data.Tiles.ElementsAs(ctx, &tiles, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create the dashboard
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	res, err := r.client.CreateDashboard(ctx, projectID, createDashboardRequest)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating dashboard: %s", err))
+		return
+	}
+
+	// Save the dashboard in the state right away, so that it is not lost if
+	// adding the tiles fails
+	data.ID = types.StringValue(res.ID.String())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Tiles.IsNull() {
+		res, err = r.syncDashboardTiles(ctx, projectID, res.ID, tiles)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error adding tiles to dashboard %s: %s", data.ID, err))
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(updateDashboardModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created dashboard", map[string]interface{}{"dashboard_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *dashboardResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data dashboardResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	dashboardID, err := posthog.DashboardIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid dashboard ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetDashboard(ctx, projectID, dashboardID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting dashboard %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil || res.Deleted {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if data.IgnoreLayoutChanges.IsNull() {
+		// imported resource
+		data.IgnoreLayoutChanges = types.BoolValue(false)
+	}
+
+	resp.Diagnostics.Append(updateDashboardModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read dashboard", map[string]interface{}{"dashboard_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func dashboardFromModel(ctx context.Context, data dashboardResourceModel) (posthog.ProjectID, posthog.Dashboard, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		diags.AddError("Invalid project ID", err.Error())
+		return posthog.ProjectID(0), posthog.Dashboard{}, diags
+	}
+
+	dashboardID, err := posthog.DashboardIDFromString(data.ID.ValueString())
+	if err != nil {
+		diags.AddError("Invalid dashboard ID", err.Error())
+		return posthog.ProjectID(0), posthog.Dashboard{}, diags
+	}
+
+	dashboard := posthog.Dashboard{
+		ID:               dashboardID,
+		Name:             data.Name.ValueString(),
+		Description:      data.Description.ValueString(),
+		Pinned:           data.Pinned.ValueBool(),
+		RestrictionLevel: dashboardRestrictionLevels[data.RestrictionLevel.ValueString()],
+	}
+
+	diags.Append(data.Tags.ElementsAs(ctx, &dashboard.Tags, false)...)
+	if diags.HasError() {
+		return posthog.ProjectID(0), posthog.Dashboard{}, diags
+	}
+
+	return projectID, dashboard, diags
+}
+
+func (r *dashboardResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data dashboardResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, dashboard, diags := dashboardFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tiles []dashboardTile
+
+	resp.Diagnostics.Append(data.Tiles.ElementsAs(ctx, &tiles, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, err := r.client.UpdateDashboard(ctx, projectID, dashboard)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating dashboard %s: %s", dashboard.ID, err))
+		return
+	}
+
+	if !data.Tiles.IsNull() {
+		res, err = r.syncDashboardTiles(ctx, projectID, dashboard.ID, tiles)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating tiles of dashboard %s: %s", dashboard.ID, err))
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(updateDashboardModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated dashboard", map[string]interface{}{"dashboard_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *dashboardResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data dashboardResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, dashboard, diags := dashboardFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Dashboards are soft deleted, like in the web UI. Insights on the
+	// dashboard are kept.
+	dashboard.Deleted = true
+
+	_, err := r.client.UpdateDashboard(ctx, projectID, dashboard)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting dashboard %s: %s", dashboard.ID, err))
+		return
+	}
+}
+
+func (r *dashboardResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, dashboardID, err := parseImportID(req.ID, "dashboard", posthog.DashboardIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), dashboardID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
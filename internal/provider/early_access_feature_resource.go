@@ -0,0 +1,339 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+var _ resource.Resource = &earlyAccessFeatureResource{}
+var _ resource.ResourceWithImportState = &earlyAccessFeatureResource{}
+var _ resource.ResourceWithValidateConfig = &earlyAccessFeatureResource{}
+
+func newEarlyAccessFeatureResource() resource.Resource {
+	return &earlyAccessFeatureResource{}
+}
+
+type earlyAccessFeatureResource struct {
+	client *posthog.Client
+}
+
+type earlyAccessFeatureResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	ProjectID        types.String `tfsdk:"project_id"`
+	Name             types.String `tfsdk:"name"`
+	Description      types.String `tfsdk:"description"`
+	Stage            types.String `tfsdk:"stage"`
+	DocumentationURL types.String `tfsdk:"documentation_url"`
+	FeatureFlagID    types.String `tfsdk:"feature_flag_id"`
+	FeatureFlagKey   types.String `tfsdk:"feature_flag_key"`
+}
+
+func (r *earlyAccessFeatureResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_early_access_feature"
+}
+
+func (r *earlyAccessFeatureResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Early Access Feature. Users opting into the feature get its feature flag enabled: PostHog adds a condition to the flag for them, which `posthog_feature_flag` keeps when updating the flag.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the early access feature",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the early access feature",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the early access feature",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the early access feature, shown to the users",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"stage": schema.StringAttribute{
+				MarkdownDescription: "Stage of the early access feature: `concept`, `alpha`, `beta` or `general-availability`. Users opting into a feature in the `concept` stage only register their interest, the feature flag is not enabled for them.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(posthog.EarlyAccessFeatureStageConcept),
+						string(posthog.EarlyAccessFeatureStageAlpha),
+						string(posthog.EarlyAccessFeatureStageBeta),
+						string(posthog.EarlyAccessFeatureStageGeneralAvailability),
+					),
+				},
+			},
+			"documentation_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the documentation of the early access feature",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"feature_flag_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the feature flag enabled for the users opting into the feature. The flag can't be linked to another early access feature. Changing it recreates the early access feature.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"feature_flag_key": schema.StringAttribute{
+				MarkdownDescription: "Key of the feature flag",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *earlyAccessFeatureResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *earlyAccessFeatureResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data earlyAccessFeatureResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.FeatureFlagID.IsNull() && !data.FeatureFlagID.IsUnknown() {
+		if _, err := posthog.FeatureFlagIDFromString(data.FeatureFlagID.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("feature_flag_id"), "Invalid feature flag ID", err.Error())
+		}
+	}
+
+	if documentationURL := data.DocumentationURL.ValueString(); documentationURL != "" {
+		if u, err := url.Parse(documentationURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			resp.Diagnostics.AddAttributeError(path.Root("documentation_url"), "Invalid documentation URL", fmt.Sprintf("%q is not an HTTP URL.", documentationURL))
+		}
+	}
+}
+
+func updateEarlyAccessFeatureModel(model *earlyAccessFeatureResourceModel, apiFeature *posthog.EarlyAccessFeature) {
+	model.ID = types.StringValue(apiFeature.ID.String())
+	model.Name = types.StringValue(apiFeature.Name)
+	model.Description = types.StringValue(apiFeature.Description)
+	model.Stage = types.StringValue(string(apiFeature.Stage))
+	model.DocumentationURL = types.StringValue(apiFeature.DocumentationURL)
+
+	if apiFeature.FeatureFlag != nil {
+		model.FeatureFlagID = types.StringValue(apiFeature.FeatureFlag.ID.String())
+		model.FeatureFlagKey = types.StringValue(apiFeature.FeatureFlag.Key)
+	} else {
+		model.FeatureFlagID = types.StringNull()
+		model.FeatureFlagKey = types.StringNull()
+	}
+}
+
+func (r *earlyAccessFeatureResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data earlyAccessFeatureResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	featureFlagID, err := posthog.FeatureFlagIDFromString(data.FeatureFlagID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid feature flag ID", err.Error())
+		return
+	}
+
+	createEarlyAccessFeatureRequest := posthog.CreateEarlyAccessFeatureRequest{
+		Name:             data.Name.ValueString(),
+		Description:      data.Description.ValueString(),
+		Stage:            posthog.EarlyAccessFeatureStage(data.Stage.ValueString()),
+		DocumentationURL: data.DocumentationURL.ValueString(),
+		FeatureFlagID:    featureFlagID,
+	}
+
+	// Create the early access feature
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	res, err := r.client.CreateEarlyAccessFeature(ctx, projectID, createEarlyAccessFeatureRequest)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating early access feature: %s", err))
+		return
+	}
+
+	updateEarlyAccessFeatureModel(&data, res)
+
+	tflog.Trace(ctx, "created early access feature", map[string]interface{}{"early_access_feature_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *earlyAccessFeatureResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data earlyAccessFeatureResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	featureID, err := posthog.EarlyAccessFeatureIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid early access feature ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetEarlyAccessFeature(ctx, projectID, featureID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting early access feature %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	updateEarlyAccessFeatureModel(&data, res)
+
+	tflog.Trace(ctx, "read early access feature", map[string]interface{}{"early_access_feature_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *earlyAccessFeatureResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data earlyAccessFeatureResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	feature := posthog.EarlyAccessFeature{
+		Name:             data.Name.ValueString(),
+		Description:      data.Description.ValueString(),
+		Stage:            posthog.EarlyAccessFeatureStage(data.Stage.ValueString()),
+		DocumentationURL: data.DocumentationURL.ValueString(),
+	}
+
+	feature.ID, err = posthog.EarlyAccessFeatureIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid early access feature ID", err.Error())
+		return
+	}
+
+	res, err := r.client.UpdateEarlyAccessFeature(ctx, projectID, feature)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating early access feature %s: %s", feature.ID, err))
+		return
+	}
+
+	updateEarlyAccessFeatureModel(&data, res)
+
+	tflog.Trace(ctx, "updated early access feature", map[string]interface{}{"early_access_feature_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *earlyAccessFeatureResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data earlyAccessFeatureResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	featureID, err := posthog.EarlyAccessFeatureIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid early access feature ID", err.Error())
+		return
+	}
+
+	err = r.client.DeleteEarlyAccessFeature(ctx, projectID, featureID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting early access feature %s: %s", data.ID, err))
+		return
+	}
+}
+
+func (r *earlyAccessFeatureResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, featureID, err := parseImportID(req.ID, "early access feature", posthog.EarlyAccessFeatureIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), featureID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+type eventFilter struct {
+	Event      string           `tfsdk:"event"`
+	Properties []propertyFilter `tfsdk:"properties"`
+}
+
+type actionFilter struct {
+	ActionID   string           `tfsdk:"action_id"`
+	Properties []propertyFilter `tfsdk:"properties"`
+}
+
+func eventFiltersSchema(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"event": schema.StringAttribute{
+					MarkdownDescription: "Name of the event",
+					Required:            true,
+				},
+				"properties": propertyFiltersSchema("Filters applied to the event", posthog.PropertyFilterTypeEvent),
+			},
+		},
+	}
+}
+
+func actionFiltersSchema(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"action_id": schema.StringAttribute{
+					MarkdownDescription: "ID of the action",
+					Required:            true,
+				},
+				"properties": propertyFiltersSchema("Filters applied to the events matching the action", posthog.PropertyFilterTypeEvent),
+			},
+		},
+	}
+}
+
+// entityFiltersFromModel converts events and actions to entity filters,
+// numbering them in order, events first.
+func entityFiltersFromModel(events []eventFilter, actions []actionFilter) ([]posthog.EntityFilter, []posthog.EntityFilter) {
+	var eventEntities, actionEntities []posthog.EntityFilter
+
+	order := int64(0)
+
+	for _, e := range events {
+		eventEntities = append(eventEntities, posthog.EntityFilter{
+			ID:         e.Event,
+			Type:       posthog.InsightEntityTypeEvents,
+			Name:       e.Event,
+			Order:      order,
+			Properties: propertyFiltersFromModel(e.Properties),
+		})
+		order++
+	}
+
+	for _, a := range actions {
+		actionEntities = append(actionEntities, posthog.EntityFilter{
+			ID:         a.ActionID,
+			Type:       posthog.InsightEntityTypeActions,
+			Order:      order,
+			Properties: propertyFiltersFromModel(a.Properties),
+		})
+		order++
+	}
+
+	return eventEntities, actionEntities
+}
+
+// entityFiltersToModel splits entity filters into events and actions.
+func entityFiltersToModel(entities []posthog.EntityFilter) ([]eventFilter, []actionFilter) {
+	var (
+		events  []eventFilter
+		actions []actionFilter
+	)
+
+	for _, e := range entities {
+		if e.Type == posthog.InsightEntityTypeActions {
+			actions = append(actions, actionFilter{ActionID: e.ID, Properties: propertyFiltersToModel(e.Properties)})
+		} else {
+			events = append(events, eventFilter{Event: e.ID, Properties: propertyFiltersToModel(e.Properties)})
+		}
+	}
+
+	return events, actions
+}
@@ -0,0 +1,304 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+var _ resource.Resource = &errorTrackingAssignmentRuleResource{}
+var _ resource.ResourceWithImportState = &errorTrackingAssignmentRuleResource{}
+var _ resource.ResourceWithConfigValidators = &errorTrackingAssignmentRuleResource{}
+
+func newErrorTrackingAssignmentRuleResource() resource.Resource {
+	return &errorTrackingAssignmentRuleResource{}
+}
+
+type errorTrackingAssignmentRuleResource struct {
+	client *posthog.Client
+}
+
+type errorTrackingAssignmentRuleResourceModel struct {
+	ID               types.String         `tfsdk:"id"`
+	ProjectID        types.String         `tfsdk:"project_id"`
+	Filters          errorTrackingFilters `tfsdk:"filters"`
+	AssigneeUserUUID types.String         `tfsdk:"assignee_user_uuid"`
+	AssigneeRoleID   types.String         `tfsdk:"assignee_role_id"`
+}
+
+func (r *errorTrackingAssignmentRuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_error_tracking_assignment_rule"
+}
+
+func (r *errorTrackingAssignmentRuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Error Tracking Assignment Rule, assigning the new issues matching filters to a user or a role.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the rule",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the rule",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"filters":            errorTrackingFiltersSchema("New issues whose first exception matches all (or any, see `match`) of the filters are assigned"),
+			"assignee_user_uuid": errorTrackingAssigneeUserUUIDSchema(),
+			"assignee_role_id":   errorTrackingAssigneeRoleIDSchema(),
+		},
+	}
+}
+
+func (r *errorTrackingAssignmentRuleResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("assignee_user_uuid"),
+			path.MatchRoot("assignee_role_id"),
+		),
+	}
+}
+
+func (r *errorTrackingAssignmentRuleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *errorTrackingAssignmentRuleResource) updateModel(ctx context.Context, projectID posthog.ProjectID, model *errorTrackingAssignmentRuleResourceModel, apiRule *posthog.ErrorTrackingAssignmentRule) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(apiRule.ID.String())
+	model.Filters = errorTrackingFiltersToModel(apiRule.Filters)
+	model.AssigneeUserUUID, model.AssigneeRoleID, diags = errorTrackingAssigneeToModel(ctx, r.client, projectID, &apiRule.Assignee)
+
+	return diags
+}
+
+func (r *errorTrackingAssignmentRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data errorTrackingAssignmentRuleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	assignee, diags := errorTrackingAssigneeFromModel(ctx, r.client, projectID, data.AssigneeUserUUID, data.AssigneeRoleID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The config validators are skipped when the values are unknown
+	if assignee == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("assignee_user_uuid"), "Missing assignee", "Exactly one of assignee_user_uuid and assignee_role_id must be set.")
+		return
+	}
+
+	// Create the rule
+
+	res, err := r.client.CreateErrorTrackingAssignmentRule(ctx, projectID, posthog.CreateErrorTrackingAssignmentRuleRequest{
+		Filters:  errorTrackingFiltersFromModel(data.Filters),
+		Assignee: *assignee,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating assignment rule: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(r.updateModel(ctx, projectID, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created assignment rule", map[string]interface{}{"assignment_rule_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *errorTrackingAssignmentRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data errorTrackingAssignmentRuleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	ruleID, err := posthog.ErrorTrackingRuleIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid assignment rule ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetErrorTrackingAssignmentRule(ctx, projectID, ruleID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting assignment rule %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(r.updateModel(ctx, projectID, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read assignment rule", map[string]interface{}{"assignment_rule_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *errorTrackingAssignmentRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data errorTrackingAssignmentRuleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	ruleID, err := posthog.ErrorTrackingRuleIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid assignment rule ID", err.Error())
+		return
+	}
+
+	assignee, diags := errorTrackingAssigneeFromModel(ctx, r.client, projectID, data.AssigneeUserUUID, data.AssigneeRoleID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The config validators are skipped when the values are unknown
+	if assignee == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("assignee_user_uuid"), "Missing assignee", "Exactly one of assignee_user_uuid and assignee_role_id must be set.")
+		return
+	}
+
+	err = r.client.UpdateErrorTrackingAssignmentRule(ctx, projectID, posthog.ErrorTrackingAssignmentRule{
+		ID:       ruleID,
+		Filters:  errorTrackingFiltersFromModel(data.Filters),
+		Assignee: *assignee,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating assignment rule %s: %s", ruleID, err))
+		return
+	}
+
+	res, err := r.client.GetErrorTrackingAssignmentRule(ctx, projectID, ruleID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting assignment rule %s: %s", ruleID, err))
+		return
+	}
+
+	if res == nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Assignment rule %s not found", ruleID))
+		return
+	}
+
+	resp.Diagnostics.Append(r.updateModel(ctx, projectID, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated assignment rule", map[string]interface{}{"assignment_rule_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *errorTrackingAssignmentRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data errorTrackingAssignmentRuleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	ruleID, err := posthog.ErrorTrackingRuleIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid assignment rule ID", err.Error())
+		return
+	}
+
+	err = r.client.DeleteErrorTrackingAssignmentRule(ctx, projectID, ruleID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting assignment rule %s: %s", data.ID, err))
+		return
+	}
+}
+
+func (r *errorTrackingAssignmentRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, ruleID, err := parseImportID(req.ID, "assignment rule", posthog.ErrorTrackingRuleIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), ruleID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
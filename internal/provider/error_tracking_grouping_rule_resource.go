@@ -0,0 +1,303 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+var _ resource.Resource = &errorTrackingGroupingRuleResource{}
+var _ resource.ResourceWithImportState = &errorTrackingGroupingRuleResource{}
+var _ resource.ResourceWithConfigValidators = &errorTrackingGroupingRuleResource{}
+
+func newErrorTrackingGroupingRuleResource() resource.Resource {
+	return &errorTrackingGroupingRuleResource{}
+}
+
+type errorTrackingGroupingRuleResource struct {
+	client *posthog.Client
+}
+
+type errorTrackingGroupingRuleResourceModel struct {
+	ID               types.String         `tfsdk:"id"`
+	ProjectID        types.String         `tfsdk:"project_id"`
+	Filters          errorTrackingFilters `tfsdk:"filters"`
+	AssigneeUserUUID types.String         `tfsdk:"assignee_user_uuid"`
+	AssigneeRoleID   types.String         `tfsdk:"assignee_role_id"`
+	Description      types.String         `tfsdk:"description"`
+}
+
+func (r *errorTrackingGroupingRuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_error_tracking_grouping_rule"
+}
+
+func (r *errorTrackingGroupingRuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Error Tracking Grouping Rule, grouping all the exceptions matching filters into a single issue instead of grouping them by fingerprint.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the rule",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the rule",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"filters":            errorTrackingFiltersSchema("Exceptions matching all (or any, see `match`) of the filters are grouped into the issue of the rule"),
+			"assignee_user_uuid": errorTrackingAssigneeUserUUIDSchema(),
+			"assignee_role_id":   errorTrackingAssigneeRoleIDSchema(),
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the rule",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+		},
+	}
+}
+
+func (r *errorTrackingGroupingRuleResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("assignee_user_uuid"),
+			path.MatchRoot("assignee_role_id"),
+		),
+	}
+}
+
+func (r *errorTrackingGroupingRuleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *errorTrackingGroupingRuleResource) updateModel(ctx context.Context, projectID posthog.ProjectID, model *errorTrackingGroupingRuleResourceModel, apiRule *posthog.ErrorTrackingGroupingRule) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(apiRule.ID.String())
+	model.Filters = errorTrackingFiltersToModel(apiRule.Filters)
+	model.Description = types.StringValue(apiRule.Description)
+	model.AssigneeUserUUID, model.AssigneeRoleID, diags = errorTrackingAssigneeToModel(ctx, r.client, projectID, apiRule.Assignee)
+
+	return diags
+}
+
+func (r *errorTrackingGroupingRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data errorTrackingGroupingRuleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	assignee, diags := errorTrackingAssigneeFromModel(ctx, r.client, projectID, data.AssigneeUserUUID, data.AssigneeRoleID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create the rule
+
+	res, err := r.client.CreateErrorTrackingGroupingRule(ctx, projectID, posthog.CreateErrorTrackingGroupingRuleRequest{
+		Filters:     errorTrackingFiltersFromModel(data.Filters),
+		Assignee:    assignee,
+		Description: data.Description.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating grouping rule: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(r.updateModel(ctx, projectID, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created grouping rule", map[string]interface{}{"grouping_rule_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *errorTrackingGroupingRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data errorTrackingGroupingRuleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	ruleID, err := posthog.ErrorTrackingRuleIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid grouping rule ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetErrorTrackingGroupingRule(ctx, projectID, ruleID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting grouping rule %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(r.updateModel(ctx, projectID, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read grouping rule", map[string]interface{}{"grouping_rule_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *errorTrackingGroupingRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data errorTrackingGroupingRuleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	ruleID, err := posthog.ErrorTrackingRuleIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid grouping rule ID", err.Error())
+		return
+	}
+
+	assignee, diags := errorTrackingAssigneeFromModel(ctx, r.client, projectID, data.AssigneeUserUUID, data.AssigneeRoleID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err = r.client.UpdateErrorTrackingGroupingRule(ctx, projectID, posthog.ErrorTrackingGroupingRule{
+		ID:          ruleID,
+		Filters:     errorTrackingFiltersFromModel(data.Filters),
+		Assignee:    assignee,
+		Description: data.Description.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating grouping rule %s: %s", ruleID, err))
+		return
+	}
+
+	res, err := r.client.GetErrorTrackingGroupingRule(ctx, projectID, ruleID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting grouping rule %s: %s", ruleID, err))
+		return
+	}
+
+	if res == nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Grouping rule %s not found", ruleID))
+		return
+	}
+
+	resp.Diagnostics.Append(r.updateModel(ctx, projectID, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated grouping rule", map[string]interface{}{"grouping_rule_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *errorTrackingGroupingRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data errorTrackingGroupingRuleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	ruleID, err := posthog.ErrorTrackingRuleIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid grouping rule ID", err.Error())
+		return
+	}
+
+	err = r.client.DeleteErrorTrackingGroupingRule(ctx, projectID, ruleID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting grouping rule %s: %s", data.ID, err))
+		return
+	}
+}
+
+func (r *errorTrackingGroupingRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, ruleID, err := parseImportID(req.ID, "grouping rule", posthog.ErrorTrackingRuleIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), ruleID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
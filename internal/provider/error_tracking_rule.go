@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+// Shared by the error tracking grouping, suppression and assignment rules.
+
+type errorTrackingFilters struct {
+	Match      string           `tfsdk:"match"`
+	Properties []propertyFilter `tfsdk:"properties"`
+}
+
+const (
+	errorTrackingMatchAll = "all"
+	errorTrackingMatchAny = "any"
+)
+
+func errorTrackingFiltersSchema(description string) schema.SingleNestedAttribute {
+	properties := propertyFiltersSchema("Filters on the properties of the exception events (eg. `$exception_types`, `$exception_values` or `$exception_sources`)", posthog.PropertyFilterTypeEvent)
+	properties.Optional = false
+	properties.Required = true
+	properties.Validators = []validator.List{
+		listvalidator.SizeAtLeast(1),
+	}
+
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: description,
+		Required:            true,
+		Attributes: map[string]schema.Attribute{
+			"match": schema.StringAttribute{
+				MarkdownDescription: "Whether exceptions must match `all` or `any` of the filters",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(errorTrackingMatchAll),
+				Validators: []validator.String{
+					stringvalidator.OneOf(errorTrackingMatchAll, errorTrackingMatchAny),
+				},
+			},
+			"properties": properties,
+		},
+	}
+}
+
+func errorTrackingFiltersFromModel(filters errorTrackingFilters) posthog.ErrorTrackingFilters {
+	res := posthog.ErrorTrackingFilters{
+		Type:       posthog.PropertyGroupTypeAnd,
+		Properties: propertyFiltersFromModel(filters.Properties),
+	}
+
+	if filters.Match == errorTrackingMatchAny {
+		res.Type = posthog.PropertyGroupTypeOr
+	}
+
+	return res
+}
+
+func errorTrackingFiltersToModel(filters posthog.ErrorTrackingFilters) errorTrackingFilters {
+	res := errorTrackingFilters{
+		Match:      errorTrackingMatchAll,
+		Properties: propertyFiltersToModel(filters.Properties),
+	}
+
+	if filters.Type == posthog.PropertyGroupTypeOr {
+		res.Match = errorTrackingMatchAny
+	}
+
+	return res
+}
+
+func errorTrackingAssigneeUserUUIDSchema() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "UUID of the organization member issues are assigned to, for example the `id` of a `posthog_organization_membership`",
+		Optional:            true,
+	}
+}
+
+func errorTrackingAssigneeRoleIDSchema() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "ID of the role issues are assigned to",
+		Optional:            true,
+	}
+}
+
+// projectOrganizationMembers lists the members of the organization of a
+// project, to map user UUIDs to the numeric IDs used by error tracking.
+func projectOrganizationMembers(ctx context.Context, client *posthog.Client, projectID posthog.ProjectID) ([]posthog.OrganizationMember, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	project, err := client.GetProject(ctx, projectID)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Error getting project %s: %s", projectID, err))
+		return nil, diags
+	}
+
+	if project == nil {
+		diags.AddAttributeError(path.Root("project_id"), "Project not found", fmt.Sprintf("No project with ID %s.", projectID))
+		return nil, diags
+	}
+
+	members, err := client.ListOrganizationMembers(ctx, project.Organization)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Error listing the members of organization %s: %s", project.Organization, err))
+		return nil, diags
+	}
+
+	return members, diags
+}
+
+// errorTrackingAssigneeFromModel returns the assignee of a rule, or nil if
+// neither userUUID nor roleID are set.
+func errorTrackingAssigneeFromModel(ctx context.Context, client *posthog.Client, projectID posthog.ProjectID, userUUID, roleID types.String) (*posthog.ErrorTrackingAssignee, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !roleID.IsNull() {
+		return &posthog.ErrorTrackingAssignee{Type: posthog.ErrorTrackingAssigneeTypeRole, ID: roleID.ValueString()}, diags
+	}
+
+	if userUUID.IsNull() {
+		return nil, diags
+	}
+
+	members, diags := projectOrganizationMembers(ctx, client, projectID)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	for _, m := range members {
+		if m.User.UUID == userUUID.ValueString() {
+			return &posthog.ErrorTrackingAssignee{Type: posthog.ErrorTrackingAssigneeTypeUser, ID: m.User.ID.String()}, diags
+		}
+	}
+
+	diags.AddAttributeError(path.Root("assignee_user_uuid"), "Not a member", fmt.Sprintf("User %s is not a member of the organization of project %s.", userUUID, projectID))
+
+	return nil, diags
+}
+
+// errorTrackingAssigneeToModel returns the user UUID and role ID of an
+// assignee.
+func errorTrackingAssigneeToModel(ctx context.Context, client *posthog.Client, projectID posthog.ProjectID, assignee *posthog.ErrorTrackingAssignee) (types.String, types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if assignee == nil {
+		return types.StringNull(), types.StringNull(), diags
+	}
+
+	if assignee.Type == posthog.ErrorTrackingAssigneeTypeRole {
+		return types.StringNull(), types.StringValue(assignee.ID), diags
+	}
+
+	members, diags := projectOrganizationMembers(ctx, client, projectID)
+	if diags.HasError() {
+		return types.StringNull(), types.StringNull(), diags
+	}
+
+	for _, m := range members {
+		if m.User.ID.String() == assignee.ID {
+			return types.StringValue(m.User.UUID), types.StringNull(), diags
+		}
+	}
+
+	// The user left the organization
+	return types.StringNull(), types.StringNull(), diags
+}
@@ -0,0 +1,248 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+var _ resource.Resource = &errorTrackingSuppressionRuleResource{}
+var _ resource.ResourceWithImportState = &errorTrackingSuppressionRuleResource{}
+
+func newErrorTrackingSuppressionRuleResource() resource.Resource {
+	return &errorTrackingSuppressionRuleResource{}
+}
+
+type errorTrackingSuppressionRuleResource struct {
+	client *posthog.Client
+}
+
+type errorTrackingSuppressionRuleResourceModel struct {
+	ID        types.String         `tfsdk:"id"`
+	ProjectID types.String         `tfsdk:"project_id"`
+	Filters   errorTrackingFilters `tfsdk:"filters"`
+}
+
+func (r *errorTrackingSuppressionRuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_error_tracking_suppression_rule"
+}
+
+func (r *errorTrackingSuppressionRuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Error Tracking Suppression Rule. Exceptions matching the filters are dropped: they neither create nor update issues.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the rule",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the rule",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"filters": errorTrackingFiltersSchema("Exceptions matching all (or any, see `match`) of the filters are suppressed"),
+		},
+	}
+}
+
+func (r *errorTrackingSuppressionRuleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func updateErrorTrackingSuppressionRuleModel(model *errorTrackingSuppressionRuleResourceModel, apiRule *posthog.ErrorTrackingSuppressionRule) {
+	model.ID = types.StringValue(apiRule.ID.String())
+	model.Filters = errorTrackingFiltersToModel(apiRule.Filters)
+}
+
+func (r *errorTrackingSuppressionRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data errorTrackingSuppressionRuleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	// Create the rule
+
+	res, err := r.client.CreateErrorTrackingSuppressionRule(ctx, projectID, posthog.CreateErrorTrackingSuppressionRuleRequest{
+		Filters: errorTrackingFiltersFromModel(data.Filters),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating suppression rule: %s", err))
+		return
+	}
+
+	updateErrorTrackingSuppressionRuleModel(&data, res)
+
+	tflog.Trace(ctx, "created suppression rule", map[string]interface{}{"suppression_rule_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *errorTrackingSuppressionRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data errorTrackingSuppressionRuleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	ruleID, err := posthog.ErrorTrackingRuleIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid suppression rule ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetErrorTrackingSuppressionRule(ctx, projectID, ruleID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting suppression rule %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	updateErrorTrackingSuppressionRuleModel(&data, res)
+
+	tflog.Trace(ctx, "read suppression rule", map[string]interface{}{"suppression_rule_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *errorTrackingSuppressionRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data errorTrackingSuppressionRuleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	ruleID, err := posthog.ErrorTrackingRuleIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid suppression rule ID", err.Error())
+		return
+	}
+
+	err = r.client.UpdateErrorTrackingSuppressionRule(ctx, projectID, posthog.ErrorTrackingSuppressionRule{
+		ID:      ruleID,
+		Filters: errorTrackingFiltersFromModel(data.Filters),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating suppression rule %s: %s", ruleID, err))
+		return
+	}
+
+	res, err := r.client.GetErrorTrackingSuppressionRule(ctx, projectID, ruleID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting suppression rule %s: %s", ruleID, err))
+		return
+	}
+
+	if res == nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Suppression rule %s not found", ruleID))
+		return
+	}
+
+	updateErrorTrackingSuppressionRuleModel(&data, res)
+
+	tflog.Trace(ctx, "updated suppression rule", map[string]interface{}{"suppression_rule_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *errorTrackingSuppressionRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data errorTrackingSuppressionRuleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	ruleID, err := posthog.ErrorTrackingRuleIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid suppression rule ID", err.Error())
+		return
+	}
+
+	err = r.client.DeleteErrorTrackingSuppressionRule(ctx, projectID, ruleID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting suppression rule %s: %s", data.ID, err))
+		return
+	}
+}
+
+func (r *errorTrackingSuppressionRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, ruleID, err := parseImportID(req.ID, "suppression rule", posthog.ErrorTrackingRuleIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), ruleID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
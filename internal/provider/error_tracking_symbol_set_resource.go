@@ -0,0 +1,335 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+	"github.com/abustany/terraform-provider-posthog/internal/typeutil"
+)
+
+var _ resource.Resource = &errorTrackingSymbolSetResource{}
+var _ resource.ResourceWithImportState = &errorTrackingSymbolSetResource{}
+var _ resource.ResourceWithModifyPlan = &errorTrackingSymbolSetResource{}
+
+func newErrorTrackingSymbolSetResource() resource.Resource {
+	return &errorTrackingSymbolSetResource{}
+}
+
+type errorTrackingSymbolSetResource struct {
+	client *posthog.Client
+}
+
+type errorTrackingSymbolSetResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	ProjectID     types.String `tfsdk:"project_id"`
+	ChunkID       types.String `tfsdk:"chunk_id"`
+	ReleaseID     types.String `tfsdk:"release_id"`
+	File          types.String `tfsdk:"file"`
+	FileHash      types.String `tfsdk:"file_hash"`
+	FailureReason types.String `tfsdk:"failure_reason"`
+}
+
+func (r *errorTrackingSymbolSetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_error_tracking_symbol_set"
+}
+
+func (r *errorTrackingSymbolSetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Error Tracking Symbol Set, the data used to resolve the stack traces of exceptions (eg. a source map and its minified file) of a release. The chunk ID is injected in the minified files by the PostHog CLI (`posthog-cli sourcemap inject`). A symbol set can't be modified, changing its file uploads a new one.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the symbol set",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the symbol set",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"chunk_id": schema.StringAttribute{
+				MarkdownDescription: "Chunk ID the PostHog CLI injected in the minified file, used to find the symbol set of the frames of a stack trace",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"release_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the release the symbol set belongs to",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"file": schema.StringAttribute{
+				MarkdownDescription: "Path to the symbol set file to upload, uploaded as is: it must be in the symbol set format of PostHog, as uploaded by the PostHog CLI",
+				Required:            true,
+			},
+			"file_hash": schema.StringAttribute{
+				MarkdownDescription: "Hash of the uploaded file, the symbol set is uploaded again when it changes",
+				Computed:            true,
+			},
+			"failure_reason": schema.StringAttribute{
+				MarkdownDescription: "Why PostHog failed to process the symbol set, if it did",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *errorTrackingSymbolSetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func symbolSetFileHash(data []byte) types.String {
+	sum := sha256.Sum256(data)
+	return types.StringValue(hex.EncodeToString(sum[:]))
+}
+
+func (r *errorTrackingSymbolSetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// destroy
+		return
+	}
+
+	var data errorTrackingSymbolSetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.File.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("file_hash"), types.StringUnknown())...)
+		return
+	}
+
+	content, err := os.ReadFile(data.File.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("file"), "Error reading symbol set file", err.Error())
+		return
+	}
+
+	fileHash := symbolSetFileHash(content)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("file_hash"), fileHash)...)
+
+	if req.State.Raw.IsNull() {
+		// create
+		return
+	}
+
+	var state errorTrackingSymbolSetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The hash is unknown after an import, don't upload the file again then
+	if !state.FileHash.IsNull() && !state.FileHash.Equal(fileHash) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("file_hash"))
+	}
+}
+
+func updateErrorTrackingSymbolSetModel(model *errorTrackingSymbolSetResourceModel, apiSymbolSet *posthog.ErrorTrackingSymbolSet) {
+	model.ID = types.StringValue(apiSymbolSet.ID.String())
+	model.ChunkID = types.StringValue(apiSymbolSet.Ref)
+	model.ReleaseID = typeutil.NullableStringPointerValue(apiSymbolSet.Release)
+	model.FailureReason = typeutil.NullableStringPointerValue(apiSymbolSet.FailureReason)
+}
+
+func (r *errorTrackingSymbolSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data errorTrackingSymbolSetResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	content, err := os.ReadFile(data.File.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("file"), "Error reading symbol set file", err.Error())
+		return
+	}
+
+	// Upload the symbol set
+
+	err = r.client.UploadErrorTrackingSymbolSet(ctx, projectID, data.ChunkID.ValueString(), data.ReleaseID.ValueString(), content)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error uploading symbol set: %s", err))
+		return
+	}
+
+	res, err := r.client.GetErrorTrackingSymbolSetByRef(ctx, projectID, data.ChunkID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting symbol set %s: %s", data.ChunkID, err))
+		return
+	}
+
+	if res == nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Symbol set %s not found after uploading it", data.ChunkID))
+		return
+	}
+
+	updateErrorTrackingSymbolSetModel(&data, res)
+	data.FileHash = symbolSetFileHash(content)
+
+	tflog.Trace(ctx, "created symbol set", map[string]interface{}{"symbol_set_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *errorTrackingSymbolSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data errorTrackingSymbolSetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	symbolSetID, err := posthog.ErrorTrackingSymbolSetIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid symbol set ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetErrorTrackingSymbolSet(ctx, projectID, symbolSetID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting symbol set %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	updateErrorTrackingSymbolSetModel(&data, res)
+
+	tflog.Trace(ctx, "read symbol set", map[string]interface{}{"symbol_set_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *errorTrackingSymbolSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data errorTrackingSymbolSetResourceModel
+
+	// Only the path of the file can change without replacing the symbol set,
+	// nothing to update in PostHog
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The hash is unknown in the plan if the path of the file was
+	if data.FileHash.IsUnknown() {
+		content, err := os.ReadFile(data.File.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("file"), "Error reading symbol set file", err.Error())
+			return
+		}
+
+		data.FileHash = symbolSetFileHash(content)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *errorTrackingSymbolSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data errorTrackingSymbolSetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	symbolSetID, err := posthog.ErrorTrackingSymbolSetIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid symbol set ID", err.Error())
+		return
+	}
+
+	err = r.client.DeleteErrorTrackingSymbolSet(ctx, projectID, symbolSetID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting symbol set %s: %s", data.ID, err))
+		return
+	}
+}
+
+func (r *errorTrackingSymbolSetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, symbolSetID, err := parseImportID(req.ID, "symbol set", posthog.ErrorTrackingSymbolSetIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), symbolSetID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
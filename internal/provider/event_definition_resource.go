@@ -0,0 +1,345 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+var _ resource.Resource = &eventDefinitionResource{}
+var _ resource.ResourceWithImportState = &eventDefinitionResource{}
+
+func newEventDefinitionResource() resource.Resource {
+	return &eventDefinitionResource{}
+}
+
+type eventDefinitionResource struct {
+	client *posthog.Client
+}
+
+type eventDefinitionResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	ProjectID   types.String `tfsdk:"project_id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Tags        types.List   `tfsdk:"tags"`
+	OwnerID     types.String `tfsdk:"owner_id"`
+	Verified    types.Bool   `tfsdk:"verified"`
+	Hidden      types.Bool   `tfsdk:"hidden"`
+}
+
+func (r *eventDefinitionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_event_definition"
+}
+
+func (r *eventDefinitionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the metadata of a Posthog Event Definition. Event definitions are created by PostHog when the first event with a given name is ingested: this resource adopts an existing definition, and resets its metadata when destroyed.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID (UUID) of the event definition",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the event",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the event",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the event",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"tags": schema.ListAttribute{
+				MarkdownDescription: "Event tags",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				Default:             listdefault.StaticValue(types.ListNull(types.StringType)),
+			},
+			"owner_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the user owning the event",
+				Optional:            true,
+			},
+			"verified": schema.BoolAttribute{
+				MarkdownDescription: "Whether the event is verified, verified events are suggested first in filters",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"hidden": schema.BoolAttribute{
+				MarkdownDescription: "Whether the event is hidden from filters",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *eventDefinitionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func updateEventDefinitionModel(ctx context.Context, model *eventDefinitionResourceModel, apiDefinition *posthog.EventDefinition) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(apiDefinition.ID.String())
+	model.Name = types.StringValue(apiDefinition.Name)
+	model.Description = types.StringValue(apiDefinition.Description)
+	model.Verified = types.BoolValue(apiDefinition.Verified)
+	model.Hidden = types.BoolValue(apiDefinition.Hidden)
+
+	if apiDefinition.Owner != nil {
+		model.OwnerID = types.StringValue(apiDefinition.Owner.ID.String())
+	} else {
+		model.OwnerID = types.StringNull()
+	}
+
+	model.Tags, diags = types.ListValueFrom(ctx, types.StringType, sortedStrings(apiDefinition.Tags))
+
+	return diags
+}
+
+func eventDefinitionFromModel(ctx context.Context, data eventDefinitionResourceModel) (posthog.UpdateEventDefinitionRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	req := posthog.UpdateEventDefinitionRequest{
+		Description: data.Description.ValueString(),
+		Verified:    data.Verified.ValueBool(),
+		Hidden:      data.Hidden.ValueBool(),
+	}
+
+	if !data.OwnerID.IsNull() {
+		ownerID, err := posthog.UserIDFromString(data.OwnerID.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("owner_id"), "Invalid owner ID", err.Error())
+			return req, diags
+		}
+
+		req.Owner = &ownerID
+	}
+
+	diags.Append(data.Tags.ElementsAs(ctx, &req.Tags, false)...)
+
+	return req, diags
+}
+
+func (r *eventDefinitionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data eventDefinitionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateRequest, diags := eventDefinitionFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	// Adopt the existing definition
+
+	definition, err := r.client.GetEventDefinitionByName(ctx, projectID, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error looking up event definition %s: %s", data.Name, err))
+		return
+	}
+
+	if definition == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Event definition not found", fmt.Sprintf("No event named %s was ingested in project %s yet, its definition does not exist.", data.Name, projectID))
+		return
+	}
+
+	res, err := r.client.UpdateEventDefinition(ctx, projectID, definition.ID, updateRequest)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating event definition %s: %s", definition.ID, err))
+		return
+	}
+
+	resp.Diagnostics.Append(updateEventDefinitionModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "adopted event definition", map[string]interface{}{"event_definition_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *eventDefinitionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data eventDefinitionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	var res *posthog.EventDefinition
+
+	if data.ID.IsNull() {
+		// imported by name
+		res, err = r.client.GetEventDefinitionByName(ctx, projectID, data.Name.ValueString())
+	} else {
+		res, err = r.client.GetEventDefinition(ctx, projectID, posthog.EventDefinitionID(data.ID.ValueString()))
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting event definition %s: %s", data.Name, err))
+		return
+	}
+
+	if res == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(updateEventDefinitionModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read event definition", map[string]interface{}{"event_definition_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *eventDefinitionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data eventDefinitionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateRequest, diags := eventDefinitionFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	res, err := r.client.UpdateEventDefinition(ctx, projectID, posthog.EventDefinitionID(data.ID.ValueString()), updateRequest)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating event definition %s: %s", data.ID, err))
+		return
+	}
+
+	resp.Diagnostics.Append(updateEventDefinitionModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated event definition", map[string]interface{}{"event_definition_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *eventDefinitionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data eventDefinitionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	// The definition belongs to PostHog, only reset its metadata
+	_, err = r.client.UpdateEventDefinition(ctx, projectID, posthog.EventDefinitionID(data.ID.ValueString()), posthog.UpdateEventDefinitionRequest{})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error resetting event definition %s: %s", data.ID, err))
+		return
+	}
+}
+
+func (r *eventDefinitionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tokens := strings.SplitN(req.ID, "/", 2)
+	if len(tokens) != 2 {
+		resp.Diagnostics.AddError("Invalid import ID", "ID not of the form PROJECT_ID/EVENT_NAME")
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(tokens[0])
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("invalid project ID: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), tokens[1])...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
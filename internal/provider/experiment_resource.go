@@ -0,0 +1,589 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+	"github.com/abustany/terraform-provider-posthog/internal/typeutil"
+)
+
+var _ resource.Resource = &experimentResource{}
+var _ resource.ResourceWithImportState = &experimentResource{}
+var _ resource.ResourceWithValidateConfig = &experimentResource{}
+
+// experimentControlVariant is the variant PostHog compares the other ones to.
+const experimentControlVariant = "control"
+
+func newExperimentResource() resource.Resource {
+	return &experimentResource{}
+}
+
+type experimentResource struct {
+	client *posthog.Client
+}
+
+type experimentResourceModel struct {
+	ID                      types.String `tfsdk:"id"`
+	ProjectID               types.String `tfsdk:"project_id"`
+	Name                    types.String `tfsdk:"name"`
+	Description             types.String `tfsdk:"description"`
+	FeatureFlagKey          types.String `tfsdk:"feature_flag_key"`
+	FeatureFlagID           types.String `tfsdk:"feature_flag_id"`
+	Variants                types.List   `tfsdk:"variants"`
+	Metrics                 types.List   `tfsdk:"metrics"`
+	SecondaryMetrics        types.List   `tfsdk:"secondary_metrics"`
+	MinimumDetectableEffect types.Int64  `tfsdk:"minimum_detectable_effect"`
+	StartDate               types.String `tfsdk:"start_date"`
+	EndDate                 types.String `tfsdk:"end_date"`
+	HoldoutID               types.String `tfsdk:"holdout_id"`
+	Archived                types.Bool   `tfsdk:"archived"`
+}
+
+type experimentVariant struct {
+	Key               types.String `tfsdk:"key"`
+	RolloutPercentage types.Int64  `tfsdk:"rollout_percentage"`
+}
+
+func (r *experimentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_experiment"
+}
+
+func experimentVariantsSchema() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "Variants of the experiment, one of them must be `control`. Rollout percentages must add up to 100. PostHog creates a `control` and a `test` variant if unset.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"key": schema.StringAttribute{
+					MarkdownDescription: "Key of the variant",
+					Required:            true,
+				},
+				"rollout_percentage": schema.Int64Attribute{
+					MarkdownDescription: "Percentage of the users getting this variant",
+					Required:            true,
+				},
+			},
+		},
+		Optional: true,
+		Computed: true,
+		PlanModifiers: []planmodifier.List{
+			listplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+func experimentMetricsAttribute(description string) schema.ListAttribute {
+	return schema.ListAttribute{
+		MarkdownDescription: description,
+		ElementType:         jsontypes.NormalizedType{},
+		Optional:            true,
+		Computed:            true,
+		Default:             listdefault.StaticValue(types.ListValueMust(jsontypes.NormalizedType{}, []attr.Value{})),
+	}
+}
+
+func (r *experimentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Experiment",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the experiment",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the experiment",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the experiment",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the experiment",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"feature_flag_key": schema.StringAttribute{
+				MarkdownDescription: "Key of the feature flag of the experiment. PostHog creates the flag if it does not exist yet.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"feature_flag_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the feature flag of the experiment",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"variants":          experimentVariantsSchema(),
+			"metrics":           experimentMetricsAttribute("Primary metrics of the experiment, as JSON queries (eg. `ExperimentMetric` or `ExperimentTrendsQuery` nodes). Formatting differences are ignored."),
+			"secondary_metrics": experimentMetricsAttribute("Secondary metrics of the experiment, as JSON queries. Formatting differences are ignored."),
+			"minimum_detectable_effect": schema.Int64Attribute{
+				MarkdownDescription: "Minimum detectable effect, in percents, used to compute the recommended sample size",
+				Optional:            true,
+			},
+			"start_date": schema.StringAttribute{
+				MarkdownDescription: "Date the experiment was launched, in RFC 3339 format. Setting it launches the experiment.",
+				Optional:            true,
+			},
+			"end_date": schema.StringAttribute{
+				MarkdownDescription: "Date the experiment ended, in RFC 3339 format. Setting it ends the experiment.",
+				Optional:            true,
+			},
+			"holdout_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the holdout group excluded from the experiment",
+				Optional:            true,
+			},
+			"archived": schema.BoolAttribute{
+				MarkdownDescription: "Whether the experiment is archived",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *experimentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *experimentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data experimentResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, attribute := range []struct {
+		name  string
+		value types.String
+	}{
+		{"start_date", data.StartDate},
+		{"end_date", data.EndDate},
+	} {
+		if attribute.value.IsNull() || attribute.value.IsUnknown() {
+			continue
+		}
+
+		if _, err := time.Parse(time.RFC3339, attribute.value.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(attribute.name), "Invalid date", err.Error())
+		}
+	}
+
+	if data.Variants.IsNull() || data.Variants.IsUnknown() {
+		return
+	}
+
+	var variants []experimentVariant
+
+	resp.Diagnostics.Append(data.Variants.ElementsAs(ctx, &variants, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var (
+		total      int64
+		hasControl bool
+		seen       = map[string]bool{}
+	)
+
+	for i, v := range variants {
+		if v.Key.IsUnknown() || v.RolloutPercentage.IsUnknown() {
+			return
+		}
+
+		key := v.Key.ValueString()
+
+		if seen[key] {
+			resp.Diagnostics.AddAttributeError(path.Root("variants").AtListIndex(i).AtName("key"), "Duplicate variant", fmt.Sprintf("Variant %s is defined more than once.", key))
+		}
+
+		seen[key] = true
+		hasControl = hasControl || key == experimentControlVariant
+		total += v.RolloutPercentage.ValueInt64()
+	}
+
+	if !hasControl {
+		resp.Diagnostics.AddAttributeError(path.Root("variants"), "Missing control variant", fmt.Sprintf("Experiments must have a %s variant.", experimentControlVariant))
+	}
+
+	if total != 100 {
+		resp.Diagnostics.AddAttributeError(path.Root("variants"), "Invalid rollout percentages", fmt.Sprintf("Variant rollout percentages must add up to 100, got %d.", total))
+	}
+}
+
+func metricsFromModel(ctx context.Context, metrics types.List) ([]json.RawMessage, diag.Diagnostics) {
+	var (
+		values []jsontypes.Normalized
+		res    []json.RawMessage
+	)
+
+	diags := metrics.ElementsAs(ctx, &values, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	for _, v := range values {
+		res = append(res, json.RawMessage(v.ValueString()))
+	}
+
+	return res, diags
+}
+
+func metricsToModel(ctx context.Context, metrics []json.RawMessage) (types.List, diag.Diagnostics) {
+	values := make([]jsontypes.Normalized, 0, len(metrics))
+
+	for _, m := range metrics {
+		values = append(values, jsontypes.NewNormalizedValue(string(m)))
+	}
+
+	return types.ListValueFrom(ctx, jsontypes.NormalizedType{}, values)
+}
+
+func updateExperimentModel(ctx context.Context, model *experimentResourceModel, apiExperiment *posthog.Experiment) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+
+	model.ID = types.StringValue(apiExperiment.ID.String())
+	model.Name = types.StringValue(apiExperiment.Name)
+	model.Description = types.StringValue(apiExperiment.Description)
+	model.FeatureFlagKey = types.StringValue(apiExperiment.FeatureFlagKey)
+	model.MinimumDetectableEffect = types.Int64PointerValue(apiExperiment.Parameters.MinimumDetectableEffect)
+	model.StartDate = typeutil.TimeValue(model.StartDate, apiExperiment.StartDate)
+	model.EndDate = typeutil.TimeValue(model.EndDate, apiExperiment.EndDate)
+	model.Archived = types.BoolValue(apiExperiment.Archived)
+
+	if apiExperiment.FeatureFlag != nil {
+		model.FeatureFlagID = types.StringValue(apiExperiment.FeatureFlag.ID.String())
+	} else {
+		model.FeatureFlagID = types.StringNull()
+	}
+
+	if apiExperiment.Holdout != nil {
+		model.HoldoutID = types.StringValue(apiExperiment.Holdout.ID.String())
+	} else {
+		model.HoldoutID = types.StringNull()
+	}
+
+	var variants []experimentVariant
+	for _, v := range apiExperiment.Parameters.FeatureFlagVariants {
+		variants = append(variants, experimentVariant{
+			Key:               types.StringValue(v.Key),
+			RolloutPercentage: types.Int64Value(v.RolloutPercentage),
+		})
+	}
+
+	model.Variants, d = types.ListValueFrom(ctx, experimentVariantsSchema().NestedObject.Type(), variants)
+	diags.Append(d...)
+
+	model.Metrics, d = metricsToModel(ctx, apiExperiment.Metrics)
+	diags.Append(d...)
+
+	model.SecondaryMetrics, d = metricsToModel(ctx, apiExperiment.MetricsSecondary)
+	diags.Append(d...)
+
+	return diags
+}
+
+// experimentFieldsFromModel converts the fields shared by the create and
+// update requests.
+func experimentFieldsFromModel(ctx context.Context, data experimentResourceModel) (posthog.Experiment, diag.Diagnostics) {
+	var (
+		diags diag.Diagnostics
+		d     diag.Diagnostics
+		err   error
+	)
+
+	experiment := posthog.Experiment{
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueString(),
+		Archived:    data.Archived.ValueBool(),
+		Parameters: posthog.ExperimentParameters{
+			MinimumDetectableEffect: data.MinimumDetectableEffect.ValueInt64Pointer(),
+		},
+	}
+
+	if !data.Variants.IsUnknown() {
+		var variants []experimentVariant
+
+		diags.Append(data.Variants.ElementsAs(ctx, &variants, false)...)
+		if diags.HasError() {
+			return experiment, diags
+		}
+
+		for _, v := range variants {
+			experiment.Parameters.FeatureFlagVariants = append(experiment.Parameters.FeatureFlagVariants, posthog.ExperimentVariant{
+				Key:               v.Key.ValueString(),
+				RolloutPercentage: v.RolloutPercentage.ValueInt64(),
+			})
+		}
+	}
+
+	experiment.Metrics, d = metricsFromModel(ctx, data.Metrics)
+	diags.Append(d...)
+
+	experiment.MetricsSecondary, d = metricsFromModel(ctx, data.SecondaryMetrics)
+	diags.Append(d...)
+
+	if diags.HasError() {
+		return experiment, diags
+	}
+
+	if !data.StartDate.IsNull() {
+		startDate, err := time.Parse(time.RFC3339, data.StartDate.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("start_date"), "Invalid date", err.Error())
+			return experiment, diags
+		}
+
+		experiment.StartDate = &startDate
+	}
+
+	if !data.EndDate.IsNull() {
+		endDate, err := time.Parse(time.RFC3339, data.EndDate.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("end_date"), "Invalid date", err.Error())
+			return experiment, diags
+		}
+
+		experiment.EndDate = &endDate
+	}
+
+	if !data.HoldoutID.IsNull() {
+		var holdoutID posthog.ExperimentHoldoutID
+
+		holdoutID, err = posthog.ExperimentHoldoutIDFromString(data.HoldoutID.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("holdout_id"), "Invalid holdout ID", err.Error())
+			return experiment, diags
+		}
+
+		experiment.HoldoutID = &holdoutID
+	}
+
+	return experiment, diags
+}
+
+func (r *experimentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data experimentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	experiment, diags := experimentFieldsFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createExperimentRequest := posthog.CreateExperimentRequest{
+		Name:             experiment.Name,
+		Description:      experiment.Description,
+		FeatureFlagKey:   data.FeatureFlagKey.ValueString(),
+		Parameters:       experiment.Parameters,
+		Metrics:          experiment.Metrics,
+		MetricsSecondary: experiment.MetricsSecondary,
+		StartDate:        experiment.StartDate,
+		EndDate:          experiment.EndDate,
+		HoldoutID:        experiment.HoldoutID,
+		Archived:         experiment.Archived,
+	}
+
+	// Create the experiment
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	res, err := r.client.CreateExperiment(ctx, projectID, createExperimentRequest)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating experiment: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(updateExperimentModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created experiment", map[string]interface{}{"experiment_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *experimentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data experimentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	experimentID, err := posthog.ExperimentIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid experiment ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetExperiment(ctx, projectID, experimentID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting experiment %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(updateExperimentModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read experiment", map[string]interface{}{"experiment_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *experimentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data experimentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	experiment, diags := experimentFieldsFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	experiment.ID, err = posthog.ExperimentIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid experiment ID", err.Error())
+		return
+	}
+
+	res, err := r.client.UpdateExperiment(ctx, projectID, experiment)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating experiment %s: %s", experiment.ID, err))
+		return
+	}
+
+	resp.Diagnostics.Append(updateExperimentModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated experiment", map[string]interface{}{"experiment_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *experimentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data experimentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	experimentID, err := posthog.ExperimentIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid experiment ID", err.Error())
+		return
+	}
+
+	// The feature flag of the experiment is kept
+	if err := r.client.DeleteExperiment(ctx, projectID, experimentID); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting experiment %s: %s", data.ID, err))
+		return
+	}
+}
+
+func (r *experimentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, experimentID, err := parseImportID(req.ID, "experiment", posthog.ExperimentIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), experimentID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
@@ -0,0 +1,344 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+	"github.com/abustany/terraform-provider-posthog/internal/typeutil"
+)
+
+var _ datasource.DataSource = &featureFlagDataSource{}
+
+func newFeatureFlagDataSource() datasource.DataSource {
+	return &featureFlagDataSource{}
+}
+
+type featureFlagDataSource struct {
+	client *posthog.Client
+}
+
+type featureFlagDataSourceModel struct {
+	ID                         types.String `tfsdk:"id"`
+	ProjectID                  types.String `tfsdk:"project_id"`
+	Key                        types.String `tfsdk:"key"`
+	Name                       types.String `tfsdk:"name"`
+	Active                     types.Bool   `tfsdk:"active"`
+	ReleaseConditions          types.List   `tfsdk:"release_conditions"`
+	Variants                   types.List   `tfsdk:"variants"`
+	Payloads                   types.Map    `tfsdk:"payloads"`
+	AggregationGroupTypeIndex  types.Int64  `tfsdk:"aggregation_group_type_index"`
+	EnsureExperienceContinuity types.Bool   `tfsdk:"ensure_experience_continuity"`
+	Evaluate                   types.List   `tfsdk:"evaluate"`
+}
+
+type featureFlagEvaluation struct {
+	DistinctID       string            `tfsdk:"distinct_id"`
+	PersonProperties map[string]string `tfsdk:"person_properties"`
+	Enabled          types.Bool        `tfsdk:"enabled"`
+	Variant          types.String      `tfsdk:"variant"`
+	Payload          types.String      `tfsdk:"payload"`
+	Inconclusive     types.Bool        `tfsdk:"inconclusive"`
+}
+
+func (d *featureFlagDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_feature_flag"
+}
+
+// propertyFiltersDataSourceSchema is the read only version of
+// propertyFiltersSchema.
+func propertyFiltersDataSourceSchema(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: description,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"key": schema.StringAttribute{
+					MarkdownDescription: "Name of the property",
+					Computed:            true,
+				},
+				"type": schema.StringAttribute{
+					MarkdownDescription: "Type of the property",
+					Computed:            true,
+				},
+				"operator": schema.StringAttribute{
+					MarkdownDescription: "Comparison operator",
+					Computed:            true,
+				},
+				"values": schema.ListAttribute{
+					MarkdownDescription: "Values the property is compared against",
+					ElementType:         types.StringType,
+					Computed:            true,
+				},
+				"group_type_index": schema.Int64Attribute{
+					MarkdownDescription: "Index of the group type, for `group` properties",
+					Computed:            true,
+				},
+			},
+		},
+		Computed: true,
+	}
+}
+
+func featureFlagDataSourceReleaseConditionsSchema() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "Conditions under which the flag is enabled. A user matching any of the conditions gets the flag.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"properties": propertyFiltersDataSourceSchema("Properties the user must match for the condition to apply"),
+				"cohort_ids": schema.ListAttribute{
+					MarkdownDescription: "IDs of cohorts the user must belong to for the condition to apply",
+					ElementType:         types.StringType,
+					Computed:            true,
+				},
+				"rollout_percentage": schema.Float64Attribute{
+					MarkdownDescription: "Percentage of the matching users that get the flag, null means 100%",
+					Computed:            true,
+				},
+				"variant": schema.StringAttribute{
+					MarkdownDescription: "Key of the variant served to users matching this condition",
+					Computed:            true,
+				},
+			},
+		},
+		Computed: true,
+	}
+}
+
+func featureFlagDataSourceVariantsSchema() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "Variants of a multivariate flag",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"key": schema.StringAttribute{
+					MarkdownDescription: "Key of the variant",
+					Computed:            true,
+				},
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Description of the variant",
+					Computed:            true,
+				},
+				"rollout_percentage": schema.Int64Attribute{
+					MarkdownDescription: "Percentage of the users with the flag enabled that get this variant",
+					Computed:            true,
+				},
+				"payload": schema.StringAttribute{
+					MarkdownDescription: "JSON payload returned along with this variant",
+					Computed:            true,
+				},
+			},
+		},
+		Computed: true,
+	}
+}
+
+func featureFlagDataSourceEvaluateSchema() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "Evaluates the flag locally for the given users, without calling PostHog. " +
+			"Flags depending on cohorts, on properties that are not given or on relative dates cannot be evaluated locally and are reported as inconclusive.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"distinct_id": schema.StringAttribute{
+					MarkdownDescription: "Distinct ID of the user, or group key for flags aggregated by group",
+					Required:            true,
+				},
+				"person_properties": schema.MapAttribute{
+					MarkdownDescription: "Properties of the user",
+					ElementType:         types.StringType,
+					Optional:            true,
+				},
+				"enabled": schema.BoolAttribute{
+					MarkdownDescription: "Whether the flag is enabled for this user, null if inconclusive",
+					Computed:            true,
+				},
+				"variant": schema.StringAttribute{
+					MarkdownDescription: "Variant served to this user, null for boolean flags",
+					Computed:            true,
+				},
+				"payload": schema.StringAttribute{
+					MarkdownDescription: "Payload returned to this user",
+					Computed:            true,
+				},
+				"inconclusive": schema.BoolAttribute{
+					MarkdownDescription: "Whether the flag could not be evaluated locally",
+					Computed:            true,
+				},
+			},
+		},
+		Optional: true,
+	}
+}
+
+func (d *featureFlagDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a Posthog Feature Flag by key",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the feature flag",
+				Computed:            true,
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the feature flag",
+				Required:            true,
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "Key of the feature flag",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Description of the feature flag",
+				Computed:            true,
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the feature flag is enabled",
+				Computed:            true,
+			},
+			"release_conditions": featureFlagDataSourceReleaseConditionsSchema(),
+			"variants":           featureFlagDataSourceVariantsSchema(),
+			"payloads": schema.MapAttribute{
+				MarkdownDescription: "JSON payloads of the flag, keyed by variant key (or `true` for flags without variants)",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"aggregation_group_type_index": schema.Int64Attribute{
+				MarkdownDescription: "Index of the group type the flag is rolled out by, null if rolled out by user",
+				Computed:            true,
+			},
+			"ensure_experience_continuity": schema.BoolAttribute{
+				MarkdownDescription: "Whether the flag value is persisted when an anonymous user logs in",
+				Computed:            true,
+			},
+			"evaluate": featureFlagDataSourceEvaluateSchema(),
+		},
+	}
+}
+
+func (d *featureFlagDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func evaluateFeatureFlag(ctx context.Context, evaluations types.List, apiFlag *posthog.FeatureFlag) (types.List, diag.Diagnostics) {
+	var (
+		diags  diag.Diagnostics
+		inputs []featureFlagEvaluation
+	)
+
+	diags.Append(evaluations.ElementsAs(ctx, &inputs, true)...)
+	if diags.HasError() {
+		return evaluations, diags
+	}
+
+	for i := range inputs {
+		res, err := apiFlag.Evaluate(inputs[i].DistinctID, inputs[i].PersonProperties)
+		if errors.Is(err, posthog.ErrInconclusiveMatch) {
+			inputs[i].Enabled = types.BoolNull()
+			inputs[i].Variant = types.StringNull()
+			inputs[i].Payload = types.StringNull()
+			inputs[i].Inconclusive = types.BoolValue(true)
+			continue
+		} else if err != nil {
+			diags.AddAttributeError(path.Root("evaluate").AtListIndex(i), "Error evaluating feature flag", err.Error())
+			return evaluations, diags
+		}
+
+		inputs[i].Enabled = types.BoolValue(res.Enabled)
+		inputs[i].Variant = typeutil.NullableStringValue(res.Variant)
+		inputs[i].Payload = types.StringPointerValue(res.Payload)
+		inputs[i].Inconclusive = types.BoolValue(false)
+	}
+
+	if inputs == nil {
+		return types.ListNull(featureFlagDataSourceEvaluateSchema().NestedObject.Type()), diags
+	}
+
+	return types.ListValueFrom(ctx, featureFlagDataSourceEvaluateSchema().NestedObject.Type(), inputs)
+}
+
+func (d *featureFlagDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data featureFlagDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	res, err := d.client.GetFeatureFlagByKey(ctx, projectID, data.Key.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting feature flag %s: %s", data.Key, err))
+		return
+	}
+
+	if res == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("key"), "Feature flag not found", fmt.Sprintf("No feature flag with key %s in project %s.", data.Key, projectID))
+		return
+	}
+
+	var diags diag.Diagnostics
+
+	data.ID = types.StringValue(res.ID.String())
+	data.Name = types.StringValue(res.Name)
+	data.Active = types.BoolValue(res.Active)
+	data.AggregationGroupTypeIndex = types.Int64PointerValue(res.Filters.AggregationGroupTypeIndex)
+	data.EnsureExperienceContinuity = types.BoolValue(res.EnsureExperienceContinuity)
+
+	data.ReleaseConditions, diags = types.ListValueFrom(ctx, featureFlagDataSourceReleaseConditionsSchema().NestedObject.Type(), featureFlagReleaseConditionsToModel(res.Filters.Groups))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Variants, diags = types.ListValueFrom(ctx, featureFlagDataSourceVariantsSchema().NestedObject.Type(), featureFlagVariantsToModel(res.Filters))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Payloads, diags = types.MapValueFrom(ctx, types.StringType, map[string]string(res.Filters.Payloads))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Evaluate, diags = evaluateFeatureFlag(ctx, data.Evaluate, res)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read feature flag", map[string]interface{}{"feature_flag_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,672 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+var _ resource.Resource = &featureFlagResource{}
+var _ resource.ResourceWithImportState = &featureFlagResource{}
+var _ resource.ResourceWithValidateConfig = &featureFlagResource{}
+
+// booleanFlagPayloadKey is the key under which PostHog stores the payload of
+// flags that don't have variants.
+const booleanFlagPayloadKey = "true"
+
+func newFeatureFlagResource() resource.Resource {
+	return &featureFlagResource{}
+}
+
+type featureFlagResource struct {
+	client *posthog.Client
+}
+
+type featureFlagResourceModel struct {
+	ID                         types.String  `tfsdk:"id"`
+	ProjectID                  types.String  `tfsdk:"project_id"`
+	Key                        types.String  `tfsdk:"key"`
+	Name                       types.String  `tfsdk:"name"`
+	Active                     types.Bool    `tfsdk:"active"`
+	RolloutPercentage          types.Float64 `tfsdk:"rollout_percentage"`
+	ReleaseConditions          types.List    `tfsdk:"release_conditions"`
+	Variants                   types.List    `tfsdk:"variants"`
+	Payload                    types.String  `tfsdk:"payload"`
+	AggregationGroupTypeIndex  types.Int64   `tfsdk:"aggregation_group_type_index"`
+	EnsureExperienceContinuity types.Bool    `tfsdk:"ensure_experience_continuity"`
+}
+
+type featureFlagReleaseCondition struct {
+	Properties        []propertyFilter `tfsdk:"properties"`
+	CohortIDs         []string         `tfsdk:"cohort_ids"`
+	RolloutPercentage types.Float64    `tfsdk:"rollout_percentage"`
+	Variant           types.String     `tfsdk:"variant"`
+}
+
+type featureFlagVariant struct {
+	Key               types.String `tfsdk:"key"`
+	Name              types.String `tfsdk:"name"`
+	RolloutPercentage types.Int64  `tfsdk:"rollout_percentage"`
+	Payload           types.String `tfsdk:"payload"`
+}
+
+func (r *featureFlagResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_feature_flag"
+}
+
+func featureFlagReleaseConditionsSchema() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "Conditions under which the flag is enabled. A user matching any of the conditions gets the flag.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"properties": propertyFiltersSchema("Properties the user must match for the condition to apply. All properties must match.", posthog.PropertyFilterTypePerson),
+				"cohort_ids": schema.ListAttribute{
+					MarkdownDescription: "IDs of cohorts the user must belong to for the condition to apply",
+					ElementType:         types.StringType,
+					Optional:            true,
+				},
+				"rollout_percentage": schema.Float64Attribute{
+					MarkdownDescription: "Percentage of the matching users that get the flag. Defaults to 100% if unset.",
+					Optional:            true,
+					Validators: []validator.Float64{
+						float64validator.Between(0, 100),
+					},
+				},
+				"variant": schema.StringAttribute{
+					MarkdownDescription: "Key of the variant served to users matching this condition, overriding the variants rollout split",
+					Optional:            true,
+				},
+			},
+		},
+		Optional: true,
+	}
+}
+
+func featureFlagVariantsSchema() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "Variants of a multivariate flag. The rollout percentages of all variants must add up to 100.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"key": schema.StringAttribute{
+					MarkdownDescription: "Key of the variant, as returned when evaluating the flag",
+					Required:            true,
+				},
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Description of the variant",
+					Optional:            true,
+					Computed:            true,
+					Default:             stringdefault.StaticString(""),
+				},
+				"rollout_percentage": schema.Int64Attribute{
+					MarkdownDescription: "Percentage of the users with the flag enabled that get this variant",
+					Required:            true,
+					Validators: []validator.Int64{
+						int64validator.Between(0, 100),
+					},
+				},
+				"payload": schema.StringAttribute{
+					MarkdownDescription: "JSON payload returned along with this variant",
+					Optional:            true,
+				},
+			},
+		},
+		Optional: true,
+	}
+}
+
+func (r *featureFlagResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Feature Flag. The conditions added by PostHog when the flag is linked to an early access feature (see `posthog_early_access_feature`) are kept when updating the flag.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the feature flag",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the feature flag",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "Key of the feature flag, used to evaluate it in code",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Description of the feature flag",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the feature flag is enabled",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"rollout_percentage": schema.Float64Attribute{
+				MarkdownDescription: "Percentage of users that get the flag when no `release_conditions` are set. Defaults to 100% if unset.",
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.Between(0, 100),
+					float64validator.ConflictsWith(path.MatchRoot("release_conditions")),
+				},
+			},
+			"release_conditions": featureFlagReleaseConditionsSchema(),
+			"variants":           featureFlagVariantsSchema(),
+			"payload": schema.StringAttribute{
+				MarkdownDescription: "JSON payload returned along with the flag, for flags without variants",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("variants")),
+				},
+			},
+			"aggregation_group_type_index": schema.Int64Attribute{
+				MarkdownDescription: "Index of the group type to roll out the flag by (eg. per company instead of per user). Users are used if unset. Indexes can be looked up by name with the `posthog_group_type` data source.",
+				Optional:            true,
+			},
+			"ensure_experience_continuity": schema.BoolAttribute{
+				MarkdownDescription: "Whether to persist the flag value when an anonymous user logs in, at the expense of slower flag evaluation",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *featureFlagResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *featureFlagResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data featureFlagResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Payload.IsNull() && !data.Payload.IsUnknown() && !json.Valid([]byte(data.Payload.ValueString())) {
+		resp.Diagnostics.AddAttributeError(path.Root("payload"), "Invalid payload", "The payload must be valid JSON.")
+	}
+
+	if data.Variants.IsUnknown() || data.ReleaseConditions.IsUnknown() {
+		return
+	}
+
+	var variants []featureFlagVariant
+
+	resp.Diagnostics.Append(data.Variants.ElementsAs(ctx, &variants, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	variantKeys := map[string]bool{}
+	totalRollout := int64(0)
+	rolloutKnown := true
+
+	for i, v := range variants {
+		if v.Key.IsUnknown() || v.RolloutPercentage.IsUnknown() {
+			rolloutKnown = false
+			continue
+		}
+
+		if variantKeys[v.Key.ValueString()] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("variants").AtListIndex(i).AtName("key"),
+				"Duplicate variant key",
+				fmt.Sprintf("Variant key %q is used more than once.", v.Key.ValueString()),
+			)
+		}
+
+		variantKeys[v.Key.ValueString()] = true
+		totalRollout += v.RolloutPercentage.ValueInt64()
+
+		if !v.Payload.IsNull() && !v.Payload.IsUnknown() && !json.Valid([]byte(v.Payload.ValueString())) {
+			resp.Diagnostics.AddAttributeError(path.Root("variants").AtListIndex(i).AtName("payload"), "Invalid payload", "The payload must be valid JSON.")
+		}
+	}
+
+	if len(variants) > 0 && rolloutKnown && totalRollout != 100 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("variants"),
+			"Invalid variants rollout",
+			fmt.Sprintf("The rollout percentages of all variants must add up to 100, got %d.", totalRollout),
+		)
+	}
+
+	var conditions []featureFlagReleaseCondition
+
+	resp.Diagnostics.Append(data.ReleaseConditions.ElementsAs(ctx, &conditions, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, c := range conditions {
+		if c.Variant.IsNull() || c.Variant.IsUnknown() || !rolloutKnown {
+			continue
+		}
+
+		if !variantKeys[c.Variant.ValueString()] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("release_conditions").AtListIndex(i).AtName("variant"),
+				"Unknown variant",
+				fmt.Sprintf("Variant %q is not declared in the variants of the flag.", c.Variant.ValueString()),
+			)
+		}
+	}
+}
+
+func featureFlagReleaseConditionsToModel(groups []posthog.FeatureFlagGroup) []featureFlagReleaseCondition {
+	var conditions []featureFlagReleaseCondition
+
+	for _, g := range groups {
+		var (
+			properties []posthog.PropertyFilter
+			cohortIDs  []string
+		)
+
+		for _, p := range g.Properties {
+			if p.Type == posthog.PropertyFilterTypeCohort {
+				cohortIDs = append(cohortIDs, p.Value...)
+			} else {
+				properties = append(properties, p)
+			}
+		}
+
+		conditions = append(conditions, featureFlagReleaseCondition{
+			Properties:        propertyFiltersToModel(properties),
+			CohortIDs:         cohortIDs,
+			RolloutPercentage: types.Float64PointerValue(g.RolloutPercentage),
+			Variant:           types.StringPointerValue(g.Variant),
+		})
+	}
+
+	return conditions
+}
+
+func featureFlagVariantsToModel(filters posthog.FeatureFlagFilters) []featureFlagVariant {
+	if filters.Multivariate == nil {
+		return nil
+	}
+
+	var variants []featureFlagVariant
+
+	for _, v := range filters.Multivariate.Variants {
+		payload := types.StringNull()
+		if p, ok := filters.Payloads[v.Key]; ok {
+			payload = types.StringValue(p)
+		}
+
+		variants = append(variants, featureFlagVariant{
+			Key:               types.StringValue(v.Key),
+			Name:              types.StringValue(v.Name),
+			RolloutPercentage: types.Int64Value(v.RolloutPercentage),
+			Payload:           payload,
+		})
+	}
+
+	return variants
+}
+
+func updateFeatureFlagModel(ctx context.Context, model *featureFlagResourceModel, apiFlag *posthog.FeatureFlag) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(apiFlag.ID.String())
+	model.Key = types.StringValue(apiFlag.Key)
+	model.Name = types.StringValue(apiFlag.Name)
+	model.Active = types.BoolValue(apiFlag.Active)
+	model.EnsureExperienceContinuity = types.BoolValue(apiFlag.EnsureExperienceContinuity)
+	model.AggregationGroupTypeIndex = types.Int64PointerValue(apiFlag.Filters.AggregationGroupTypeIndex)
+
+	// A single condition without properties is what we send when only
+	// rollout_percentage is set, map it back to that attribute unless the
+	// configuration uses release_conditions.
+	groups := apiFlag.Filters.Groups
+	isSimpleRollout := len(groups) == 1 && len(groups[0].Properties) == 0 && groups[0].Variant == nil
+
+	if isSimpleRollout && model.ReleaseConditions.IsNull() {
+		model.RolloutPercentage = types.Float64PointerValue(groups[0].RolloutPercentage)
+		model.ReleaseConditions = types.ListNull(featureFlagReleaseConditionsSchema().NestedObject.Type())
+	} else {
+		model.RolloutPercentage = types.Float64Null()
+		model.ReleaseConditions, diags = types.ListValueFrom(ctx, featureFlagReleaseConditionsSchema().NestedObject.Type(), featureFlagReleaseConditionsToModel(groups))
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	variants := featureFlagVariantsToModel(apiFlag.Filters)
+
+	model.Variants, diags = types.ListValueFrom(ctx, featureFlagVariantsSchema().NestedObject.Type(), variants)
+	if diags.HasError() {
+		return diags
+	}
+
+	model.Payload = types.StringNull()
+	if p, ok := apiFlag.Filters.Payloads[booleanFlagPayloadKey]; ok && len(variants) == 0 {
+		model.Payload = types.StringValue(p)
+	}
+
+	return diags
+}
+
+func featureFlagFiltersFromModel(ctx context.Context, data featureFlagResourceModel) (posthog.FeatureFlagFilters, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	filters := posthog.FeatureFlagFilters{
+		Payloads:                  posthog.FeatureFlagPayloads{},
+		AggregationGroupTypeIndex: data.AggregationGroupTypeIndex.ValueInt64Pointer(),
+	}
+
+	// Decode release conditions
+
+	var conditions []featureFlagReleaseCondition
+
+	diags.Append(data.ReleaseConditions.ElementsAs(ctx, &conditions, true)...)
+	if diags.HasError() {
+		return posthog.FeatureFlagFilters{}, diags
+	}
+
+	if len(conditions) == 0 {
+		filters.Groups = []posthog.FeatureFlagGroup{{
+			RolloutPercentage: data.RolloutPercentage.ValueFloat64Pointer(),
+		}}
+	}
+
+	for _, c := range conditions {
+		properties := propertyFiltersFromModel(c.Properties)
+
+		for _, cohortID := range c.CohortIDs {
+			properties = append(properties, posthog.PropertyFilter{
+				Key:      "id",
+				Type:     posthog.PropertyFilterTypeCohort,
+				Operator: posthog.PropertyOperatorIn,
+				Value:    posthog.PropertyValue{cohortID},
+			})
+		}
+
+		filters.Groups = append(filters.Groups, posthog.FeatureFlagGroup{
+			Properties:        properties,
+			RolloutPercentage: c.RolloutPercentage.ValueFloat64Pointer(),
+			Variant:           c.Variant.ValueStringPointer(),
+		})
+	}
+
+	// Decode variants
+
+	var variants []featureFlagVariant
+
+	diags.Append(data.Variants.ElementsAs(ctx, &variants, false)...)
+	if diags.HasError() {
+		return posthog.FeatureFlagFilters{}, diags
+	}
+
+	if len(variants) > 0 {
+		filters.Multivariate = &posthog.FeatureFlagMultivariate{}
+	}
+
+	for _, v := range variants {
+		filters.Multivariate.Variants = append(filters.Multivariate.Variants, posthog.FeatureFlagVariant{
+			Key:               v.Key.ValueString(),
+			Name:              v.Name.ValueString(),
+			RolloutPercentage: v.RolloutPercentage.ValueInt64(),
+		})
+
+		if !v.Payload.IsNull() {
+			filters.Payloads[v.Key.ValueString()] = v.Payload.ValueString()
+		}
+	}
+
+	if !data.Payload.IsNull() {
+		filters.Payloads[booleanFlagPayloadKey] = data.Payload.ValueString()
+	}
+
+	return filters, diags
+}
+
+func (r *featureFlagResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data featureFlagResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filters, diags := featureFlagFiltersFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createFeatureFlagRequest := posthog.CreateFeatureFlagRequest{
+		Key:                        data.Key.ValueString(),
+		Name:                       data.Name.ValueString(),
+		Filters:                    filters,
+		Active:                     data.Active.ValueBool(),
+		EnsureExperienceContinuity: data.EnsureExperienceContinuity.ValueBool(),
+	}
+
+	// Create the feature flag
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	res, err := r.client.CreateFeatureFlag(ctx, projectID, createFeatureFlagRequest)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating feature flag: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(updateFeatureFlagModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created feature flag", map[string]interface{}{"feature_flag_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *featureFlagResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data featureFlagResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	flagID, err := posthog.FeatureFlagIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid feature flag ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetFeatureFlag(ctx, projectID, flagID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting feature flag %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil || res.Deleted {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(updateFeatureFlagModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read feature flag", map[string]interface{}{"feature_flag_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func featureFlagFromModel(ctx context.Context, data featureFlagResourceModel) (posthog.ProjectID, posthog.FeatureFlag, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		diags.AddError("Invalid project ID", err.Error())
+		return posthog.ProjectID(0), posthog.FeatureFlag{}, diags
+	}
+
+	flagID, err := posthog.FeatureFlagIDFromString(data.ID.ValueString())
+	if err != nil {
+		diags.AddError("Invalid feature flag ID", err.Error())
+		return posthog.ProjectID(0), posthog.FeatureFlag{}, diags
+	}
+
+	filters, diags := featureFlagFiltersFromModel(ctx, data)
+	if diags.HasError() {
+		return posthog.ProjectID(0), posthog.FeatureFlag{}, diags
+	}
+
+	flag := posthog.FeatureFlag{
+		ID:                         flagID,
+		Key:                        data.Key.ValueString(),
+		Name:                       data.Name.ValueString(),
+		Filters:                    filters,
+		Active:                     data.Active.ValueBool(),
+		EnsureExperienceContinuity: data.EnsureExperienceContinuity.ValueBool(),
+	}
+
+	return projectID, flag, diags
+}
+
+func (r *featureFlagResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data featureFlagResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, flag, diags := featureFlagFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := r.client.GetFeatureFlag(ctx, projectID, flag.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error reading feature flag %s: %s", flag.ID, err))
+		return
+	}
+
+	if current == nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Feature flag %s not found", flag.ID))
+		return
+	}
+
+	// The filters are replaced as a whole, keep the conditions added when the
+	// flag is linked to an early access feature
+	flag.Filters.SuperGroups = current.Filters.SuperGroups
+
+	res, err := r.client.UpdateFeatureFlag(ctx, projectID, flag)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating feature flag %s: %s", flag.ID, err))
+		return
+	}
+
+	resp.Diagnostics.Append(updateFeatureFlagModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated feature flag", map[string]interface{}{"feature_flag_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *featureFlagResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data featureFlagResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, flag, diags := featureFlagFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Feature flags are soft deleted, like in the web UI
+	flag.Deleted = true
+
+	_, err := r.client.UpdateFeatureFlag(ctx, projectID, flag)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting feature flag %s: %s", flag.ID, err))
+		return
+	}
+}
+
+func (r *featureFlagResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, flagID, err := parseImportID(req.ID, "feature flag", posthog.FeatureFlagIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), flagID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+var _ datasource.DataSource = &groupTypeDataSource{}
+
+func newGroupTypeDataSource() datasource.DataSource {
+	return &groupTypeDataSource{}
+}
+
+type groupTypeDataSource struct {
+	client *posthog.Client
+}
+
+func (d *groupTypeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_type"
+}
+
+func (d *groupTypeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a Posthog Group Type by name, for example to get the index to use in the `aggregation_group_type_index` of feature flags.",
+
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the group type",
+				Required:            true,
+			},
+			"group_type": schema.StringAttribute{
+				MarkdownDescription: "Name of the group type, as sent in events (eg. `company`)",
+				Required:            true,
+			},
+			"group_type_index": schema.Int64Attribute{
+				MarkdownDescription: "Index of the group type",
+				Computed:            true,
+			},
+			"name_singular": schema.StringAttribute{
+				MarkdownDescription: "Singular display name of the group type",
+				Computed:            true,
+			},
+			"name_plural": schema.StringAttribute{
+				MarkdownDescription: "Plural display name of the group type",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *groupTypeDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *groupTypeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data groupTypeResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	res, err := d.client.GetGroupTypeByName(ctx, projectID, data.GroupType.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting group type %s: %s", data.GroupType, err))
+		return
+	}
+
+	if res == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("group_type"), "Group type not found", fmt.Sprintf("No group type named %s in project %s.", data.GroupType, projectID))
+		return
+	}
+
+	updateGroupTypeModel(&data, res)
+
+	tflog.Trace(ctx, "read group type", map[string]interface{}{"group_type_index": res.GroupTypeIndex})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,262 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+	"github.com/abustany/terraform-provider-posthog/internal/typeutil"
+)
+
+var _ resource.Resource = &groupTypeResource{}
+var _ resource.ResourceWithImportState = &groupTypeResource{}
+
+func newGroupTypeResource() resource.Resource {
+	return &groupTypeResource{}
+}
+
+type groupTypeResource struct {
+	client *posthog.Client
+}
+
+type groupTypeResourceModel struct {
+	ProjectID      types.String `tfsdk:"project_id"`
+	GroupType      types.String `tfsdk:"group_type"`
+	GroupTypeIndex types.Int64  `tfsdk:"group_type_index"`
+	NameSingular   types.String `tfsdk:"name_singular"`
+	NamePlural     types.String `tfsdk:"name_plural"`
+}
+
+func (r *groupTypeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_type"
+}
+
+func (r *groupTypeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the display names of a Posthog Group Type. Group types are created by PostHog when the first event with a given group type is ingested: this resource adopts an existing group type, and resets its display names when destroyed.",
+
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the group type",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"group_type": schema.StringAttribute{
+				MarkdownDescription: "Name of the group type, as sent in events (eg. `company`)",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"group_type_index": schema.Int64Attribute{
+				MarkdownDescription: "Index of the group type, used for example in the `aggregation_group_type_index` of feature flags",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name_singular": schema.StringAttribute{
+				MarkdownDescription: "Singular display name of the group type (eg. `Company`)",
+				Optional:            true,
+			},
+			"name_plural": schema.StringAttribute{
+				MarkdownDescription: "Plural display name of the group type (eg. `Companies`)",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *groupTypeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func updateGroupTypeModel(model *groupTypeResourceModel, apiGroupType *posthog.GroupType) {
+	model.GroupType = types.StringValue(apiGroupType.GroupType)
+	model.GroupTypeIndex = types.Int64Value(apiGroupType.GroupTypeIndex)
+	model.NameSingular = typeutil.NullableStringPointerValue(apiGroupType.NameSingular)
+	model.NamePlural = typeutil.NullableStringPointerValue(apiGroupType.NamePlural)
+}
+
+// updateMetadata sets the display names of the group type in the model,
+// whose index must be known.
+func (r *groupTypeResource) updateMetadata(ctx context.Context, projectID posthog.ProjectID, data *groupTypeResourceModel) error {
+	res, err := r.client.UpdateGroupTypeMetadata(ctx, projectID, posthog.GroupType{
+		GroupType:      data.GroupType.ValueString(),
+		GroupTypeIndex: data.GroupTypeIndex.ValueInt64(),
+		NameSingular:   data.NameSingular.ValueStringPointer(),
+		NamePlural:     data.NamePlural.ValueStringPointer(),
+	})
+	if err != nil {
+		return err
+	}
+
+	updateGroupTypeModel(data, res)
+
+	return nil
+}
+
+func (r *groupTypeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data groupTypeResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	// Adopt the existing group type
+
+	groupType, err := r.client.GetGroupTypeByName(ctx, projectID, data.GroupType.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error looking up group type %s: %s", data.GroupType, err))
+		return
+	}
+
+	if groupType == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("group_type"), "Group type not found", fmt.Sprintf("No event with the group type %s was ingested in project %s yet, the group type does not exist.", data.GroupType, projectID))
+		return
+	}
+
+	data.GroupTypeIndex = types.Int64Value(groupType.GroupTypeIndex)
+
+	if err := r.updateMetadata(ctx, projectID, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating group type %s: %s", data.GroupType, err))
+		return
+	}
+
+	tflog.Trace(ctx, "adopted group type", map[string]interface{}{"group_type_index": groupType.GroupTypeIndex})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *groupTypeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data groupTypeResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetGroupTypeByName(ctx, projectID, data.GroupType.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting group type %s: %s", data.GroupType, err))
+		return
+	}
+
+	if res == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	updateGroupTypeModel(&data, res)
+
+	tflog.Trace(ctx, "read group type", map[string]interface{}{"group_type_index": res.GroupTypeIndex})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *groupTypeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data groupTypeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	if err := r.updateMetadata(ctx, projectID, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating group type %s: %s", data.GroupType, err))
+		return
+	}
+
+	tflog.Trace(ctx, "updated group type", map[string]interface{}{"group_type_index": data.GroupTypeIndex.ValueInt64()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *groupTypeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data groupTypeResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	// The group type belongs to PostHog, only reset its display names
+	data.NameSingular = types.StringNull()
+	data.NamePlural = types.StringNull()
+
+	if err := r.updateMetadata(ctx, projectID, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error resetting group type %s: %s", data.GroupType, err))
+		return
+	}
+}
+
+func (r *groupTypeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, groupType, err := parseImportID(req.ID, "group type", func(s string) (string, error) { return s, nil })
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_type"), groupType)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
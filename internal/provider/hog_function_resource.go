@@ -0,0 +1,760 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+	"github.com/abustany/terraform-provider-posthog/internal/typeutil"
+)
+
+var _ resource.Resource = &hogFunctionResource{}
+var _ resource.ResourceWithImportState = &hogFunctionResource{}
+var _ resource.ResourceWithValidateConfig = &hogFunctionResource{}
+
+func newHogFunctionResource() resource.Resource {
+	return &hogFunctionResource{}
+}
+
+type hogFunctionResource struct {
+	client *posthog.Client
+}
+
+type hogFunctionResourceModel struct {
+	ID              types.String        `tfsdk:"id"`
+	ProjectID       types.String        `tfsdk:"project_id"`
+	Type            types.String        `tfsdk:"type"`
+	Name            types.String        `tfsdk:"name"`
+	Description     types.String        `tfsdk:"description"`
+	Enabled         types.Bool          `tfsdk:"enabled"`
+	TemplateID      types.String        `tfsdk:"template_id"`
+	Hog             types.String        `tfsdk:"hog"`
+	InputsSchema    types.List          `tfsdk:"inputs_schema"`
+	Inputs          types.Map           `tfsdk:"inputs"`
+	SensitiveInputs types.Map           `tfsdk:"sensitive_inputs"`
+	Filters         *hogFunctionFilters `tfsdk:"filters"`
+	Masking         *hogFunctionMasking `tfsdk:"masking"`
+	ExecutionOrder  types.Int64         `tfsdk:"execution_order"`
+}
+
+type hogFunctionInputSchema struct {
+	Key         string               `tfsdk:"key"`
+	Type        string               `tfsdk:"type"`
+	Label       types.String         `tfsdk:"label"`
+	Description types.String         `tfsdk:"description"`
+	Required    bool                 `tfsdk:"required"`
+	Secret      bool                 `tfsdk:"secret"`
+	Default     jsontypes.Normalized `tfsdk:"default"`
+}
+
+type hogFunctionFilters struct {
+	Events             []eventFilter    `tfsdk:"events"`
+	Actions            []actionFilter   `tfsdk:"actions"`
+	Properties         []propertyFilter `tfsdk:"properties"`
+	FilterTestAccounts types.Bool       `tfsdk:"filter_test_accounts"`
+}
+
+type hogFunctionMasking struct {
+	TTL       types.Int64  `tfsdk:"ttl"`
+	Threshold types.Int64  `tfsdk:"threshold"`
+	Hash      types.String `tfsdk:"hash"`
+}
+
+var hogFunctionInputTypes = []string{
+	"string",
+	"boolean",
+	"dictionary",
+	"choice",
+	"json",
+	"integration",
+	"integration_field",
+	"email",
+}
+
+func (r *hogFunctionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hog_function"
+}
+
+func hogFunctionInputsSchemaAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "Inputs of the Hog function, available in the source code as `inputs.KEY`",
+		Optional:            true,
+		Computed:            true,
+		PlanModifiers: []planmodifier.List{
+			listplanmodifier.UseStateForUnknown(),
+		},
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"key": schema.StringAttribute{
+					MarkdownDescription: "Key of the input",
+					Required:            true,
+				},
+				"type": schema.StringAttribute{
+					MarkdownDescription: "Type of the input, for example `string`, `boolean`, `dictionary`, `json` or `integration`",
+					Required:            true,
+					Validators: []validator.String{
+						stringvalidator.OneOf(hogFunctionInputTypes...),
+					},
+				},
+				"label": schema.StringAttribute{
+					MarkdownDescription: "Label of the input in the web UI",
+					Optional:            true,
+				},
+				"description": schema.StringAttribute{
+					MarkdownDescription: "Description of the input in the web UI",
+					Optional:            true,
+				},
+				"required": schema.BoolAttribute{
+					MarkdownDescription: "Whether the input must be set",
+					Optional:            true,
+					Computed:            true,
+					Default:             booldefault.StaticBool(false),
+				},
+				"secret": schema.BoolAttribute{
+					MarkdownDescription: "Whether the input is secret. Secret inputs must be set in `sensitive_inputs`.",
+					Optional:            true,
+					Computed:            true,
+					Default:             booldefault.StaticBool(false),
+				},
+				"default": schema.StringAttribute{
+					MarkdownDescription: "Default value of the input, as JSON",
+					CustomType:          jsontypes.NormalizedType{},
+					Optional:            true,
+				},
+			},
+		},
+	}
+}
+
+func (r *hogFunctionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Hog function, the CDP destinations and transformations run on incoming events",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the Hog function",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the Hog function",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of the Hog function: `destination` (sends events to other services), `transformation` (modifies events before they are ingested) or `site_destination` (runs in the browser)",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(posthog.HogFunctionTypeDestination),
+						string(posthog.HogFunctionTypeTransformation),
+						string(posthog.HogFunctionTypeSiteDestination),
+					),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the Hog function",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the Hog function",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the Hog function runs on incoming events",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"template_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the template the Hog function is created from (eg. `template-slack`). The source code and inputs schema of the template are used if `hog` and `inputs_schema` are not set.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"hog": schema.StringAttribute{
+				MarkdownDescription: "Source code of the Hog function",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"inputs_schema": hogFunctionInputsSchemaAttribute(),
+			"inputs": schema.MapAttribute{
+				MarkdownDescription: "Values of the inputs, as JSON (eg. `jsonencode(\"https://example.com\")`)",
+				ElementType:         jsontypes.NormalizedType{},
+				Optional:            true,
+				Computed:            true,
+				Default:             mapdefault.StaticValue(types.MapValueMust(jsontypes.NormalizedType{}, map[string]attr.Value{})),
+			},
+			"sensitive_inputs": schema.MapAttribute{
+				MarkdownDescription: "Values of the secret inputs, as JSON. PostHog never returns those values, changes made outside of Terraform are not detected.",
+				ElementType:         jsontypes.NormalizedType{},
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"filters": schema.SingleNestedAttribute{
+				MarkdownDescription: "Events the Hog function runs on: events matching any of `events` or `actions`, and all of `properties`. The function runs on all events if unset.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"events":     eventFiltersSchema("Events the Hog function runs on"),
+					"actions":    actionFiltersSchema("Actions the Hog function runs on"),
+					"properties": propertyFiltersSchema("Filters applied to all the events", posthog.PropertyFilterTypeEvent),
+					"filter_test_accounts": schema.BoolAttribute{
+						MarkdownDescription: "Whether to ignore the events of internal and test users",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+					},
+				},
+			},
+			"masking": schema.SingleNestedAttribute{
+				MarkdownDescription: "Limits how often the Hog function runs: it runs at most once per `ttl` for events with the same value of `hash`",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"ttl": schema.Int64Attribute{
+						MarkdownDescription: "Duration during which events with the same hash are ignored, in seconds",
+						Required:            true,
+						Validators: []validator.Int64{
+							int64validator.Between(60, 86400*365),
+						},
+					},
+					"threshold": schema.Int64Attribute{
+						MarkdownDescription: "Number of events after which the Hog function runs again for the same hash, even if the TTL did not expire",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"hash": schema.StringAttribute{
+						MarkdownDescription: "Hog expression computing the hash of an event, for example `{person.id}`",
+						Required:            true,
+					},
+				},
+			},
+			"execution_order": schema.Int64Attribute{
+				MarkdownDescription: "Position of the transformation in the list of transformations run on events",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *hogFunctionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *hogFunctionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data hogFunctionResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Hog.IsNull() && data.TemplateID.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("hog"), "Missing source code", "Either hog or template_id must be set.")
+	}
+
+	if !data.ExecutionOrder.IsNull() && !data.Type.IsUnknown() && data.Type.ValueString() != string(posthog.HogFunctionTypeTransformation) {
+		resp.Diagnostics.AddAttributeError(path.Root("execution_order"), "Invalid Hog function", "Only transformations have an execution order.")
+	}
+
+	if data.Inputs.IsUnknown() || data.SensitiveInputs.IsUnknown() {
+		return
+	}
+
+	for key := range data.SensitiveInputs.Elements() {
+		if _, ok := data.Inputs.Elements()[key]; ok {
+			resp.Diagnostics.AddAttributeError(path.Root("sensitive_inputs").AtMapKey(key), "Duplicate input", fmt.Sprintf("Input %s is set in both inputs and sensitive_inputs.", key))
+		}
+	}
+
+	if data.InputsSchema.IsNull() || data.InputsSchema.IsUnknown() {
+		return
+	}
+
+	var inputsSchema []hogFunctionInputSchema
+
+	resp.Diagnostics.Append(data.InputsSchema.ElementsAs(ctx, &inputsSchema, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secret := map[string]bool{}
+	for _, s := range inputsSchema {
+		secret[s.Key] = s.Secret
+	}
+
+	for _, attribute := range []struct {
+		name  string
+		value types.Map
+	}{
+		{"inputs", data.Inputs},
+		{"sensitive_inputs", data.SensitiveInputs},
+	} {
+		for key := range attribute.value.Elements() {
+			isSecret, ok := secret[key]
+
+			switch {
+			case !ok:
+				resp.Diagnostics.AddAttributeError(path.Root(attribute.name).AtMapKey(key), "Unknown input", fmt.Sprintf("Input %s is not in inputs_schema.", key))
+			case isSecret && attribute.name == "inputs":
+				resp.Diagnostics.AddAttributeError(path.Root(attribute.name).AtMapKey(key), "Secret input", fmt.Sprintf("Input %s is secret, it must be set in sensitive_inputs.", key))
+			}
+		}
+	}
+}
+
+func hogFunctionInputsSchemaToModel(ctx context.Context, inputsSchema []posthog.HogFunctionInputSchema) (types.List, diag.Diagnostics) {
+	var res []hogFunctionInputSchema
+
+	for _, s := range inputsSchema {
+		model := hogFunctionInputSchema{
+			Key:         s.Key,
+			Type:        s.Type,
+			Label:       typeutil.NullableStringValue(s.Label),
+			Description: typeutil.NullableStringValue(s.Description),
+			Required:    s.Required,
+			Secret:      s.Secret,
+			Default:     jsontypes.NewNormalizedNull(),
+		}
+
+		if len(s.Default) > 0 && string(s.Default) != "null" {
+			model.Default = jsontypes.NewNormalizedValue(string(s.Default))
+		}
+
+		res = append(res, model)
+	}
+
+	return types.ListValueFrom(ctx, hogFunctionInputsSchemaAttribute().NestedObject.Type(), res)
+}
+
+func hogFunctionFiltersToModel(f *posthog.HogFunctionFilters) *hogFunctionFilters {
+	if f == nil || (len(f.Events) == 0 && len(f.Actions) == 0 && len(f.Properties) == 0 && !f.FilterTestAccounts) {
+		return nil
+	}
+
+	res := &hogFunctionFilters{
+		Properties:         propertyFiltersToModel(f.Properties),
+		FilterTestAccounts: types.BoolValue(f.FilterTestAccounts),
+	}
+
+	res.Events, res.Actions = entityFiltersToModel(append(append([]posthog.EntityFilter(nil), f.Events...), f.Actions...))
+
+	return res
+}
+
+func updateHogFunctionModel(ctx context.Context, model *hogFunctionResourceModel, apiHogFunction *posthog.HogFunction) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+
+	model.ID = types.StringValue(apiHogFunction.ID.String())
+	model.Type = types.StringValue(string(apiHogFunction.Type))
+	model.Name = types.StringValue(apiHogFunction.Name)
+	model.Description = types.StringValue(apiHogFunction.Description)
+	model.Enabled = types.BoolValue(apiHogFunction.Enabled)
+	model.TemplateID = typeutil.NullableStringValue(apiHogFunction.TemplateID)
+	model.Hog = types.StringValue(apiHogFunction.Hog)
+	model.ExecutionOrder = types.Int64PointerValue(apiHogFunction.ExecutionOrder)
+
+	model.InputsSchema, d = hogFunctionInputsSchemaToModel(ctx, apiHogFunction.InputsSchema)
+	diags.Append(d...)
+
+	// Secret values are not returned by the API, sensitive inputs are kept
+	// from the state.
+	inputs := map[string]jsontypes.Normalized{}
+	for key, input := range apiHogFunction.Inputs {
+		if _, ok := model.SensitiveInputs.Elements()[key]; ok || input.Secret || !input.HasValue() {
+			continue
+		}
+
+		inputs[key] = jsontypes.NewNormalizedValue(string(input.Value))
+	}
+
+	model.Inputs, d = types.MapValueFrom(ctx, jsontypes.NormalizedType{}, inputs)
+	diags.Append(d...)
+
+	model.Filters = hogFunctionFiltersToModel(apiHogFunction.Filters)
+
+	model.Masking = nil
+
+	if m := apiHogFunction.Masking; m != nil {
+		model.Masking = &hogFunctionMasking{
+			TTL:       types.Int64Value(m.TTL),
+			Threshold: types.Int64PointerValue(m.Threshold),
+			Hash:      types.StringValue(m.Hash),
+		}
+	}
+
+	return diags
+}
+
+func hogFunctionInputsFromModel(ctx context.Context, data hogFunctionResourceModel) (map[string]posthog.HogFunctionInput, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	res := map[string]posthog.HogFunctionInput{}
+
+	for _, m := range []types.Map{data.Inputs, data.SensitiveInputs} {
+		if m.IsNull() {
+			continue
+		}
+
+		var values map[string]jsontypes.Normalized
+
+		diags.Append(m.ElementsAs(ctx, &values, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		for key, value := range values {
+			res[key] = posthog.HogFunctionInput{Value: json.RawMessage(value.ValueString())}
+		}
+	}
+
+	if data.InputsSchema.IsNull() || data.InputsSchema.IsUnknown() {
+		return res, diags
+	}
+
+	var inputsSchema []hogFunctionInputSchema
+
+	diags.Append(data.InputsSchema.ElementsAs(ctx, &inputsSchema, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// Secret inputs that are not in the configuration (eg. after an import)
+	// keep their current value.
+	for _, s := range inputsSchema {
+		if _, ok := res[s.Key]; s.Secret && !ok {
+			res[s.Key] = posthog.HogFunctionInput{Secret: true}
+		}
+	}
+
+	return res, diags
+}
+
+func hogFunctionInputsSchemaFromModel(ctx context.Context, inputsSchema types.List) ([]posthog.HogFunctionInputSchema, diag.Diagnostics) {
+	var (
+		models []hogFunctionInputSchema
+		res    []posthog.HogFunctionInputSchema
+	)
+
+	diags := inputsSchema.ElementsAs(ctx, &models, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	for _, s := range models {
+		apiSchema := posthog.HogFunctionInputSchema{
+			Key:         s.Key,
+			Type:        s.Type,
+			Label:       s.Label.ValueString(),
+			Description: s.Description.ValueString(),
+			Required:    s.Required,
+			Secret:      s.Secret,
+		}
+
+		if !s.Default.IsNull() {
+			apiSchema.Default = json.RawMessage(s.Default.ValueString())
+		}
+
+		res = append(res, apiSchema)
+	}
+
+	return res, diags
+}
+
+func hogFunctionFiltersFromModel(filters *hogFunctionFilters) *posthog.HogFunctionFilters {
+	if filters == nil {
+		return nil
+	}
+
+	res := posthog.HogFunctionFilters{
+		Properties:         propertyFiltersFromModel(filters.Properties),
+		FilterTestAccounts: filters.FilterTestAccounts.ValueBool(),
+	}
+
+	res.Events, res.Actions = entityFiltersFromModel(filters.Events, filters.Actions)
+
+	return &res
+}
+
+// hogFunctionFromModel converts the model to a Hog function. The inputs
+// schema and the source code are only set if they are known, to let PostHog
+// copy them from the template otherwise.
+func hogFunctionFromModel(ctx context.Context, data hogFunctionResourceModel) (posthog.ProjectID, posthog.HogFunction, diag.Diagnostics) {
+	var (
+		diags       diag.Diagnostics
+		d           diag.Diagnostics
+		err         error
+		projectID   posthog.ProjectID
+		hogFunction posthog.HogFunction
+	)
+
+	projectID, err = posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("project_id"), "Invalid project ID", err.Error())
+		return projectID, hogFunction, diags
+	}
+
+	if !data.ID.IsUnknown() {
+		hogFunction.ID, err = posthog.HogFunctionIDFromString(data.ID.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("id"), "Invalid Hog function ID", err.Error())
+			return projectID, hogFunction, diags
+		}
+	}
+
+	hogFunction.Type = posthog.HogFunctionType(data.Type.ValueString())
+	hogFunction.Name = data.Name.ValueString()
+	hogFunction.Description = data.Description.ValueString()
+	hogFunction.Enabled = data.Enabled.ValueBool()
+	hogFunction.TemplateID = data.TemplateID.ValueString()
+	hogFunction.Filters = hogFunctionFiltersFromModel(data.Filters)
+
+	if !data.Hog.IsUnknown() {
+		hogFunction.Hog = data.Hog.ValueString()
+	}
+
+	if !data.InputsSchema.IsUnknown() && !data.InputsSchema.IsNull() {
+		hogFunction.InputsSchema, d = hogFunctionInputsSchemaFromModel(ctx, data.InputsSchema)
+		diags.Append(d...)
+	}
+
+	hogFunction.Inputs, d = hogFunctionInputsFromModel(ctx, data)
+	diags.Append(d...)
+
+	if data.Masking != nil {
+		hogFunction.Masking = &posthog.HogFunctionMasking{
+			TTL:       data.Masking.TTL.ValueInt64(),
+			Threshold: data.Masking.Threshold.ValueInt64Pointer(),
+			Hash:      data.Masking.Hash.ValueString(),
+		}
+	}
+
+	if !data.ExecutionOrder.IsUnknown() {
+		hogFunction.ExecutionOrder = data.ExecutionOrder.ValueInt64Pointer()
+	}
+
+	return projectID, hogFunction, diags
+}
+
+func (r *hogFunctionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data hogFunctionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, hogFunction, diags := hogFunctionFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createHogFunctionRequest := posthog.CreateHogFunctionRequest{
+		Type:           hogFunction.Type,
+		Name:           hogFunction.Name,
+		Description:    hogFunction.Description,
+		Enabled:        hogFunction.Enabled,
+		TemplateID:     hogFunction.TemplateID,
+		Hog:            hogFunction.Hog,
+		InputsSchema:   hogFunction.InputsSchema,
+		Inputs:         hogFunction.Inputs,
+		Filters:        hogFunction.Filters,
+		Masking:        hogFunction.Masking,
+		ExecutionOrder: hogFunction.ExecutionOrder,
+	}
+
+	// Create the Hog function
+
+	res, err := r.client.CreateHogFunction(ctx, projectID, createHogFunctionRequest)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating Hog function: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(updateHogFunctionModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created Hog function", map[string]interface{}{"hog_function_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *hogFunctionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data hogFunctionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	hogFunctionID, err := posthog.HogFunctionIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Hog function ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetHogFunction(ctx, projectID, hogFunctionID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting Hog function %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil || res.Deleted {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(updateHogFunctionModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read Hog function", map[string]interface{}{"hog_function_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *hogFunctionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state hogFunctionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, hogFunction, diags := hogFunctionFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The state only holds the attributes of the inputs schema known by the
+	// provider, only send it if it changed to keep the other ones (eg. the
+	// choices of a template input).
+	if data.InputsSchema.Equal(state.InputsSchema) {
+		hogFunction.InputsSchema = nil
+	}
+
+	res, err := r.client.UpdateHogFunction(ctx, projectID, hogFunction)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating Hog function %s: %s", hogFunction.ID, err))
+		return
+	}
+
+	resp.Diagnostics.Append(updateHogFunctionModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated Hog function", map[string]interface{}{"hog_function_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *hogFunctionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data hogFunctionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, hogFunction, diags := hogFunctionFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Hog functions are soft deleted, like in the web UI
+	hogFunction.InputsSchema = nil
+	hogFunction.Deleted = true
+
+	_, err := r.client.UpdateHogFunction(ctx, projectID, hogFunction)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting Hog function %s: %s", hogFunction.ID, err))
+		return
+	}
+}
+
+func (r *hogFunctionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, hogFunctionID, err := parseImportID(req.ID, "Hog function", posthog.HogFunctionIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), hogFunctionID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
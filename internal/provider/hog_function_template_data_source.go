@@ -0,0 +1,220 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+var _ datasource.DataSource = &hogFunctionTemplateDataSource{}
+
+func newHogFunctionTemplateDataSource() datasource.DataSource {
+	return &hogFunctionTemplateDataSource{}
+}
+
+type hogFunctionTemplateDataSource struct {
+	client *posthog.Client
+}
+
+type hogFunctionTemplateDataSourceModel struct {
+	ID           types.String        `tfsdk:"id"`
+	ProjectID    types.String        `tfsdk:"project_id"`
+	Name         types.String        `tfsdk:"name"`
+	Description  types.String        `tfsdk:"description"`
+	Type         types.String        `tfsdk:"type"`
+	Status       types.String        `tfsdk:"status"`
+	Hog          types.String        `tfsdk:"hog"`
+	InputsSchema types.List          `tfsdk:"inputs_schema"`
+	Filters      *hogFunctionFilters `tfsdk:"filters"`
+}
+
+func (d *hogFunctionTemplateDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hog_function_template"
+}
+
+func (d *hogFunctionTemplateDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a Posthog Hog function template by ID. The attributes match the ones of `posthog_hog_function`, so that they can be used as a starting point for Hog functions.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the template, for example `template-slack`",
+				Required:            true,
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project the template is looked up in",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the template",
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the template",
+				Computed:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of the Hog functions created from the template, for example `destination` or `transformation`",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Maturity of the template, for example `stable`, `beta` or `alpha`",
+				Computed:            true,
+			},
+			"hog": schema.StringAttribute{
+				MarkdownDescription: "Source code of the template",
+				Computed:            true,
+			},
+			"inputs_schema": schema.ListNestedAttribute{
+				MarkdownDescription: "Inputs expected by the template",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							MarkdownDescription: "Key of the input",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Type of the input, for example `string`, `boolean`, `dictionary`, `json` or `integration`",
+							Computed:            true,
+						},
+						"label": schema.StringAttribute{
+							MarkdownDescription: "Label of the input in the web UI",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Description of the input in the web UI",
+							Computed:            true,
+						},
+						"required": schema.BoolAttribute{
+							MarkdownDescription: "Whether the input must be set",
+							Computed:            true,
+						},
+						"secret": schema.BoolAttribute{
+							MarkdownDescription: "Whether the input is secret, and must be set in `sensitive_inputs`",
+							Computed:            true,
+						},
+						"default": schema.StringAttribute{
+							MarkdownDescription: "Default value of the input, as JSON",
+							CustomType:          jsontypes.NormalizedType{},
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"filters": schema.SingleNestedAttribute{
+				MarkdownDescription: "Default filters of the Hog functions created from the template, null if they run on all events",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"events": schema.ListNestedAttribute{
+						MarkdownDescription: "Events the Hog function runs on",
+						Computed:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"event": schema.StringAttribute{
+									MarkdownDescription: "Name of the event",
+									Computed:            true,
+								},
+								"properties": propertyFiltersDataSourceSchema("Filters applied to the event"),
+							},
+						},
+					},
+					"actions": schema.ListNestedAttribute{
+						MarkdownDescription: "Actions the Hog function runs on",
+						Computed:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"action_id": schema.StringAttribute{
+									MarkdownDescription: "ID of the action",
+									Computed:            true,
+								},
+								"properties": propertyFiltersDataSourceSchema("Filters applied to the events matching the action"),
+							},
+						},
+					},
+					"properties": propertyFiltersDataSourceSchema("Filters applied to all the events"),
+					"filter_test_accounts": schema.BoolAttribute{
+						MarkdownDescription: "Whether to ignore the events of internal and test users",
+						Computed:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *hogFunctionTemplateDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *hogFunctionTemplateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data hogFunctionTemplateDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	res, err := d.client.GetHogFunctionTemplate(ctx, projectID, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting Hog function template %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("id"), "Hog function template not found", fmt.Sprintf("No Hog function template with ID %s.", data.ID))
+		return
+	}
+
+	data.Name = types.StringValue(res.Name)
+	data.Description = types.StringValue(res.Description)
+	data.Type = types.StringValue(string(res.Type))
+	data.Status = types.StringValue(res.Status)
+	data.Hog = types.StringValue(res.Hog)
+	data.Filters = hogFunctionFiltersToModel(res.Filters)
+
+	inputsSchema, diags := hogFunctionInputsSchemaToModel(ctx, res.InputsSchema)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.InputsSchema = inputsSchema
+
+	tflog.Trace(ctx, "read Hog function template", map[string]interface{}{"hog_function_template_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+// parseImportID parses import IDs of the form PROJECT_ID/OBJECT_ID, where
+// objectName is the human readable name of the imported object (eg.
+// "feature flag") and parseObjectID parses its ID.
+func parseImportID[T any](s string, objectName string, parseObjectID func(string) (T, error)) (posthog.ProjectID, T, error) {
+	var zero T
+
+	tokens := strings.SplitN(s, "/", 2)
+	if len(tokens) != 2 {
+		return posthog.ProjectID(0), zero, fmt.Errorf("ID not of the form PROJECT_ID/%s_ID", strings.ToUpper(strings.ReplaceAll(objectName, " ", "_")))
+	}
+
+	projectID, err := posthog.ProjectIDFromString(tokens[0])
+	if err != nil {
+		return posthog.ProjectID(0), zero, fmt.Errorf("invalid project ID: %w", err)
+	}
+
+	objectID, err := parseObjectID(tokens[1])
+	if err != nil {
+		return posthog.ProjectID(0), zero, fmt.Errorf("invalid %s ID: %w", objectName, err)
+	}
+
+	return projectID, objectID, nil
+}
+
+// parseOrganizationImportID parses import IDs of the form
+// ORGANIZATION_ID/OBJECT_ID, for objects that belong to an organization
+// rather than to a project.
+func parseOrganizationImportID[T any](s string, objectName string, parseObjectID func(string) (T, error)) (posthog.OrganizationID, T, error) {
+	var zero T
+
+	tokens := strings.SplitN(s, "/", 2)
+	if len(tokens) != 2 {
+		return "", zero, fmt.Errorf("ID not of the form ORGANIZATION_ID/%s_ID", strings.ToUpper(strings.ReplaceAll(objectName, " ", "_")))
+	}
+
+	organizationID, err := posthog.OrganizationIDFromString(tokens[0])
+	if err != nil {
+		return "", zero, fmt.Errorf("invalid organization ID: %w", err)
+	}
+
+	objectID, err := parseObjectID(tokens[1])
+	if err != nil {
+		return "", zero, fmt.Errorf("invalid %s ID: %w", objectName, err)
+	}
+
+	return organizationID, objectID, nil
+}
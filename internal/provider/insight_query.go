@@ -0,0 +1,673 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+	"github.com/abustany/terraform-provider-posthog/internal/typeutil"
+)
+
+type insightSeries struct {
+	Event        types.String     `tfsdk:"event"`
+	ActionID     types.String     `tfsdk:"action_id"`
+	CustomName   types.String     `tfsdk:"custom_name"`
+	Math         types.String     `tfsdk:"math"`
+	MathProperty types.String     `tfsdk:"math_property"`
+	Properties   []propertyFilter `tfsdk:"properties"`
+}
+
+type insightEntity struct {
+	Event    types.String `tfsdk:"event"`
+	ActionID types.String `tfsdk:"action_id"`
+}
+
+type insightBreakdown struct {
+	Property string `tfsdk:"property"`
+	Type     string `tfsdk:"type"`
+}
+
+type insightTrendsQuery struct {
+	Series             []insightSeries   `tfsdk:"series"`
+	Interval           types.String      `tfsdk:"interval"`
+	DateFrom           types.String      `tfsdk:"date_from"`
+	DateTo             types.String      `tfsdk:"date_to"`
+	Properties         []propertyFilter  `tfsdk:"properties"`
+	FilterTestAccounts types.Bool        `tfsdk:"filter_test_accounts"`
+	Breakdown          *insightBreakdown `tfsdk:"breakdown"`
+	Display            types.String      `tfsdk:"display"`
+	Formula            types.String      `tfsdk:"formula"`
+}
+
+type insightFunnelsQuery struct {
+	Series               []insightSeries   `tfsdk:"series"`
+	DateFrom             types.String      `tfsdk:"date_from"`
+	DateTo               types.String      `tfsdk:"date_to"`
+	Properties           []propertyFilter  `tfsdk:"properties"`
+	FilterTestAccounts   types.Bool        `tfsdk:"filter_test_accounts"`
+	Breakdown            *insightBreakdown `tfsdk:"breakdown"`
+	OrderType            types.String      `tfsdk:"order_type"`
+	VizType              types.String      `tfsdk:"viz_type"`
+	ConversionWindow     types.Int64       `tfsdk:"conversion_window"`
+	ConversionWindowUnit types.String      `tfsdk:"conversion_window_unit"`
+}
+
+type insightRetentionQuery struct {
+	Target             insightEntity    `tfsdk:"target"`
+	Returning          insightEntity    `tfsdk:"returning"`
+	RetentionType      types.String     `tfsdk:"retention_type"`
+	TotalIntervals     types.Int64      `tfsdk:"total_intervals"`
+	Period             types.String     `tfsdk:"period"`
+	DateFrom           types.String     `tfsdk:"date_from"`
+	DateTo             types.String     `tfsdk:"date_to"`
+	Properties         []propertyFilter `tfsdk:"properties"`
+	FilterTestAccounts types.Bool       `tfsdk:"filter_test_accounts"`
+}
+
+type insightPathsQuery struct {
+	IncludeEventTypes  []string         `tfsdk:"include_event_types"`
+	StartPoint         types.String     `tfsdk:"start_point"`
+	EndPoint           types.String     `tfsdk:"end_point"`
+	StepLimit          types.Int64      `tfsdk:"step_limit"`
+	DateFrom           types.String     `tfsdk:"date_from"`
+	DateTo             types.String     `tfsdk:"date_to"`
+	Properties         []propertyFilter `tfsdk:"properties"`
+	FilterTestAccounts types.Bool       `tfsdk:"filter_test_accounts"`
+}
+
+// insightSeriesQuery is the model of stickiness and lifecycle queries, that
+// only have series and an interval.
+type insightSeriesQuery struct {
+	Series             []insightSeries  `tfsdk:"series"`
+	Interval           types.String     `tfsdk:"interval"`
+	DateFrom           types.String     `tfsdk:"date_from"`
+	DateTo             types.String     `tfsdk:"date_to"`
+	Properties         []propertyFilter `tfsdk:"properties"`
+	FilterTestAccounts types.Bool       `tfsdk:"filter_test_accounts"`
+}
+
+func insightEventOrActionAttributes(what string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"event": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("Name of the event %s, exclusive with `action_id`", what),
+			Optional:            true,
+		},
+		"action_id": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("ID of the action %s, exclusive with `event`", what),
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("event")),
+			},
+		},
+	}
+}
+
+func insightSeriesSchema(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: description,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"event": schema.StringAttribute{
+					MarkdownDescription: "Name of the event to count, exclusive with `action_id`. Counts all events if neither `event` nor `action_id` are set.",
+					Optional:            true,
+				},
+				"action_id": schema.StringAttribute{
+					MarkdownDescription: "ID of the action to count, exclusive with `event`",
+					Optional:            true,
+					Validators: []validator.String{
+						stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("event")),
+					},
+				},
+				"custom_name": schema.StringAttribute{
+					MarkdownDescription: "Name of the series displayed in the insight",
+					Optional:            true,
+				},
+				"math": schema.StringAttribute{
+					MarkdownDescription: "How to aggregate the events, for example `total`, `dau` (unique users), `weekly_active`, `monthly_active`, `unique_session`, `sum`, `avg`, `min`, `max`, `median` or `p90`. PostHog counts the total number of events if unset.",
+					Optional:            true,
+				},
+				"math_property": schema.StringAttribute{
+					MarkdownDescription: "Event property to aggregate, for property aggregations like `sum` or `avg`",
+					Optional:            true,
+				},
+				"properties": propertyFiltersSchema("Filters applied to the events of this series", posthog.PropertyFilterTypeEvent),
+			},
+		},
+		Required: true,
+		Validators: []validator.List{
+			listvalidator.SizeAtLeast(1),
+		},
+	}
+}
+
+func insightIntervalAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "Granularity of the results, one of `hour`, `day`, `week` or `month`",
+		Optional:            true,
+		Validators: []validator.String{
+			stringvalidator.OneOf("hour", "day", "week", "month"),
+		},
+	}
+}
+
+func insightBreakdownAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Property to break the results down by",
+		Attributes: map[string]schema.Attribute{
+			"property": schema.StringAttribute{
+				MarkdownDescription: "Name of the property",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of the property, one of `event`, `person`, `session`, `group` or `hogql`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(posthog.PropertyFilterTypeEvent)),
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(posthog.PropertyFilterTypeEvent),
+						string(posthog.PropertyFilterTypePerson),
+						string(posthog.PropertyFilterTypeSession),
+						string(posthog.PropertyFilterTypeGroup),
+						string(posthog.PropertyFilterTypeHogQL),
+					),
+				},
+			},
+		},
+		Optional: true,
+	}
+}
+
+// insightQueryAttributes returns the attributes shared by all the typed
+// queries, merged with the given ones.
+func insightQueryAttributes(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	attributes["date_from"] = schema.StringAttribute{
+		MarkdownDescription: "Start of the date range, either absolute (`2024-01-31`) or relative (`-7d`, `-1m`, `mStart`). PostHog uses the last 7 days if unset.",
+		Optional:            true,
+	}
+	attributes["date_to"] = schema.StringAttribute{
+		MarkdownDescription: "End of the date range, either absolute or relative. Defaults to now.",
+		Optional:            true,
+	}
+	attributes["properties"] = propertyFiltersSchema("Filters applied to all the events of the query", posthog.PropertyFilterTypeEvent)
+	attributes["filter_test_accounts"] = schema.BoolAttribute{
+		MarkdownDescription: "Whether to filter out internal and test users, as defined in the project settings",
+		Optional:            true,
+	}
+
+	return attributes
+}
+
+func insightTrendsSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Trends query, showing the evolution of events over time",
+		Attributes: insightQueryAttributes(map[string]schema.Attribute{
+			"series":    insightSeriesSchema("Series to display"),
+			"interval":  insightIntervalAttribute(),
+			"breakdown": insightBreakdownAttribute(),
+			"display": schema.StringAttribute{
+				MarkdownDescription: "Type of chart, for example `ActionsLineGraph`, `ActionsLineGraphCumulative`, `ActionsAreaGraph`, `ActionsBar`, `ActionsBarValue`, `ActionsTable`, `ActionsPie`, `BoldNumber` or `WorldMap`",
+				Optional:            true,
+			},
+			"formula": schema.StringAttribute{
+				MarkdownDescription: "Formula combining the series, referred to by letter (eg. `A / B`)",
+				Optional:            true,
+			},
+		}),
+		Optional: true,
+	}
+}
+
+func insightFunnelsSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Funnels query, showing how users go through a sequence of steps",
+		Attributes: insightQueryAttributes(map[string]schema.Attribute{
+			"series":    insightSeriesSchema("Steps of the funnel"),
+			"breakdown": insightBreakdownAttribute(),
+			"order_type": schema.StringAttribute{
+				MarkdownDescription: "How the steps must be ordered, one of `ordered` (default), `strict` or `unordered`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("ordered", "strict", "unordered"),
+				},
+			},
+			"viz_type": schema.StringAttribute{
+				MarkdownDescription: "Visualization of the funnel, one of `steps` (default), `time_to_convert` or `trends`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("steps", "time_to_convert", "trends"),
+				},
+			},
+			"conversion_window": schema.Int64Attribute{
+				MarkdownDescription: "Time users have to complete the funnel, in `conversion_window_unit`",
+				Optional:            true,
+			},
+			"conversion_window_unit": schema.StringAttribute{
+				MarkdownDescription: "Unit of `conversion_window`, one of `second`, `minute`, `hour`, `day`, `week` or `month`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("second", "minute", "hour", "day", "week", "month"),
+				},
+			},
+		}),
+		Optional: true,
+	}
+}
+
+func insightRetentionSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Retention query, showing how many users come back after a first event",
+		Attributes: insightQueryAttributes(map[string]schema.Attribute{
+			"target": schema.SingleNestedAttribute{
+				MarkdownDescription: "Event or action that makes users part of a cohort",
+				Attributes:          insightEventOrActionAttributes("that makes users part of a cohort"),
+				Required:            true,
+			},
+			"returning": schema.SingleNestedAttribute{
+				MarkdownDescription: "Event or action counting as users coming back",
+				Attributes:          insightEventOrActionAttributes("counting as users coming back"),
+				Required:            true,
+			},
+			"retention_type": schema.StringAttribute{
+				MarkdownDescription: "Either `retention_first_time` (cohorts are based on the first time users performed the target event) or `retention_recurring`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("retention_first_time", "retention_recurring"),
+				},
+			},
+			"total_intervals": schema.Int64Attribute{
+				MarkdownDescription: "Number of periods to display",
+				Optional:            true,
+			},
+			"period": schema.StringAttribute{
+				MarkdownDescription: "Length of a period, one of `Hour`, `Day`, `Week` or `Month`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("Hour", "Day", "Week", "Month"),
+				},
+			},
+		}),
+		Optional: true,
+	}
+}
+
+func insightPathsSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Paths query, showing the paths users take through the product",
+		Attributes: insightQueryAttributes(map[string]schema.Attribute{
+			"include_event_types": schema.ListAttribute{
+				MarkdownDescription: "Types of events making the paths, among `$pageview`, `$screen`, `custom_event` and `hogql`",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.OneOf("$pageview", "$screen", "custom_event", "hogql")),
+				},
+			},
+			"start_point": schema.StringAttribute{
+				MarkdownDescription: "Only show paths starting with this event or URL",
+				Optional:            true,
+			},
+			"end_point": schema.StringAttribute{
+				MarkdownDescription: "Only show paths ending with this event or URL",
+				Optional:            true,
+			},
+			"step_limit": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of steps of the paths",
+				Optional:            true,
+			},
+		}),
+		Optional: true,
+	}
+}
+
+func insightStickinessSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Stickiness query, showing how many intervals users performed events in",
+		Attributes: insightQueryAttributes(map[string]schema.Attribute{
+			"series":   insightSeriesSchema("Series to display"),
+			"interval": insightIntervalAttribute(),
+		}),
+		Optional: true,
+	}
+}
+
+func insightLifecycleSchema() schema.SingleNestedAttribute {
+	series := insightSeriesSchema("Series to display, lifecycle queries accept a single series")
+	series.Validators = []validator.List{
+		listvalidator.SizeBetween(1, 1),
+	}
+
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Lifecycle query, showing new, returning, resurrecting and dormant users",
+		Attributes: insightQueryAttributes(map[string]schema.Attribute{
+			"series":   series,
+			"interval": insightIntervalAttribute(),
+		}),
+		Optional: true,
+	}
+}
+
+func insightSeriesFromModel(series []insightSeries) ([]posthog.InsightSeries, error) {
+	var res []posthog.InsightSeries
+
+	for _, s := range series {
+		node := posthog.InsightSeries{
+			Kind:         posthog.InsightQueryKindEvents,
+			CustomName:   s.CustomName.ValueString(),
+			Math:         s.Math.ValueString(),
+			MathProperty: s.MathProperty.ValueString(),
+			Properties:   propertyFiltersFromModel(s.Properties),
+			Name:         "All events",
+		}
+
+		if !s.ActionID.IsNull() {
+			actionID, err := posthog.ActionIDFromString(s.ActionID.ValueString())
+			if err != nil {
+				return nil, fmt.Errorf("invalid action ID %q: %w", s.ActionID.ValueString(), err)
+			}
+
+			node.Kind = posthog.InsightQueryKindActions
+			node.ID = &actionID
+			node.Name = ""
+		} else if !s.Event.IsNull() {
+			node.Event = s.Event.ValueStringPointer()
+			node.Name = s.Event.ValueString()
+		}
+
+		res = append(res, node)
+	}
+
+	return res, nil
+}
+
+func insightSeriesToModel(series []posthog.InsightSeries) []insightSeries {
+	var res []insightSeries
+
+	for _, s := range series {
+		m := insightSeries{
+			Event:        types.StringPointerValue(s.Event),
+			ActionID:     types.StringNull(),
+			CustomName:   typeutil.NullableStringValue(s.CustomName),
+			Math:         typeutil.NullableStringValue(s.Math),
+			MathProperty: typeutil.NullableStringValue(s.MathProperty),
+			Properties:   propertyFiltersToModel(s.Properties),
+		}
+
+		if s.Kind == posthog.InsightQueryKindActions && s.ID != nil {
+			m.ActionID = types.StringValue(s.ID.String())
+		}
+
+		res = append(res, m)
+	}
+
+	return res
+}
+
+func insightEntityFromModel(e insightEntity) *posthog.InsightEntity {
+	if !e.ActionID.IsNull() {
+		return &posthog.InsightEntity{ID: e.ActionID.ValueString(), Type: posthog.InsightEntityTypeActions}
+	}
+
+	return &posthog.InsightEntity{ID: e.Event.ValueString(), Type: posthog.InsightEntityTypeEvents, Name: e.Event.ValueString()}
+}
+
+func insightEntityToModel(e *posthog.InsightEntity) insightEntity {
+	res := insightEntity{Event: types.StringNull(), ActionID: types.StringNull()}
+
+	if e == nil {
+		return res
+	}
+
+	if e.Type == posthog.InsightEntityTypeActions {
+		res.ActionID = types.StringValue(e.ID)
+	} else {
+		res.Event = types.StringValue(e.ID)
+	}
+
+	return res
+}
+
+func insightBreakdownFromModel(b *insightBreakdown) *posthog.InsightBreakdownFilter {
+	if b == nil {
+		return nil
+	}
+
+	return &posthog.InsightBreakdownFilter{Breakdown: b.Property, BreakdownType: posthog.PropertyFilterType(b.Type)}
+}
+
+func insightBreakdownToModel(b *posthog.InsightBreakdownFilter) *insightBreakdown {
+	if b == nil || b.Breakdown == "" {
+		return nil
+	}
+
+	return &insightBreakdown{Property: b.Breakdown, Type: string(b.BreakdownType)}
+}
+
+func insightDateRangeFromModel(dateFrom, dateTo types.String) *posthog.InsightDateRange {
+	if dateFrom.IsNull() && dateTo.IsNull() {
+		return nil
+	}
+
+	return &posthog.InsightDateRange{DateFrom: dateFrom.ValueString(), DateTo: dateTo.ValueString()}
+}
+
+func insightDateRangeToModel(r *posthog.InsightDateRange) (types.String, types.String) {
+	if r == nil {
+		return types.StringNull(), types.StringNull()
+	}
+
+	return typeutil.NullableStringValue(r.DateFrom), typeutil.NullableStringValue(r.DateTo)
+}
+
+func (q insightTrendsQuery) source() (posthog.InsightQuerySource, error) {
+	series, err := insightSeriesFromModel(q.Series)
+	if err != nil {
+		return posthog.InsightQuerySource{}, err
+	}
+
+	source := posthog.InsightQuerySource{
+		Kind:               posthog.InsightQueryKindTrends,
+		Series:             series,
+		Interval:           q.Interval.ValueString(),
+		DateRange:          insightDateRangeFromModel(q.DateFrom, q.DateTo),
+		Properties:         propertyFiltersFromModel(q.Properties),
+		FilterTestAccounts: q.FilterTestAccounts.ValueBoolPointer(),
+		BreakdownFilter:    insightBreakdownFromModel(q.Breakdown),
+	}
+
+	if !q.Display.IsNull() || !q.Formula.IsNull() {
+		source.TrendsFilter = &posthog.InsightTrendsFilter{
+			Display: q.Display.ValueString(),
+			Formula: q.Formula.ValueString(),
+		}
+	}
+
+	return source, nil
+}
+
+func insightTrendsQueryToModel(source posthog.InsightQuerySource) *insightTrendsQuery {
+	res := &insightTrendsQuery{
+		Series:             insightSeriesToModel(source.Series),
+		Interval:           typeutil.NullableStringValue(source.Interval),
+		Properties:         propertyFiltersToModel(source.Properties),
+		FilterTestAccounts: types.BoolPointerValue(source.FilterTestAccounts),
+		Breakdown:          insightBreakdownToModel(source.BreakdownFilter),
+		Display:            types.StringNull(),
+		Formula:            types.StringNull(),
+	}
+
+	res.DateFrom, res.DateTo = insightDateRangeToModel(source.DateRange)
+
+	if source.TrendsFilter != nil {
+		res.Display = typeutil.NullableStringValue(source.TrendsFilter.Display)
+		res.Formula = typeutil.NullableStringValue(source.TrendsFilter.Formula)
+	}
+
+	return res
+}
+
+func (q insightFunnelsQuery) source() (posthog.InsightQuerySource, error) {
+	series, err := insightSeriesFromModel(q.Series)
+	if err != nil {
+		return posthog.InsightQuerySource{}, err
+	}
+
+	source := posthog.InsightQuerySource{
+		Kind:               posthog.InsightQueryKindFunnels,
+		Series:             series,
+		DateRange:          insightDateRangeFromModel(q.DateFrom, q.DateTo),
+		Properties:         propertyFiltersFromModel(q.Properties),
+		FilterTestAccounts: q.FilterTestAccounts.ValueBoolPointer(),
+		BreakdownFilter:    insightBreakdownFromModel(q.Breakdown),
+	}
+
+	if !q.OrderType.IsNull() || !q.VizType.IsNull() || !q.ConversionWindow.IsNull() || !q.ConversionWindowUnit.IsNull() {
+		source.FunnelsFilter = &posthog.InsightFunnelsFilter{
+			FunnelOrderType:          q.OrderType.ValueString(),
+			FunnelVizType:            q.VizType.ValueString(),
+			FunnelWindowInterval:     q.ConversionWindow.ValueInt64Pointer(),
+			FunnelWindowIntervalUnit: q.ConversionWindowUnit.ValueString(),
+		}
+	}
+
+	return source, nil
+}
+
+func insightFunnelsQueryToModel(source posthog.InsightQuerySource) *insightFunnelsQuery {
+	res := &insightFunnelsQuery{
+		Series:               insightSeriesToModel(source.Series),
+		Properties:           propertyFiltersToModel(source.Properties),
+		FilterTestAccounts:   types.BoolPointerValue(source.FilterTestAccounts),
+		Breakdown:            insightBreakdownToModel(source.BreakdownFilter),
+		OrderType:            types.StringNull(),
+		VizType:              types.StringNull(),
+		ConversionWindow:     types.Int64Null(),
+		ConversionWindowUnit: types.StringNull(),
+	}
+
+	res.DateFrom, res.DateTo = insightDateRangeToModel(source.DateRange)
+
+	if f := source.FunnelsFilter; f != nil {
+		res.OrderType = typeutil.NullableStringValue(f.FunnelOrderType)
+		res.VizType = typeutil.NullableStringValue(f.FunnelVizType)
+		res.ConversionWindow = types.Int64PointerValue(f.FunnelWindowInterval)
+		res.ConversionWindowUnit = typeutil.NullableStringValue(f.FunnelWindowIntervalUnit)
+	}
+
+	return res
+}
+
+func (q insightRetentionQuery) source() (posthog.InsightQuerySource, error) {
+	return posthog.InsightQuerySource{
+		Kind:               posthog.InsightQueryKindRetention,
+		DateRange:          insightDateRangeFromModel(q.DateFrom, q.DateTo),
+		Properties:         propertyFiltersFromModel(q.Properties),
+		FilterTestAccounts: q.FilterTestAccounts.ValueBoolPointer(),
+		RetentionFilter: &posthog.InsightRetentionFilter{
+			TargetEntity:    insightEntityFromModel(q.Target),
+			ReturningEntity: insightEntityFromModel(q.Returning),
+			RetentionType:   q.RetentionType.ValueString(),
+			TotalIntervals:  q.TotalIntervals.ValueInt64Pointer(),
+			Period:          q.Period.ValueString(),
+		},
+	}, nil
+}
+
+func insightRetentionQueryToModel(source posthog.InsightQuerySource) *insightRetentionQuery {
+	res := &insightRetentionQuery{
+		Target:             insightEntityToModel(nil),
+		Returning:          insightEntityToModel(nil),
+		RetentionType:      types.StringNull(),
+		TotalIntervals:     types.Int64Null(),
+		Period:             types.StringNull(),
+		Properties:         propertyFiltersToModel(source.Properties),
+		FilterTestAccounts: types.BoolPointerValue(source.FilterTestAccounts),
+	}
+
+	res.DateFrom, res.DateTo = insightDateRangeToModel(source.DateRange)
+
+	if f := source.RetentionFilter; f != nil {
+		res.Target = insightEntityToModel(f.TargetEntity)
+		res.Returning = insightEntityToModel(f.ReturningEntity)
+		res.RetentionType = typeutil.NullableStringValue(f.RetentionType)
+		res.TotalIntervals = types.Int64PointerValue(f.TotalIntervals)
+		res.Period = typeutil.NullableStringValue(f.Period)
+	}
+
+	return res
+}
+
+func (q insightPathsQuery) source() (posthog.InsightQuerySource, error) {
+	return posthog.InsightQuerySource{
+		Kind:               posthog.InsightQueryKindPaths,
+		DateRange:          insightDateRangeFromModel(q.DateFrom, q.DateTo),
+		Properties:         propertyFiltersFromModel(q.Properties),
+		FilterTestAccounts: q.FilterTestAccounts.ValueBoolPointer(),
+		PathsFilter: &posthog.InsightPathsFilter{
+			IncludeEventTypes: q.IncludeEventTypes,
+			StartPoint:        q.StartPoint.ValueString(),
+			EndPoint:          q.EndPoint.ValueString(),
+			StepLimit:         q.StepLimit.ValueInt64Pointer(),
+		},
+	}, nil
+}
+
+func insightPathsQueryToModel(source posthog.InsightQuerySource) *insightPathsQuery {
+	res := &insightPathsQuery{
+		StartPoint:         types.StringNull(),
+		EndPoint:           types.StringNull(),
+		StepLimit:          types.Int64Null(),
+		Properties:         propertyFiltersToModel(source.Properties),
+		FilterTestAccounts: types.BoolPointerValue(source.FilterTestAccounts),
+	}
+
+	res.DateFrom, res.DateTo = insightDateRangeToModel(source.DateRange)
+
+	if f := source.PathsFilter; f != nil {
+		res.IncludeEventTypes = f.IncludeEventTypes
+		res.StartPoint = typeutil.NullableStringValue(f.StartPoint)
+		res.EndPoint = typeutil.NullableStringValue(f.EndPoint)
+		res.StepLimit = types.Int64PointerValue(f.StepLimit)
+	}
+
+	return res
+}
+
+func (q insightSeriesQuery) source(kind posthog.InsightQueryKind) (posthog.InsightQuerySource, error) {
+	series, err := insightSeriesFromModel(q.Series)
+	if err != nil {
+		return posthog.InsightQuerySource{}, err
+	}
+
+	return posthog.InsightQuerySource{
+		Kind:               kind,
+		Series:             series,
+		Interval:           q.Interval.ValueString(),
+		DateRange:          insightDateRangeFromModel(q.DateFrom, q.DateTo),
+		Properties:         propertyFiltersFromModel(q.Properties),
+		FilterTestAccounts: q.FilterTestAccounts.ValueBoolPointer(),
+	}, nil
+}
+
+func insightSeriesQueryToModel(source posthog.InsightQuerySource) *insightSeriesQuery {
+	res := &insightSeriesQuery{
+		Series:             insightSeriesToModel(source.Series),
+		Interval:           typeutil.NullableStringValue(source.Interval),
+		Properties:         propertyFiltersToModel(source.Properties),
+		FilterTestAccounts: types.BoolPointerValue(source.FilterTestAccounts),
+	}
+
+	res.DateFrom, res.DateTo = insightDateRangeToModel(source.DateRange)
+
+	return res
+}
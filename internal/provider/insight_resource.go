@@ -0,0 +1,539 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+var _ resource.Resource = &insightResource{}
+var _ resource.ResourceWithImportState = &insightResource{}
+var _ resource.ResourceWithConfigValidators = &insightResource{}
+
+func newInsightResource() resource.Resource {
+	return &insightResource{}
+}
+
+type insightResource struct {
+	client *posthog.Client
+}
+
+type insightResourceModel struct {
+	ID           types.String           `tfsdk:"id"`
+	ShortID      types.String           `tfsdk:"short_id"`
+	ProjectID    types.String           `tfsdk:"project_id"`
+	Name         types.String           `tfsdk:"name"`
+	Description  types.String           `tfsdk:"description"`
+	Tags         types.List             `tfsdk:"tags"`
+	Saved        types.Bool             `tfsdk:"saved"`
+	DashboardIDs types.Set              `tfsdk:"dashboard_ids"`
+	QueryJSON    jsontypes.Normalized   `tfsdk:"query_json"`
+	HogQL        types.String           `tfsdk:"hogql_query"`
+	Trends       *insightTrendsQuery    `tfsdk:"trends_query"`
+	Funnels      *insightFunnelsQuery   `tfsdk:"funnels_query"`
+	Retention    *insightRetentionQuery `tfsdk:"retention_query"`
+	Paths        *insightPathsQuery     `tfsdk:"paths_query"`
+	Stickiness   *insightSeriesQuery    `tfsdk:"stickiness_query"`
+	Lifecycle    *insightSeriesQuery    `tfsdk:"lifecycle_query"`
+}
+
+func (r *insightResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_insight"
+}
+
+func (r *insightResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Insight. The query of the insight is defined by exactly one of `trends_query`, `funnels_query`, `retention_query`, `paths_query`, `stickiness_query`, `lifecycle_query`, `hogql_query` or `query_json`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the insight",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"short_id": schema.StringAttribute{
+				MarkdownDescription: "Short ID of the insight, as found in its URL (`/project/PROJECT_ID/insights/SHORT_ID`)",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the insight",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the insight",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the insight",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"tags": schema.ListAttribute{
+				MarkdownDescription: "Insight tags",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				Default:             listdefault.StaticValue(types.ListNull(types.StringType)),
+			},
+			"saved": schema.BoolAttribute{
+				MarkdownDescription: "Whether the insight appears in the list of saved insights",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"dashboard_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of the dashboards the insight is on. If unset, the dashboards of the insight are not managed by this resource. Must not be set for insights on a `posthog_dashboard` that sets `tiles`, since both attributes manage the same tiles and would undo each other's changes.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"query_json": schema.StringAttribute{
+				MarkdownDescription: "Query of the insight, as JSON (see the [query schema](https://github.com/PostHog/posthog/blob/master/frontend/src/queries/schema.json)). Formatting differences are ignored. Imported insights use this attribute.",
+				CustomType:          jsontypes.NormalizedType{},
+				Optional:            true,
+			},
+			"hogql_query": schema.StringAttribute{
+				MarkdownDescription: "SQL (HogQL) query of the insight",
+				Optional:            true,
+			},
+			"trends_query":     insightTrendsSchema(),
+			"funnels_query":    insightFunnelsSchema(),
+			"retention_query":  insightRetentionSchema(),
+			"paths_query":      insightPathsSchema(),
+			"stickiness_query": insightStickinessSchema(),
+			"lifecycle_query":  insightLifecycleSchema(),
+		},
+	}
+}
+
+func (r *insightResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("query_json"),
+			path.MatchRoot("hogql_query"),
+			path.MatchRoot("trends_query"),
+			path.MatchRoot("funnels_query"),
+			path.MatchRoot("retention_query"),
+			path.MatchRoot("paths_query"),
+			path.MatchRoot("stickiness_query"),
+			path.MatchRoot("lifecycle_query"),
+		),
+	}
+}
+
+func (r *insightResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// insightQueryFromModel builds the query of the insight from whichever query
+// attribute is set.
+func insightQueryFromModel(data insightResourceModel) (json.RawMessage, error) {
+	if !data.QueryJSON.IsNull() {
+		return json.RawMessage(data.QueryJSON.ValueString()), nil
+	}
+
+	query := posthog.InsightQuery{Kind: posthog.InsightQueryKindInsightViz}
+
+	var err error
+
+	switch {
+	case !data.HogQL.IsNull():
+		query.Kind = posthog.InsightQueryKindDataVisualization
+		query.Source = posthog.InsightQuerySource{Kind: posthog.InsightQueryKindHogQL, Query: data.HogQL.ValueString()}
+	case data.Trends != nil:
+		query.Source, err = data.Trends.source()
+	case data.Funnels != nil:
+		query.Source, err = data.Funnels.source()
+	case data.Retention != nil:
+		query.Source, err = data.Retention.source()
+	case data.Paths != nil:
+		query.Source, err = data.Paths.source()
+	case data.Stickiness != nil:
+		query.Source, err = data.Stickiness.source(posthog.InsightQueryKindStickiness)
+	case data.Lifecycle != nil:
+		query.Source, err = data.Lifecycle.source(posthog.InsightQueryKindLifecycle)
+	default:
+		return nil, errors.New("no query set")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(query)
+}
+
+// updateInsightQueryModel sets the query attribute used in the model. If the
+// kind of query was changed outside of Terraform, or if the query can't be
+// represented by the typed attributes, it is stored in query_json instead so
+// that the difference shows up in the plan.
+func updateInsightQueryModel(model *insightResourceModel, query json.RawMessage) {
+	var parsed posthog.InsightQuery
+
+	if model.QueryJSON.IsNull() {
+		if err := json.Unmarshal(query, &parsed); err != nil {
+			parsed = posthog.InsightQuery{}
+		}
+	}
+
+	isViz := parsed.Kind == posthog.InsightQueryKindInsightViz
+
+	switch {
+	case !model.HogQL.IsNull() && parsed.Kind == posthog.InsightQueryKindDataVisualization && parsed.Source.Kind == posthog.InsightQueryKindHogQL:
+		model.HogQL = types.StringValue(parsed.Source.Query)
+	case model.Trends != nil && isViz && parsed.Source.Kind == posthog.InsightQueryKindTrends:
+		model.Trends = insightTrendsQueryToModel(parsed.Source)
+	case model.Funnels != nil && isViz && parsed.Source.Kind == posthog.InsightQueryKindFunnels:
+		model.Funnels = insightFunnelsQueryToModel(parsed.Source)
+	case model.Retention != nil && isViz && parsed.Source.Kind == posthog.InsightQueryKindRetention:
+		model.Retention = insightRetentionQueryToModel(parsed.Source)
+	case model.Paths != nil && isViz && parsed.Source.Kind == posthog.InsightQueryKindPaths:
+		model.Paths = insightPathsQueryToModel(parsed.Source)
+	case model.Stickiness != nil && isViz && parsed.Source.Kind == posthog.InsightQueryKindStickiness:
+		model.Stickiness = insightSeriesQueryToModel(parsed.Source)
+	case model.Lifecycle != nil && isViz && parsed.Source.Kind == posthog.InsightQueryKindLifecycle:
+		model.Lifecycle = insightSeriesQueryToModel(parsed.Source)
+	default:
+		model.HogQL = types.StringNull()
+		model.Trends = nil
+		model.Funnels = nil
+		model.Retention = nil
+		model.Paths = nil
+		model.Stickiness = nil
+		model.Lifecycle = nil
+
+		if len(query) == 0 || string(query) == "null" {
+			// insights created before queries existed only have filters
+			model.QueryJSON = jsontypes.NewNormalizedNull()
+		} else {
+			model.QueryJSON = jsontypes.NewNormalizedValue(string(query))
+		}
+	}
+}
+
+func updateInsightModel(ctx context.Context, model *insightResourceModel, apiInsight *posthog.Insight) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(apiInsight.ID.String())
+	model.ShortID = types.StringValue(apiInsight.ShortID)
+	model.Name = types.StringValue(apiInsight.Name)
+	model.Description = types.StringValue(apiInsight.Description)
+	model.Saved = types.BoolValue(apiInsight.Saved)
+
+	model.Tags, diags = types.ListValueFrom(ctx, types.StringType, sortedStrings(apiInsight.Tags))
+	if diags.HasError() {
+		return diags
+	}
+
+	dashboardIDs := make([]string, 0, len(apiInsight.Dashboards))
+	for _, id := range apiInsight.Dashboards {
+		dashboardIDs = append(dashboardIDs, id.String())
+	}
+
+	model.DashboardIDs, diags = types.SetValueFrom(ctx, types.StringType, dashboardIDs)
+	if diags.HasError() {
+		return diags
+	}
+
+	updateInsightQueryModel(model, apiInsight.Query)
+
+	return diags
+}
+
+func dashboardIDsFromModel(ctx context.Context, ids types.Set) ([]posthog.DashboardID, diag.Diagnostics) {
+	var (
+		diags        diag.Diagnostics
+		stringIDs    []string
+		dashboardIDs []posthog.DashboardID
+	)
+
+	diags.Append(ids.ElementsAs(ctx, &stringIDs, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	for _, s := range stringIDs {
+		id, err := posthog.DashboardIDFromString(s)
+		if err != nil {
+			diags.AddError("Invalid dashboard ID", err.Error())
+			return nil, diags
+		}
+
+		dashboardIDs = append(dashboardIDs, id)
+	}
+
+	return dashboardIDs, diags
+}
+
+func (r *insightResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data insightResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := insightQueryFromModel(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid query", err.Error())
+		return
+	}
+
+	createInsightRequest := posthog.CreateInsightRequest{
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueString(),
+		Saved:       data.Saved.ValueBool(),
+		Query:       query,
+	}
+
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &createInsightRequest.Tags, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.DashboardIDs.IsUnknown() {
+		var diags diag.Diagnostics
+
+		createInsightRequest.Dashboards, diags = dashboardIDsFromModel(ctx, data.DashboardIDs)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Create the insight
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	res, err := r.client.CreateInsight(ctx, projectID, createInsightRequest)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating insight: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(updateInsightModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created insight", map[string]interface{}{"insight_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *insightResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data insightResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	insightID, err := posthog.InsightIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid insight ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetInsight(ctx, projectID, insightID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting insight %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil || res.Deleted {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(updateInsightModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read insight", map[string]interface{}{"insight_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func insightFromModel(ctx context.Context, data insightResourceModel) (posthog.ProjectID, posthog.Insight, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		diags.AddError("Invalid project ID", err.Error())
+		return posthog.ProjectID(0), posthog.Insight{}, diags
+	}
+
+	insightID, err := posthog.InsightIDFromString(data.ID.ValueString())
+	if err != nil {
+		diags.AddError("Invalid insight ID", err.Error())
+		return posthog.ProjectID(0), posthog.Insight{}, diags
+	}
+
+	query, err := insightQueryFromModel(data)
+	if err != nil {
+		diags.AddError("Invalid query", err.Error())
+		return posthog.ProjectID(0), posthog.Insight{}, diags
+	}
+
+	insight := posthog.Insight{
+		ID:          insightID,
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueString(),
+		Saved:       data.Saved.ValueBool(),
+		Query:       query,
+	}
+
+	diags.Append(data.Tags.ElementsAs(ctx, &insight.Tags, false)...)
+	if diags.HasError() {
+		return posthog.ProjectID(0), posthog.Insight{}, diags
+	}
+
+	return projectID, insight, diags
+}
+
+func (r *insightResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state insightResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, insight, diags := insightFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only touch the dashboards if they are managed by this resource, the plan
+	// otherwise keeps the value from the state.
+	if !data.DashboardIDs.IsUnknown() && !data.DashboardIDs.Equal(state.DashboardIDs) {
+		dashboardIDs, diags := dashboardIDsFromModel(ctx, data.DashboardIDs)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if err := r.client.SetInsightDashboards(ctx, projectID, insight.ID, dashboardIDs); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating dashboards of insight %s: %s", insight.ID, err))
+			return
+		}
+	}
+
+	res, err := r.client.UpdateInsight(ctx, projectID, insight)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating insight %s: %s", insight.ID, err))
+		return
+	}
+
+	resp.Diagnostics.Append(updateInsightModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated insight", map[string]interface{}{"insight_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *insightResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data insightResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, insight, diags := insightFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Insights are soft deleted, like in the web UI
+	insight.Deleted = true
+
+	_, err := r.client.UpdateInsight(ctx, projectID, insight)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting insight %s: %s", insight.ID, err))
+		return
+	}
+}
+
+func (r *insightResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, insightID, err := parseImportID(req.ID, "insight", posthog.InsightIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), insightID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+var _ datasource.DataSource = &integrationDataSource{}
+
+func newIntegrationDataSource() datasource.DataSource {
+	return &integrationDataSource{}
+}
+
+type integrationDataSource struct {
+	client *posthog.Client
+}
+
+type integrationDataSourceModel struct {
+	ID          types.String         `tfsdk:"id"`
+	ProjectID   types.String         `tfsdk:"project_id"`
+	Kind        types.String         `tfsdk:"kind"`
+	DisplayName types.String         `tfsdk:"display_name"`
+	Config      jsontypes.Normalized `tfsdk:"config"`
+	Errors      types.String         `tfsdk:"errors"`
+}
+
+func (d *integrationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_integration"
+}
+
+func (d *integrationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a Posthog Integration by kind and display name, for example to get the ID of the Slack workspace to use in Hog functions and subscriptions.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the integration",
+				Computed:            true,
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the integration",
+				Required:            true,
+			},
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "Kind of the integration (eg. `slack`, `salesforce`, `hubspot` or `google-ads`)",
+				Required:            true,
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the integration displayed in PostHog (eg. the name of the Slack workspace). Required if the project has several integrations of the given kind.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"config": schema.StringAttribute{
+				MarkdownDescription: "Settings of the integration, as JSON",
+				CustomType:          jsontypes.NormalizedType{},
+				Computed:            true,
+			},
+			"errors": schema.StringAttribute{
+				MarkdownDescription: "Errors reported by PostHog when using the integration",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *integrationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *integrationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data integrationDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	integrations, err := d.client.ListIntegrations(ctx, projectID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error listing integrations: %s", err))
+		return
+	}
+
+	var matches []posthog.Integration
+	var displayNames []string
+
+	for _, i := range integrations {
+		if string(i.Kind) != data.Kind.ValueString() {
+			continue
+		}
+
+		if !data.DisplayName.IsNull() && i.DisplayName != data.DisplayName.ValueString() {
+			continue
+		}
+
+		matches = append(matches, i)
+		displayNames = append(displayNames, fmt.Sprintf("%q", i.DisplayName))
+	}
+
+	if len(matches) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("kind"), "Integration not found", fmt.Sprintf("No %s integration matching the given display name in project %s.", data.Kind.ValueString(), projectID))
+		return
+	}
+
+	if len(matches) > 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("display_name"), "Several integrations found", fmt.Sprintf("Several %s integrations match in project %s (%s), set display_name to select one.", data.Kind.ValueString(), projectID, strings.Join(displayNames, ", ")))
+		return
+	}
+
+	res := matches[0]
+
+	data.ID = types.StringValue(res.ID.String())
+	data.DisplayName = types.StringValue(res.DisplayName)
+	data.Config = jsontypes.NewNormalizedValue(string(res.Config))
+	data.Errors = types.StringValue(res.Errors)
+
+	tflog.Trace(ctx, "read integration", map[string]interface{}{"integration_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
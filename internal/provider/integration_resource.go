@@ -0,0 +1,290 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+var _ resource.Resource = &integrationResource{}
+var _ resource.ResourceWithImportState = &integrationResource{}
+
+func newIntegrationResource() resource.Resource {
+	return &integrationResource{}
+}
+
+type integrationResource struct {
+	client *posthog.Client
+}
+
+type integrationResourceModel struct {
+	ID              types.String         `tfsdk:"id"`
+	ProjectID       types.String         `tfsdk:"project_id"`
+	Kind            types.String         `tfsdk:"kind"`
+	Config          jsontypes.Normalized `tfsdk:"config"`
+	SensitiveConfig jsontypes.Normalized `tfsdk:"sensitive_config"`
+	DisplayName     types.String         `tfsdk:"display_name"`
+	Errors          types.String         `tfsdk:"errors"`
+}
+
+// oauthIntegrationKinds are the kinds of integrations that can't be created
+// through the API.
+var oauthIntegrationKinds = []string{
+	string(posthog.IntegrationKindSlack),
+	string(posthog.IntegrationKindSalesforce),
+	string(posthog.IntegrationKindHubSpot),
+	string(posthog.IntegrationKindGoogleAds),
+	string(posthog.IntegrationKindLinkedIn),
+	string(posthog.IntegrationKindSnapchat),
+	string(posthog.IntegrationKindIntercom),
+	string(posthog.IntegrationKindLinear),
+	string(posthog.IntegrationKindGitHub),
+}
+
+func (r *integrationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_integration"
+}
+
+func (r *integrationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Integration. Only integrations configured with plain settings can be created, integrations connected through OAuth (Slack, Salesforce, HubSpot, Google Ads...) must be connected from the web UI and can be looked up with the `posthog_integration` data source. Integrations can't be updated, any change re-creates them.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the integration",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the integration",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "Kind of the integration (eg. `twilio`)",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.NoneOf(oauthIntegrationKinds...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"config": schema.StringAttribute{
+				MarkdownDescription: "Settings of the integration, as JSON. PostHog might add fields to the settings, only the ones sent when creating the integration are tracked.",
+				CustomType:          jsontypes.NormalizedType{},
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"sensitive_config": schema.StringAttribute{
+				MarkdownDescription: "Secret settings of the integration (eg. API tokens), as JSON. PostHog never returns those values, changes made outside of Terraform are not detected.",
+				CustomType:          jsontypes.NormalizedType{},
+				Optional:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the integration displayed in PostHog",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"errors": schema.StringAttribute{
+				MarkdownDescription: "Errors reported by PostHog when using the integration",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *integrationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func updateIntegrationModel(model *integrationResourceModel, apiIntegration *posthog.Integration) {
+	model.ID = types.StringValue(apiIntegration.ID.String())
+	model.Kind = types.StringValue(string(apiIntegration.Kind))
+	model.DisplayName = types.StringValue(apiIntegration.DisplayName)
+	model.Errors = types.StringValue(apiIntegration.Errors)
+
+	// The config returned by PostHog contains additional fields, only set it
+	// when importing.
+	if model.Config.IsNull() || model.Config.IsUnknown() {
+		model.Config = jsontypes.NewNormalizedValue(string(apiIntegration.Config))
+	}
+}
+
+func (r *integrationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data integrationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createIntegrationRequest := posthog.CreateIntegrationRequest{
+		Kind:   posthog.IntegrationKind(data.Kind.ValueString()),
+		Config: json.RawMessage(data.Config.ValueString()),
+	}
+
+	if !data.SensitiveConfig.IsNull() {
+		createIntegrationRequest.SensitiveConfig = json.RawMessage(data.SensitiveConfig.ValueString())
+	}
+
+	// Create the integration
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	res, err := r.client.CreateIntegration(ctx, projectID, createIntegrationRequest)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating integration: %s", err))
+		return
+	}
+
+	updateIntegrationModel(&data, res)
+
+	tflog.Trace(ctx, "created integration", map[string]interface{}{"integration_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *integrationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data integrationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	integrationID, err := posthog.IntegrationIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid integration ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetIntegration(ctx, projectID, integrationID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting integration %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	updateIntegrationModel(&data, res)
+
+	tflog.Trace(ctx, "read integration", map[string]interface{}{"integration_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *integrationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// All the attributes require replacing the integration
+	var data integrationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *integrationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data integrationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	integrationID, err := posthog.IntegrationIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid integration ID", err.Error())
+		return
+	}
+
+	err = r.client.DeleteIntegration(ctx, projectID, integrationID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting integration %s: %s", data.ID, err))
+		return
+	}
+}
+
+func (r *integrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, integrationID, err := parseImportID(req.ID, "integration", posthog.IntegrationIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), integrationID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
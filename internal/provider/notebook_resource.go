@@ -0,0 +1,352 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+var _ resource.Resource = &notebookResource{}
+var _ resource.ResourceWithImportState = &notebookResource{}
+var _ resource.ResourceWithConfigValidators = &notebookResource{}
+var _ resource.ResourceWithValidateConfig = &notebookResource{}
+
+func newNotebookResource() resource.Resource {
+	return &notebookResource{}
+}
+
+type notebookResource struct {
+	client *posthog.Client
+}
+
+type notebookResourceModel struct {
+	ID          types.String         `tfsdk:"id"`
+	ProjectID   types.String         `tfsdk:"project_id"`
+	Title       types.String         `tfsdk:"title"`
+	Content     types.String         `tfsdk:"content"`
+	ContentJSON jsontypes.Normalized `tfsdk:"content_json"`
+	Version     types.Int64          `tfsdk:"version"`
+}
+
+func (r *notebookResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notebook"
+}
+
+func (r *notebookResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Notebook. The content of the notebook is defined by exactly one of `content` (Markdown) or `content_json` (ProseMirror document). Updates fail if the notebook was modified in PostHog since it was last read, instead of overwriting the changes.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Short ID of the notebook, as found in its URL (`/project/PROJECT_ID/notebooks/SHORT_ID`)",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the notebook",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "Title of the notebook. With `content`, it is also added as the first heading of the notebook, like in notebooks created from the web UI.",
+				Required:            true,
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "Content of the notebook, in simplified Markdown: headings, paragraphs, bullet and ordered lists (not nested), block quotes, code blocks, horizontal rules, bold, italic, inline code and links. " +
+					"A line of the form `{{KIND ID}}` embeds a PostHog object, where KIND is one of `" + strings.Join(posthog.NotebookEmbedKinds, "`, `") + "` and ID is the ID of the object " +
+					"(the short ID for insights, the session ID for recordings), eg. `{{insight ${posthog_insight.signups.short_id}}}`.",
+				Optional: true,
+			},
+			"content_json": schema.StringAttribute{
+				MarkdownDescription: "Content of the notebook, as a ProseMirror JSON document. Formatting differences are ignored. Imported notebooks, and notebooks modified in PostHog, use this attribute.",
+				CustomType:          jsontypes.NormalizedType{},
+				Optional:            true,
+			},
+			"version": schema.Int64Attribute{
+				MarkdownDescription: "Version of the notebook, incremented by each change",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *notebookResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("content"),
+			path.MatchRoot("content_json"),
+		),
+	}
+}
+
+func (r *notebookResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *notebookResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data notebookResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Content.IsUnknown() || data.Content.IsNull() {
+		return
+	}
+
+	if _, err := posthog.NotebookContentFromMarkdown(data.Title.ValueString(), data.Content.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("content"), "Invalid notebook content", err.Error())
+	}
+}
+
+// notebookContentFromModel returns the ProseMirror document of the notebook,
+// converting it from Markdown if needed.
+func notebookContentFromModel(data notebookResourceModel) (json.RawMessage, error) {
+	if !data.ContentJSON.IsNull() {
+		return json.RawMessage(data.ContentJSON.ValueString()), nil
+	}
+
+	doc, err := posthog.NotebookContentFromMarkdown(data.Title.ValueString(), data.Content.ValueString())
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(doc)
+}
+
+// updateNotebookModel updates the model after reading the notebook. The
+// content is only read back if the notebook was modified since the version in
+// the model, since PostHog adds attributes to the nodes of the document. The
+// content is then stored in content_json, so that the difference shows up in
+// the plan.
+func updateNotebookModel(model *notebookResourceModel, apiNotebook *posthog.Notebook) {
+	model.ID = types.StringValue(apiNotebook.ID.String())
+	model.Title = types.StringValue(apiNotebook.Title)
+
+	if model.Version.IsNull() || model.Version.IsUnknown() || model.Version.ValueInt64() != apiNotebook.Version {
+		model.Content = types.StringNull()
+		model.ContentJSON = jsontypes.NewNormalizedValue(string(apiNotebook.Content))
+	}
+
+	model.Version = types.Int64Value(apiNotebook.Version)
+}
+
+func (r *notebookResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data notebookResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	content, err := notebookContentFromModel(data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("content"), "Invalid notebook content", err.Error())
+		return
+	}
+
+	createNotebookRequest := posthog.CreateNotebookRequest{
+		Title:   data.Title.ValueString(),
+		Content: content,
+	}
+
+	// Create the notebook
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	res, err := r.client.CreateNotebook(ctx, projectID, createNotebookRequest)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating notebook: %s", err))
+		return
+	}
+
+	data.Version = types.Int64Value(res.Version)
+	updateNotebookModel(&data, res)
+
+	tflog.Trace(ctx, "created notebook", map[string]interface{}{"notebook_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *notebookResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data notebookResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	notebookID, err := posthog.NotebookIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid notebook ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetNotebook(ctx, projectID, notebookID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting notebook %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil || res.Deleted {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	updateNotebookModel(&data, res)
+
+	tflog.Trace(ctx, "read notebook", map[string]interface{}{"notebook_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *notebookResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state notebookResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	notebookID, err := posthog.NotebookIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid notebook ID", err.Error())
+		return
+	}
+
+	content, err := notebookContentFromModel(data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("content"), "Invalid notebook content", err.Error())
+		return
+	}
+
+	// The update is based on the version that was read when planning
+	notebook := posthog.Notebook{
+		ID:      notebookID,
+		Title:   data.Title.ValueString(),
+		Content: content,
+		Version: state.Version.ValueInt64(),
+	}
+
+	res, err := r.client.UpdateNotebook(ctx, projectID, notebook)
+	if errors.Is(err, posthog.ErrNotebookConflict) {
+		resp.Diagnostics.AddError("Notebook modified concurrently", fmt.Sprintf("Notebook %s was modified in PostHog since it was last read, run Terraform again to review the changes.", notebookID))
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating notebook %s: %s", notebookID, err))
+		return
+	}
+
+	data.Version = types.Int64Value(res.Version)
+	updateNotebookModel(&data, res)
+
+	tflog.Trace(ctx, "updated notebook", map[string]interface{}{"notebook_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *notebookResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data notebookResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	notebookID, err := posthog.NotebookIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid notebook ID", err.Error())
+		return
+	}
+
+	// Notebooks are soft deleted, like in the web UI
+	_, err = r.client.UpdateNotebook(ctx, projectID, posthog.Notebook{
+		ID:      notebookID,
+		Title:   data.Title.ValueString(),
+		Version: data.Version.ValueInt64(),
+		Deleted: true,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting notebook %s: %s", data.ID, err))
+		return
+	}
+}
+
+func (r *notebookResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, notebookID, err := parseImportID(req.ID, "notebook", posthog.NotebookIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), notebookID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
@@ -0,0 +1,436 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+	"github.com/abustany/terraform-provider-posthog/internal/typeutil"
+)
+
+var _ resource.Resource = &organizationInviteResource{}
+var _ resource.ResourceWithImportState = &organizationInviteResource{}
+
+func newOrganizationInviteResource() resource.Resource {
+	return &organizationInviteResource{}
+}
+
+type organizationInviteResource struct {
+	client *posthog.Client
+}
+
+type organizationInviteResourceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	OrganizationID       types.String `tfsdk:"organization_id"`
+	Email                types.String `tfsdk:"email"`
+	Level                types.String `tfsdk:"level"`
+	Message              types.String `tfsdk:"message"`
+	PrivateProjectAccess types.List   `tfsdk:"private_project_access"`
+	IsExpired            types.Bool   `tfsdk:"is_expired"`
+}
+
+type organizationInviteProjectAccess struct {
+	ProjectID types.String `tfsdk:"project_id"`
+	Level     types.String `tfsdk:"level"`
+}
+
+func (r *organizationInviteResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_invite"
+}
+
+// organizationIDAttribute is the organization_id attribute of the resources
+// that belong to an organization.
+func organizationIDAttribute(description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: description + ". Defaults to `@current`, the organization of the API key.",
+		Optional:            true,
+		Computed:            true,
+		Default:             stringdefault.StaticString(posthog.OrganizationIDCurrent.String()),
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+func organizationMembershipLevelValidator(levels ...posthog.OrganizationMembershipLevel) validator.String {
+	names := make([]string, len(levels))
+	for i, l := range levels {
+		names[i] = l.String()
+	}
+
+	return stringvalidator.OneOf(names...)
+}
+
+func organizationInvitePrivateProjectAccessSchema() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "Private projects the user gets access to once the invite is accepted",
+		Optional:            true,
+		PlanModifiers: []planmodifier.List{
+			listplanmodifier.RequiresReplace(),
+		},
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"project_id": schema.StringAttribute{
+					MarkdownDescription: "ID of the project",
+					Required:            true,
+				},
+				"level": schema.StringAttribute{
+					MarkdownDescription: "Level of the user in the project: `member` or `admin`",
+					Required:            true,
+					Validators: []validator.String{
+						organizationMembershipLevelValidator(
+							posthog.OrganizationMembershipLevelMember,
+							posthog.OrganizationMembershipLevelAdmin,
+						),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *organizationInviteResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Organization Invite. Invites can't be modified, changing any attribute sends a new invite. Expired invites are sent again on the next apply. Once the invite is accepted, the membership can be managed with `posthog_organization_membership`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the invite",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": organizationIDAttribute("ID of the organization the user is invited to"),
+			"email": schema.StringAttribute{
+				MarkdownDescription: "Email of the invited user",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"level": schema.StringAttribute{
+				MarkdownDescription: "Level of the user in the organization once the invite is accepted: `member`, `admin` or `owner`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(posthog.OrganizationMembershipLevelMember.String()),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					organizationMembershipLevelValidator(
+						posthog.OrganizationMembershipLevelMember,
+						posthog.OrganizationMembershipLevelAdmin,
+						posthog.OrganizationMembershipLevelOwner,
+					),
+				},
+			},
+			"message": schema.StringAttribute{
+				MarkdownDescription: "Message included in the invite email",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"private_project_access": organizationInvitePrivateProjectAccessSchema(),
+			"is_expired": schema.BoolAttribute{
+				MarkdownDescription: "Whether the invite expired without being accepted",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *organizationInviteResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func updateOrganizationInviteModel(ctx context.Context, model *organizationInviteResourceModel, apiInvite *posthog.OrganizationInvite) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(apiInvite.ID.String())
+	model.Email = types.StringValue(apiInvite.TargetEmail)
+	model.Level = types.StringValue(apiInvite.Level.String())
+	model.Message = typeutil.NullableStringValue(apiInvite.Message)
+	model.IsExpired = types.BoolValue(apiInvite.IsExpired)
+
+	projectAccessType := organizationInvitePrivateProjectAccessSchema().NestedObject.Type()
+	model.PrivateProjectAccess = types.ListNull(projectAccessType)
+
+	if len(apiInvite.PrivateProjectAccess) > 0 {
+		projectAccess := make([]organizationInviteProjectAccess, len(apiInvite.PrivateProjectAccess))
+		for i, a := range apiInvite.PrivateProjectAccess {
+			projectAccess[i] = organizationInviteProjectAccess{
+				ProjectID: types.StringValue(a.ID.String()),
+				Level:     types.StringValue(a.Level.String()),
+			}
+		}
+
+		model.PrivateProjectAccess, diags = types.ListValueFrom(ctx, projectAccessType, projectAccess)
+	}
+
+	return diags
+}
+
+func (r *organizationInviteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data organizationInviteResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	level, err := posthog.OrganizationMembershipLevelFromString(data.Level.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("level"), "Invalid level", err.Error())
+		return
+	}
+
+	createOrganizationInviteRequest := posthog.CreateOrganizationInviteRequest{
+		TargetEmail: data.Email.ValueString(),
+		Level:       level,
+		Message:     data.Message.ValueString(),
+		SendEmail:   true,
+	}
+
+	if !data.PrivateProjectAccess.IsNull() {
+		var projectAccess []organizationInviteProjectAccess
+
+		resp.Diagnostics.Append(data.PrivateProjectAccess.ElementsAs(ctx, &projectAccess, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		for i, a := range projectAccess {
+			p := path.Root("private_project_access").AtListIndex(i)
+
+			projectID, err := posthog.ProjectIDFromString(a.ProjectID.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(p.AtName("project_id"), "Invalid project ID", err.Error())
+				return
+			}
+
+			level, err := posthog.OrganizationMembershipLevelFromString(a.Level.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(p.AtName("level"), "Invalid level", err.Error())
+				return
+			}
+
+			createOrganizationInviteRequest.PrivateProjectAccess = append(createOrganizationInviteRequest.PrivateProjectAccess, posthog.OrganizationInviteProjectAccess{
+				ID:    projectID,
+				Level: level,
+			})
+		}
+	}
+
+	organizationID, err := posthog.OrganizationIDFromString(data.OrganizationID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid organization ID", err.Error())
+		return
+	}
+
+	// Expired invites are not deleted by PostHog, remove the ones sent to the
+	// same email so that they do not pile up.
+
+	invites, err := r.client.ListOrganizationInvites(ctx, organizationID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error listing organization invites: %s", err))
+		return
+	}
+
+	for _, i := range invites {
+		if !i.IsExpired || i.TargetEmail != createOrganizationInviteRequest.TargetEmail {
+			continue
+		}
+
+		if err := r.client.DeleteOrganizationInvite(ctx, organizationID, i.ID); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting expired organization invite %s: %s", i.ID, err))
+			return
+		}
+
+		tflog.Trace(ctx, "deleted expired organization invite", map[string]interface{}{"organization_invite_id": i.ID})
+	}
+
+	// Create the invite
+
+	res, err := r.client.CreateOrganizationInvite(ctx, organizationID, createOrganizationInviteRequest)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating organization invite: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(updateOrganizationInviteModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created organization invite", map[string]interface{}{"organization_invite_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *organizationInviteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data organizationInviteResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID, err := posthog.OrganizationIDFromString(data.OrganizationID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid organization ID", err.Error())
+		return
+	}
+
+	inviteID, err := posthog.OrganizationInviteIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid organization invite ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetOrganizationInvite(ctx, organizationID, inviteID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting organization invite %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil {
+		// Accepted invites are deleted, keep the resource around if the user
+		// joined the organization, since inviting them again would fail.
+		member, err := r.client.GetOrganizationMemberByEmail(ctx, organizationID, data.Email.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting organization member %s: %s", data.Email, err))
+			return
+		}
+
+		if member == nil {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		tflog.Trace(ctx, "organization invite accepted", map[string]interface{}{"organization_invite_id": data.ID.ValueString()})
+
+		data.IsExpired = types.BoolValue(false)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	if res.IsExpired {
+		// Let Terraform send a new invite
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(updateOrganizationInviteModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read organization invite", map[string]interface{}{"organization_invite_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *organizationInviteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// All the configurable attributes require replacing the invite, so there
+	// is nothing to send to PostHog here.
+	var data organizationInviteResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *organizationInviteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data organizationInviteResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID, err := posthog.OrganizationIDFromString(data.OrganizationID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid organization ID", err.Error())
+		return
+	}
+
+	inviteID, err := posthog.OrganizationInviteIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid organization invite ID", err.Error())
+		return
+	}
+
+	// Deleting an accepted invite is a no-op, use
+	// posthog_organization_membership to remove users from the organization.
+	res, err := r.client.GetOrganizationInvite(ctx, organizationID, inviteID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting organization invite %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil {
+		return
+	}
+
+	if err := r.client.DeleteOrganizationInvite(ctx, organizationID, inviteID); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting organization invite %s: %s", data.ID, err))
+		return
+	}
+}
+
+func (r *organizationInviteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	organizationID, inviteID, err := parseOrganizationImportID(req.ID, "invite", posthog.OrganizationInviteIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), inviteID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), organizationID.String())...)
+}
@@ -0,0 +1,282 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+var _ resource.Resource = &organizationMembershipResource{}
+var _ resource.ResourceWithImportState = &organizationMembershipResource{}
+
+func newOrganizationMembershipResource() resource.Resource {
+	return &organizationMembershipResource{}
+}
+
+type organizationMembershipResource struct {
+	client *posthog.Client
+}
+
+type organizationMembershipResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	OrganizationID types.String `tfsdk:"organization_id"`
+	Email          types.String `tfsdk:"email"`
+	Level          types.String `tfsdk:"level"`
+}
+
+func (r *organizationMembershipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_membership"
+}
+
+func (r *organizationMembershipResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Organization Membership. Users join organizations by accepting invites (see `posthog_organization_invite`), this resource manages the level of existing members. Destroying it removes the user from the organization.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "UUID of the user",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": organizationIDAttribute("ID of the organization"),
+			"email": schema.StringAttribute{
+				MarkdownDescription: "Email of the user, who must already be a member of the organization. The user is found by email when creating the resource, and by UUID afterwards, so that changing the email of the user in PostHog doesn't affect the membership.",
+				Required:            true,
+			},
+			"level": schema.StringAttribute{
+				MarkdownDescription: "Level of the user in the organization: `member`, `admin` or `owner`",
+				Required:            true,
+				Validators: []validator.String{
+					organizationMembershipLevelValidator(
+						posthog.OrganizationMembershipLevelMember,
+						posthog.OrganizationMembershipLevelAdmin,
+						posthog.OrganizationMembershipLevelOwner,
+					),
+				},
+			},
+		},
+	}
+}
+
+func (r *organizationMembershipResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// updateOrganizationMembershipModel updates the model after reading the
+// membership. The email is only read for imported memberships, since users can
+// change their email.
+func updateOrganizationMembershipModel(model *organizationMembershipResourceModel, apiMember *posthog.OrganizationMember) {
+	model.ID = types.StringValue(apiMember.User.UUID)
+
+	if model.Email.IsNull() {
+		model.Email = types.StringValue(apiMember.User.Email)
+	}
+
+	model.Level = types.StringValue(apiMember.Level.String())
+}
+
+func (r *organizationMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data organizationMembershipResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID, err := posthog.OrganizationIDFromString(data.OrganizationID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid organization ID", err.Error())
+		return
+	}
+
+	level, err := posthog.OrganizationMembershipLevelFromString(data.Level.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("level"), "Invalid level", err.Error())
+		return
+	}
+
+	// Memberships can't be created, take over the existing one
+
+	member, err := r.client.GetOrganizationMemberByEmail(ctx, organizationID, data.Email.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting organization member %s: %s", data.Email, err))
+		return
+	}
+
+	if member == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("email"), "Not a member", fmt.Sprintf("No member of the organization has the email %s, users must accept an invite before their membership can be managed.", data.Email))
+		return
+	}
+
+	res, err := r.client.UpdateOrganizationMemberLevel(ctx, organizationID, member.User.UUID, level)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating organization member %s: %s", data.Email, err))
+		return
+	}
+
+	updateOrganizationMembershipModel(&data, res)
+
+	tflog.Trace(ctx, "created organization membership", map[string]interface{}{"user_uuid": res.User.UUID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *organizationMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data organizationMembershipResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID, err := posthog.OrganizationIDFromString(data.OrganizationID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid organization ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetOrganizationMember(ctx, organizationID, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting organization member %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	updateOrganizationMembershipModel(&data, res)
+
+	tflog.Trace(ctx, "read organization membership", map[string]interface{}{"user_uuid": res.User.UUID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *organizationMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state organizationMembershipResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID, err := posthog.OrganizationIDFromString(data.OrganizationID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid organization ID", err.Error())
+		return
+	}
+
+	level, err := posthog.OrganizationMembershipLevelFromString(data.Level.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("level"), "Invalid level", err.Error())
+		return
+	}
+
+	// The email can be updated to follow a change made in PostHog, but must
+	// still be the one of the same user
+	if !data.Email.Equal(state.Email) {
+		member, err := r.client.GetOrganizationMemberByEmail(ctx, organizationID, data.Email.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting organization member %s: %s", data.Email, err))
+			return
+		}
+
+		if member == nil {
+			resp.Diagnostics.AddAttributeError(path.Root("email"), "Not a member", fmt.Sprintf("No member of the organization has the email %s.", data.Email))
+			return
+		}
+
+		if member.User.UUID != data.ID.ValueString() {
+			resp.Diagnostics.AddAttributeError(path.Root("email"), "Different user", fmt.Sprintf("The email %s belongs to another member of the organization, the membership of another user must be managed by another resource.", data.Email))
+			return
+		}
+	}
+
+	res, err := r.client.UpdateOrganizationMemberLevel(ctx, organizationID, data.ID.ValueString(), level)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating organization member %s: %s", data.Email, err))
+		return
+	}
+
+	updateOrganizationMembershipModel(&data, res)
+
+	tflog.Trace(ctx, "updated organization membership", map[string]interface{}{"user_uuid": res.User.UUID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *organizationMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data organizationMembershipResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID, err := posthog.OrganizationIDFromString(data.OrganizationID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid organization ID", err.Error())
+		return
+	}
+
+	if err := r.client.DeleteOrganizationMember(ctx, organizationID, data.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error removing organization member %s: %s", data.Email, err))
+		return
+	}
+}
+
+func (r *organizationMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	organizationID, userUUID, err := parseOrganizationImportID(req.ID, "user", func(s string) (string, error) {
+		if s == "" {
+			return "", errors.New("empty user UUID")
+		}
+
+		return s, nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), userUUID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), organizationID.String())...)
+}
@@ -0,0 +1,432 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+var _ resource.Resource = &propertyDefinitionResource{}
+var _ resource.ResourceWithImportState = &propertyDefinitionResource{}
+var _ resource.ResourceWithValidateConfig = &propertyDefinitionResource{}
+
+func newPropertyDefinitionResource() resource.Resource {
+	return &propertyDefinitionResource{}
+}
+
+type propertyDefinitionResource struct {
+	client *posthog.Client
+}
+
+type propertyDefinitionResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	ProjectID      types.String `tfsdk:"project_id"`
+	Name           types.String `tfsdk:"name"`
+	Type           types.String `tfsdk:"type"`
+	GroupTypeIndex types.Int64  `tfsdk:"group_type_index"`
+	Description    types.String `tfsdk:"description"`
+	Tags           types.List   `tfsdk:"tags"`
+	PropertyType   types.String `tfsdk:"property_type"`
+	Verified       types.Bool   `tfsdk:"verified"`
+	Hidden         types.Bool   `tfsdk:"hidden"`
+}
+
+func (r *propertyDefinitionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_property_definition"
+}
+
+func (r *propertyDefinitionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the metadata of a Posthog Property Definition. Property definitions are created by PostHog when a property is first ingested: this resource adopts an existing definition, and resets its metadata when destroyed.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID (UUID) of the property definition",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the property",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the property",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of the property, one of `event`, `person` or `group`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(posthog.PropertyDefinitionTypeEvent)),
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(posthog.PropertyDefinitionTypeEvent),
+						string(posthog.PropertyDefinitionTypePerson),
+						string(posthog.PropertyDefinitionTypeGroup),
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"group_type_index": schema.Int64Attribute{
+				MarkdownDescription: "Index of the group type, for `group` properties",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the property",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"tags": schema.ListAttribute{
+				MarkdownDescription: "Property tags",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				Default:             listdefault.StaticValue(types.ListNull(types.StringType)),
+			},
+			"property_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the values of the property, one of `String`, `Numeric`, `Boolean` or `DateTime`. Detected by PostHog if unset.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(posthog.PropertyTypeString),
+						string(posthog.PropertyTypeNumeric),
+						string(posthog.PropertyTypeBoolean),
+						string(posthog.PropertyTypeDateTime),
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"verified": schema.BoolAttribute{
+				MarkdownDescription: "Whether the property is verified, verified properties are suggested first in filters",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"hidden": schema.BoolAttribute{
+				MarkdownDescription: "Whether the property is hidden from filters",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *propertyDefinitionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *propertyDefinitionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data propertyDefinitionResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Type.IsUnknown() || data.GroupTypeIndex.IsUnknown() {
+		return
+	}
+
+	isGroup := data.Type.ValueString() == string(posthog.PropertyDefinitionTypeGroup)
+
+	if isGroup && data.GroupTypeIndex.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("group_type_index"), "Missing group type index", "Group properties must set the index of their group type.")
+	}
+
+	if !isGroup && !data.GroupTypeIndex.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("group_type_index"), "Invalid group type index", "Only group properties can set a group type index.")
+	}
+}
+
+func updatePropertyDefinitionModel(ctx context.Context, model *propertyDefinitionResourceModel, apiDefinition *posthog.PropertyDefinition) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(apiDefinition.ID.String())
+	model.Name = types.StringValue(apiDefinition.Name)
+	model.Description = types.StringValue(apiDefinition.Description)
+	model.Verified = types.BoolValue(apiDefinition.Verified)
+	model.Hidden = types.BoolValue(apiDefinition.Hidden)
+
+	if apiDefinition.PropertyType != nil {
+		model.PropertyType = types.StringValue(string(*apiDefinition.PropertyType))
+	} else {
+		model.PropertyType = types.StringNull()
+	}
+
+	model.Tags, diags = types.ListValueFrom(ctx, types.StringType, sortedStrings(apiDefinition.Tags))
+
+	return diags
+}
+
+func propertyDefinitionFromModel(ctx context.Context, data propertyDefinitionResourceModel) (posthog.UpdatePropertyDefinitionRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	req := posthog.UpdatePropertyDefinitionRequest{
+		Description: data.Description.ValueString(),
+		Verified:    data.Verified.ValueBool(),
+		Hidden:      data.Hidden.ValueBool(),
+	}
+
+	if !data.PropertyType.IsNull() && !data.PropertyType.IsUnknown() {
+		propertyType := posthog.PropertyType(data.PropertyType.ValueString())
+		req.PropertyType = &propertyType
+	}
+
+	diags.Append(data.Tags.ElementsAs(ctx, &req.Tags, false)...)
+
+	return req, diags
+}
+
+func (r *propertyDefinitionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data propertyDefinitionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateRequest, diags := propertyDefinitionFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	// Adopt the existing definition
+
+	definition, err := r.client.GetPropertyDefinitionByName(ctx, projectID, posthog.PropertyDefinitionType(data.Type.ValueString()), data.GroupTypeIndex.ValueInt64(), data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error looking up property definition %s: %s", data.Name, err))
+		return
+	}
+
+	if definition == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Property definition not found", fmt.Sprintf("No %s property named %s was ingested in project %s yet, its definition does not exist.", data.Type.ValueString(), data.Name, projectID))
+		return
+	}
+
+	res, err := r.client.UpdatePropertyDefinition(ctx, projectID, definition.ID, updateRequest)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating property definition %s: %s", definition.ID, err))
+		return
+	}
+
+	resp.Diagnostics.Append(updatePropertyDefinitionModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "adopted property definition", map[string]interface{}{"property_definition_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *propertyDefinitionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data propertyDefinitionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	var res *posthog.PropertyDefinition
+
+	if data.ID.IsNull() {
+		// imported by name
+		res, err = r.client.GetPropertyDefinitionByName(ctx, projectID, posthog.PropertyDefinitionType(data.Type.ValueString()), data.GroupTypeIndex.ValueInt64(), data.Name.ValueString())
+	} else {
+		res, err = r.client.GetPropertyDefinition(ctx, projectID, posthog.PropertyDefinitionID(data.ID.ValueString()))
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting property definition %s: %s", data.Name, err))
+		return
+	}
+
+	if res == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(updatePropertyDefinitionModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read property definition", map[string]interface{}{"property_definition_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *propertyDefinitionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data propertyDefinitionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateRequest, diags := propertyDefinitionFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	res, err := r.client.UpdatePropertyDefinition(ctx, projectID, posthog.PropertyDefinitionID(data.ID.ValueString()), updateRequest)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating property definition %s: %s", data.ID, err))
+		return
+	}
+
+	resp.Diagnostics.Append(updatePropertyDefinitionModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated property definition", map[string]interface{}{"property_definition_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *propertyDefinitionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data propertyDefinitionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	// The definition belongs to PostHog, only reset its metadata. The property
+	// type is left as is, since it is normally detected during ingestion.
+	_, err = r.client.UpdatePropertyDefinition(ctx, projectID, posthog.PropertyDefinitionID(data.ID.ValueString()), posthog.UpdatePropertyDefinitionRequest{})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error resetting property definition %s: %s", data.ID, err))
+		return
+	}
+}
+
+func (r *propertyDefinitionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	const importIDError = "ID not of the form PROJECT_ID/TYPE/PROPERTY_NAME, or PROJECT_ID/group/GROUP_TYPE_INDEX/PROPERTY_NAME for group properties"
+
+	tokens := strings.SplitN(req.ID, "/", 3)
+	if len(tokens) != 3 {
+		resp.Diagnostics.AddError("Invalid import ID", importIDError)
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(tokens[0])
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("invalid project ID: %s", err))
+		return
+	}
+
+	definitionType, name := tokens[1], tokens[2]
+
+	if definitionType == string(posthog.PropertyDefinitionTypeGroup) {
+		groupTokens := strings.SplitN(name, "/", 2)
+		if len(groupTokens) != 2 {
+			resp.Diagnostics.AddError("Invalid import ID", importIDError)
+			return
+		}
+
+		groupTypeIndex, err := strconv.ParseInt(groupTokens[0], 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("invalid group type index: %s", err))
+			return
+		}
+
+		name = groupTokens[1]
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_type_index"), groupTypeIndex)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), definitionType)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
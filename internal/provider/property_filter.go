@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+type propertyFilter struct {
+	Key            string      `tfsdk:"key"`
+	Type           string      `tfsdk:"type"`
+	Operator       string      `tfsdk:"operator"`
+	Values         []string    `tfsdk:"values"`
+	GroupTypeIndex types.Int64 `tfsdk:"group_type_index"`
+}
+
+var propertyFilterTypes = []string{
+	string(posthog.PropertyFilterTypePerson),
+	string(posthog.PropertyFilterTypeEvent),
+	string(posthog.PropertyFilterTypeGroup),
+	string(posthog.PropertyFilterTypeElement),
+	string(posthog.PropertyFilterTypeSession),
+	string(posthog.PropertyFilterTypeHogQL),
+}
+
+var propertyOperators = []string{
+	string(posthog.PropertyOperatorExact),
+	string(posthog.PropertyOperatorIsNot),
+	string(posthog.PropertyOperatorIContains),
+	string(posthog.PropertyOperatorNotIContains),
+	string(posthog.PropertyOperatorRegex),
+	string(posthog.PropertyOperatorNotRegex),
+	string(posthog.PropertyOperatorGT),
+	string(posthog.PropertyOperatorGTE),
+	string(posthog.PropertyOperatorLT),
+	string(posthog.PropertyOperatorLTE),
+	string(posthog.PropertyOperatorIsSet),
+	string(posthog.PropertyOperatorIsNotSet),
+	string(posthog.PropertyOperatorIsDateBefore),
+	string(posthog.PropertyOperatorIsDateAfter),
+	string(posthog.PropertyOperatorIsDateExact),
+}
+
+func propertyFilterAttributes(defaultType posthog.PropertyFilterType) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"key": schema.StringAttribute{
+			MarkdownDescription: "Name of the property",
+			Required:            true,
+		},
+		"type": schema.StringAttribute{
+			MarkdownDescription: "Type of the property, one of `person`, `event`, `group`, `element`, `session` or `hogql`",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString(string(defaultType)),
+			Validators: []validator.String{
+				stringvalidator.OneOf(propertyFilterTypes...),
+			},
+		},
+		"operator": schema.StringAttribute{
+			MarkdownDescription: "Comparison operator, for example `exact`, `is_not`, `icontains`, `regex`, `gt`, `lt` or `is_set`",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString(string(posthog.PropertyOperatorExact)),
+			Validators: []validator.String{
+				stringvalidator.OneOf(propertyOperators...),
+			},
+		},
+		"values": schema.ListAttribute{
+			MarkdownDescription: "Values to compare the property against. Matches if any of the values matches. Leave empty for `is_set` and `is_not_set`.",
+			ElementType:         types.StringType,
+			Optional:            true,
+		},
+		"group_type_index": schema.Int64Attribute{
+			MarkdownDescription: "Index of the group type, for `group` properties",
+			Optional:            true,
+		},
+	}
+}
+
+func propertyFiltersSchema(description string, defaultType posthog.PropertyFilterType) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: description,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: propertyFilterAttributes(defaultType),
+		},
+		Optional: true,
+	}
+}
+
+func propertyFiltersFromModel(filters []propertyFilter) []posthog.PropertyFilter {
+	var res []posthog.PropertyFilter
+
+	for _, f := range filters {
+		res = append(res, posthog.PropertyFilter{
+			Key:            f.Key,
+			Type:           posthog.PropertyFilterType(f.Type),
+			Operator:       posthog.PropertyOperator(f.Operator),
+			Value:          posthog.PropertyValue(f.Values),
+			GroupTypeIndex: f.GroupTypeIndex.ValueInt64Pointer(),
+		})
+	}
+
+	return res
+}
+
+func propertyFiltersToModel(filters []posthog.PropertyFilter) []propertyFilter {
+	var res []propertyFilter
+
+	for _, f := range filters {
+		operator := f.Operator
+		if operator == "" {
+			operator = posthog.PropertyOperatorExact
+		}
+
+		res = append(res, propertyFilter{
+			Key:            f.Key,
+			Type:           string(f.Type),
+			Operator:       string(operator),
+			Values:         f.Value,
+			GroupTypeIndex: types.Int64PointerValue(f.GroupTypeIndex),
+		})
+	}
+
+	return res
+}
@@ -87,14 +87,48 @@ func (p *postHogProvider) Configure(ctx context.Context, req provider.ConfigureR
 
 func (p *postHogProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		newAccessControlResource,
 		newActionResource,
+		newAlertResource,
+		newAnnotationResource,
+		newBatchExportResource,
+		newCohortResource,
+		newDashboardResource,
+		newEarlyAccessFeatureResource,
+		newErrorTrackingAssignmentRuleResource,
+		newErrorTrackingGroupingRuleResource,
+		newErrorTrackingSuppressionRuleResource,
+		newErrorTrackingSymbolSetResource,
+		newEventDefinitionResource,
+		newExperimentResource,
+		newFeatureFlagResource,
+		newGroupTypeResource,
+		newHogFunctionResource,
+		newInsightResource,
+		newIntegrationResource,
+		newNotebookResource,
+		newOrganizationInviteResource,
+		newOrganizationMembershipResource,
 		newProjectResource,
+		newPropertyDefinitionResource,
+		newRoleResource,
+		newRoleMembershipResource,
+		newSessionRecordingPlaylistResource,
+		newSubscriptionResource,
+		newSurveyResource,
+		newWarehouseSavedQueryResource,
+		newWarehouseSourceResource,
+		newWarehouseTableResource,
+		newWarehouseViewLinkResource,
 	}
 }
 
 func (p *postHogProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
-		//NewExampleDataSource,
+		newFeatureFlagDataSource,
+		newGroupTypeDataSource,
+		newHogFunctionTemplateDataSource,
+		newIntegrationDataSource,
 	}
 }
 
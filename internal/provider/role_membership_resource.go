@@ -0,0 +1,255 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+var _ resource.Resource = &roleMembershipResource{}
+var _ resource.ResourceWithImportState = &roleMembershipResource{}
+
+func newRoleMembershipResource() resource.Resource {
+	return &roleMembershipResource{}
+}
+
+type roleMembershipResource struct {
+	client *posthog.Client
+}
+
+type roleMembershipResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	OrganizationID types.String `tfsdk:"organization_id"`
+	RoleID         types.String `tfsdk:"role_id"`
+	UserUUID       types.String `tfsdk:"user_uuid"`
+}
+
+func (r *roleMembershipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_membership"
+}
+
+func (r *roleMembershipResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Role Membership",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the role membership",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": organizationIDAttribute("ID of the organization of the role"),
+			"role_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the role",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_uuid": schema.StringAttribute{
+				MarkdownDescription: "UUID of the user, for example the `id` of a `posthog_organization_membership`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *roleMembershipResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func updateRoleMembershipModel(model *roleMembershipResourceModel, apiMembership *posthog.RoleMembership) {
+	model.ID = types.StringValue(apiMembership.ID)
+	model.RoleID = types.StringValue(apiMembership.RoleID.String())
+	model.UserUUID = types.StringValue(apiMembership.User.UUID)
+}
+
+func (r *roleMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data roleMembershipResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID, err := posthog.OrganizationIDFromString(data.OrganizationID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid organization ID", err.Error())
+		return
+	}
+
+	roleID, err := posthog.RoleIDFromString(data.RoleID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("role_id"), "Invalid role ID", err.Error())
+		return
+	}
+
+	res, err := r.client.CreateRoleMembership(ctx, organizationID, roleID, posthog.CreateRoleMembershipRequest{UserUUID: data.UserUUID.ValueString()})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating role membership: %s", err))
+		return
+	}
+
+	updateRoleMembershipModel(&data, res)
+
+	tflog.Trace(ctx, "created role membership", map[string]interface{}{"role_membership_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *roleMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data roleMembershipResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID, err := posthog.OrganizationIDFromString(data.OrganizationID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid organization ID", err.Error())
+		return
+	}
+
+	roleID, err := posthog.RoleIDFromString(data.RoleID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid role ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetRoleMembership(ctx, organizationID, roleID, data.UserUUID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting role membership %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// The role ID is not always returned when listing memberships
+	res.RoleID = roleID
+	updateRoleMembershipModel(&data, res)
+
+	tflog.Trace(ctx, "read role membership", map[string]interface{}{"role_membership_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *roleMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Changing the role or the user requires replacing the membership, only
+	// the organization ID can change here, from @current to the actual ID or
+	// the opposite.
+	var data roleMembershipResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *roleMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data roleMembershipResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID, err := posthog.OrganizationIDFromString(data.OrganizationID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid organization ID", err.Error())
+		return
+	}
+
+	roleID, err := posthog.RoleIDFromString(data.RoleID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid role ID", err.Error())
+		return
+	}
+
+	if err := r.client.DeleteRoleMembership(ctx, organizationID, roleID, data.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting role membership %s: %s", data.ID, err))
+		return
+	}
+}
+
+// roleMembershipImportID is the ROLE_ID/USER_UUID part of the import ID of
+// role memberships.
+type roleMembershipImportID struct {
+	roleID   posthog.RoleID
+	userUUID string
+}
+
+func roleMembershipImportIDFromString(s string) (roleMembershipImportID, error) {
+	roleID, userUUID, ok := strings.Cut(s, "/")
+	if !ok || userUUID == "" {
+		return roleMembershipImportID{}, errors.New("ID not of the form ROLE_ID/USER_UUID")
+	}
+
+	parsedRoleID, err := posthog.RoleIDFromString(roleID)
+	if err != nil {
+		return roleMembershipImportID{}, err
+	}
+
+	return roleMembershipImportID{roleID: parsedRoleID, userUUID: userUUID}, nil
+}
+
+func (r *roleMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	organizationID, membershipID, err := parseOrganizationImportID(req.ID, "role membership", roleMembershipImportIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), organizationID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_id"), membershipID.roleID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_uuid"), membershipID.userUUID)...)
+}
@@ -0,0 +1,230 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+var _ resource.Resource = &roleResource{}
+var _ resource.ResourceWithImportState = &roleResource{}
+
+func newRoleResource() resource.Resource {
+	return &roleResource{}
+}
+
+type roleResource struct {
+	client *posthog.Client
+}
+
+type roleResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	OrganizationID types.String `tfsdk:"organization_id"`
+	Name           types.String `tfsdk:"name"`
+}
+
+func (r *roleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role"
+}
+
+func (r *roleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Role. Members are added with `posthog_role_membership`, and access (to projects, to individual resources, or to all the resources of a type, eg. all feature flags) is granted with `posthog_access_control`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the role",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": organizationIDAttribute("ID of the organization of the role"),
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the role",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (r *roleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func updateRoleModel(model *roleResourceModel, apiRole *posthog.Role) {
+	model.ID = types.StringValue(apiRole.ID.String())
+	model.Name = types.StringValue(apiRole.Name)
+}
+
+func (r *roleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data roleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID, err := posthog.OrganizationIDFromString(data.OrganizationID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid organization ID", err.Error())
+		return
+	}
+
+	res, err := r.client.CreateRole(ctx, organizationID, posthog.CreateRoleRequest{Name: data.Name.ValueString()})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating role: %s", err))
+		return
+	}
+
+	updateRoleModel(&data, res)
+
+	tflog.Trace(ctx, "created role", map[string]interface{}{"role_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *roleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data roleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID, err := posthog.OrganizationIDFromString(data.OrganizationID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid organization ID", err.Error())
+		return
+	}
+
+	roleID, err := posthog.RoleIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid role ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetRole(ctx, organizationID, roleID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting role %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	updateRoleModel(&data, res)
+
+	tflog.Trace(ctx, "read role", map[string]interface{}{"role_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data roleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID, err := posthog.OrganizationIDFromString(data.OrganizationID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid organization ID", err.Error())
+		return
+	}
+
+	role := posthog.Role{
+		Name: data.Name.ValueString(),
+	}
+
+	role.ID, err = posthog.RoleIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid role ID", err.Error())
+		return
+	}
+
+	res, err := r.client.UpdateRole(ctx, organizationID, role)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating role %s: %s", role.ID, err))
+		return
+	}
+
+	updateRoleModel(&data, res)
+
+	tflog.Trace(ctx, "updated role", map[string]interface{}{"role_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *roleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data roleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID, err := posthog.OrganizationIDFromString(data.OrganizationID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid organization ID", err.Error())
+		return
+	}
+
+	roleID, err := posthog.RoleIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid role ID", err.Error())
+		return
+	}
+
+	if err := r.client.DeleteRole(ctx, organizationID, roleID); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting role %s: %s", data.ID, err))
+		return
+	}
+}
+
+func (r *roleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	organizationID, roleID, err := parseOrganizationImportID(req.ID, "role", posthog.RoleIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), roleID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), organizationID.String())...)
+}
@@ -0,0 +1,548 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+	"github.com/abustany/terraform-provider-posthog/internal/typeutil"
+)
+
+var _ resource.Resource = &sessionRecordingPlaylistResource{}
+var _ resource.ResourceWithImportState = &sessionRecordingPlaylistResource{}
+
+func newSessionRecordingPlaylistResource() resource.Resource {
+	return &sessionRecordingPlaylistResource{}
+}
+
+type sessionRecordingPlaylistResource struct {
+	client *posthog.Client
+}
+
+type sessionRecordingPlaylistResourceModel struct {
+	ID          types.String                     `tfsdk:"id"`
+	ProjectID   types.String                     `tfsdk:"project_id"`
+	Name        types.String                     `tfsdk:"name"`
+	DerivedName types.String                     `tfsdk:"derived_name"`
+	Description types.String                     `tfsdk:"description"`
+	Pinned      types.Bool                       `tfsdk:"pinned"`
+	Filters     *sessionRecordingPlaylistFilters `tfsdk:"filters"`
+}
+
+type sessionRecordingPlaylistFilters struct {
+	DateFrom           types.String                      `tfsdk:"date_from"`
+	DateTo             types.String                      `tfsdk:"date_to"`
+	Match              types.String                      `tfsdk:"match"`
+	Duration           *sessionRecordingPlaylistDuration `tfsdk:"duration"`
+	Events             []eventFilter                     `tfsdk:"events"`
+	Actions            []actionFilter                    `tfsdk:"actions"`
+	Properties         []propertyFilter                  `tfsdk:"properties"`
+	ConsoleLogLevels   []string                          `tfsdk:"console_log_levels"`
+	ConsoleLogMessage  types.String                      `tfsdk:"console_log_message"`
+	FilterTestAccounts types.Bool                        `tfsdk:"filter_test_accounts"`
+}
+
+type sessionRecordingPlaylistDuration struct {
+	Type     string `tfsdk:"type"`
+	Operator string `tfsdk:"operator"`
+	Seconds  int64  `tfsdk:"seconds"`
+}
+
+const (
+	sessionRecordingPlaylistMatchAll = "all"
+	sessionRecordingPlaylistMatchAny = "any"
+)
+
+func (r *sessionRecordingPlaylistResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_session_recording_playlist"
+}
+
+func (r *sessionRecordingPlaylistResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Session Recording Playlist, a saved list of the session recordings matching filters.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Short ID of the playlist, as found in its URL (`/project/PROJECT_ID/replay/playlists/SHORT_ID`)",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the playlist",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the playlist. If empty, PostHog displays the derived name instead.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"derived_name": schema.StringAttribute{
+				MarkdownDescription: "Name generated from the filters, like the web UI does, displayed when `name` is empty",
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the playlist",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"pinned": schema.BoolAttribute{
+				MarkdownDescription: "Whether the playlist is pinned at the top of the list of playlists",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"filters": schema.SingleNestedAttribute{
+				MarkdownDescription: "Recordings listed in the playlist: recordings matching all (or any, see `match`) of `events`, `actions`, `properties` and the console log filters, and matching `duration`",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"date_from": schema.StringAttribute{
+						MarkdownDescription: "Start of the date range of the recordings, either a date (`2024-01-01`) or relative to the current time (eg. `-7d`)",
+						Optional:            true,
+						Computed:            true,
+						Default:             stringdefault.StaticString("-3d"),
+					},
+					"date_to": schema.StringAttribute{
+						MarkdownDescription: "End of the date range of the recordings, the current time if unset",
+						Optional:            true,
+					},
+					"match": schema.StringAttribute{
+						MarkdownDescription: "Whether recordings must match `all` or `any` of the filters",
+						Optional:            true,
+						Computed:            true,
+						Default:             stringdefault.StaticString(sessionRecordingPlaylistMatchAll),
+						Validators: []validator.String{
+							stringvalidator.OneOf(sessionRecordingPlaylistMatchAll, sessionRecordingPlaylistMatchAny),
+						},
+					},
+					"duration": schema.SingleNestedAttribute{
+						MarkdownDescription: "Filter on the duration of the recordings",
+						Optional:            true,
+						Attributes: map[string]schema.Attribute{
+							"type": schema.StringAttribute{
+								MarkdownDescription: "Duration the filter applies to: `duration` (total duration), `active_seconds` or `inactive_seconds`",
+								Optional:            true,
+								Computed:            true,
+								Default:             stringdefault.StaticString("duration"),
+								Validators: []validator.String{
+									stringvalidator.OneOf("duration", "active_seconds", "inactive_seconds"),
+								},
+							},
+							"operator": schema.StringAttribute{
+								MarkdownDescription: "`gt` for recordings longer than `seconds`, `lt` for shorter recordings",
+								Optional:            true,
+								Computed:            true,
+								Default:             stringdefault.StaticString(string(posthog.PropertyOperatorGT)),
+								Validators: []validator.String{
+									stringvalidator.OneOf(string(posthog.PropertyOperatorGT), string(posthog.PropertyOperatorLT)),
+								},
+							},
+							"seconds": schema.Int64Attribute{
+								MarkdownDescription: "Duration, in seconds",
+								Required:            true,
+								Validators: []validator.Int64{
+									int64validator.AtLeast(0),
+								},
+							},
+						},
+					},
+					"events":     eventFiltersSchema("Events that must happen during the recordings"),
+					"actions":    actionFiltersSchema("Actions that must happen during the recordings"),
+					"properties": propertyFiltersSchema("Filters on the properties of the person, or of the session", posthog.PropertyFilterTypePerson),
+					"console_log_levels": schema.SetAttribute{
+						MarkdownDescription: "Levels of the console logs that must appear in the recordings: `info`, `warn` or `error`",
+						ElementType:         types.StringType,
+						Optional:            true,
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+							setvalidator.ValueStringsAre(stringvalidator.OneOf("info", "warn", "error")),
+						},
+					},
+					"console_log_message": schema.StringAttribute{
+						MarkdownDescription: "Text that a console log of the recordings must contain",
+						Optional:            true,
+					},
+					"filter_test_accounts": schema.BoolAttribute{
+						MarkdownDescription: "Whether to ignore the recordings of internal and test users",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *sessionRecordingPlaylistResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func sessionRecordingPlaylistFiltersFromModel(filters *sessionRecordingPlaylistFilters) posthog.SessionRecordingFilters {
+	res := posthog.SessionRecordingFilters{
+		DateFrom:    "-3d",
+		Duration:    []posthog.SessionRecordingDurationFilter{},
+		FilterGroup: posthog.SessionRecordingFilterGroup{Type: posthog.PropertyGroupTypeAnd},
+	}
+
+	if filters == nil {
+		return res
+	}
+
+	res.DateFrom = filters.DateFrom.ValueString()
+	res.DateTo = filters.DateTo.ValueStringPointer()
+	res.FilterTestAccounts = filters.FilterTestAccounts.ValueBool()
+
+	if filters.Match.ValueString() == sessionRecordingPlaylistMatchAny {
+		res.FilterGroup.Type = posthog.PropertyGroupTypeOr
+	}
+
+	if d := filters.Duration; d != nil {
+		res.Duration = append(res.Duration, posthog.SessionRecordingDurationFilter{
+			Key:      d.Type,
+			Type:     posthog.PropertyFilterTypeRecording,
+			Operator: posthog.PropertyOperator(d.Operator),
+			Value:    d.Seconds,
+		})
+	}
+
+	events, actions := entityFiltersFromModel(filters.Events, filters.Actions)
+	res.FilterGroup.Entities = append(events, actions...)
+	res.FilterGroup.Properties = propertyFiltersFromModel(filters.Properties)
+
+	if len(filters.ConsoleLogLevels) > 0 {
+		res.FilterGroup.Properties = append(res.FilterGroup.Properties, posthog.PropertyFilter{
+			Key:      "level",
+			Type:     posthog.PropertyFilterTypeLogEntry,
+			Operator: posthog.PropertyOperatorExact,
+			Value:    sortedStrings(filters.ConsoleLogLevels),
+		})
+	}
+
+	if !filters.ConsoleLogMessage.IsNull() {
+		res.FilterGroup.Properties = append(res.FilterGroup.Properties, posthog.PropertyFilter{
+			Key:      "message",
+			Type:     posthog.PropertyFilterTypeLogEntry,
+			Operator: posthog.PropertyOperatorIContains,
+			Value:    posthog.PropertyValue{filters.ConsoleLogMessage.ValueString()},
+		})
+	}
+
+	return res
+}
+
+func sessionRecordingPlaylistFiltersToModel(f posthog.SessionRecordingFilters, prior *sessionRecordingPlaylistFilters) *sessionRecordingPlaylistFilters {
+	if prior == nil && len(f.Duration) == 0 && len(f.FilterGroup.Entities) == 0 && len(f.FilterGroup.Properties) == 0 && f.DateTo == nil && !f.FilterTestAccounts {
+		return nil
+	}
+
+	res := &sessionRecordingPlaylistFilters{
+		DateFrom:           types.StringValue(f.DateFrom),
+		DateTo:             types.StringPointerValue(f.DateTo),
+		Match:              types.StringValue(sessionRecordingPlaylistMatchAll),
+		ConsoleLogMessage:  types.StringNull(),
+		FilterTestAccounts: types.BoolValue(f.FilterTestAccounts),
+	}
+
+	if f.FilterGroup.Type == posthog.PropertyGroupTypeOr {
+		res.Match = types.StringValue(sessionRecordingPlaylistMatchAny)
+	}
+
+	for _, d := range f.Duration {
+		res.Duration = &sessionRecordingPlaylistDuration{Type: d.Key, Operator: string(d.Operator), Seconds: d.Value}
+	}
+
+	res.Events, res.Actions = entityFiltersToModel(f.FilterGroup.Entities)
+
+	var properties []posthog.PropertyFilter
+
+	for _, p := range f.FilterGroup.Properties {
+		switch {
+		case p.Type == posthog.PropertyFilterTypeLogEntry && p.Key == "level":
+			res.ConsoleLogLevels = sortedStrings(p.Value)
+		case p.Type == posthog.PropertyFilterTypeLogEntry && p.Key == "message" && len(p.Value) > 0:
+			res.ConsoleLogMessage = types.StringValue(p.Value[0])
+		default:
+			properties = append(properties, p)
+		}
+	}
+
+	res.Properties = propertyFiltersToModel(properties)
+
+	return res
+}
+
+// sessionRecordingPlaylistDerivedName summarizes the filters of a playlist,
+// for playlists without a name.
+func sessionRecordingPlaylistDerivedName(f posthog.SessionRecordingFilters) string {
+	var parts []string
+
+	for _, e := range f.FilterGroup.Entities {
+		if e.Type == posthog.InsightEntityTypeActions {
+			parts = append(parts, "action "+e.ID)
+		} else {
+			parts = append(parts, e.ID)
+		}
+	}
+
+	for _, p := range f.FilterGroup.Properties {
+		switch {
+		case p.Type == posthog.PropertyFilterTypeLogEntry && p.Key == "level":
+			parts = append(parts, strings.Join(p.Value, " or ")+" console logs")
+		case p.Type == posthog.PropertyFilterTypeLogEntry && p.Key == "message":
+			parts = append(parts, "console logs containing "+strings.Join(p.Value, ""))
+		default:
+			parts = append(parts, p.Key)
+		}
+	}
+
+	separator := ", "
+	if f.FilterGroup.Type == posthog.PropertyGroupTypeOr {
+		separator = " or "
+	}
+
+	res := strings.Join(parts, separator)
+
+	for _, d := range f.Duration {
+		comparison := "longer"
+		if d.Operator == posthog.PropertyOperatorLT {
+			comparison = "shorter"
+		}
+
+		duration := fmt.Sprintf("%s than %ds", comparison, d.Value)
+		if res == "" {
+			res = duration
+		} else {
+			res += ", " + duration
+		}
+	}
+
+	if res == "" {
+		return ""
+	}
+
+	return "Recordings with " + res
+}
+
+func updateSessionRecordingPlaylistModel(model *sessionRecordingPlaylistResourceModel, apiPlaylist *posthog.SessionRecordingPlaylist) {
+	model.ID = types.StringValue(apiPlaylist.ID.String())
+	model.Name = types.StringValue(apiPlaylist.Name)
+	model.DerivedName = typeutil.NullableStringValue(apiPlaylist.DerivedName)
+	model.Description = types.StringValue(apiPlaylist.Description)
+	model.Pinned = types.BoolValue(apiPlaylist.Pinned)
+	model.Filters = sessionRecordingPlaylistFiltersToModel(apiPlaylist.Filters, model.Filters)
+}
+
+func sessionRecordingPlaylistFromModel(data sessionRecordingPlaylistResourceModel) (posthog.ProjectID, posthog.SessionRecordingPlaylist, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		diags.AddError("Invalid project ID", err.Error())
+		return posthog.ProjectID(0), posthog.SessionRecordingPlaylist{}, diags
+	}
+
+	playlistID, err := posthog.SessionRecordingPlaylistIDFromString(data.ID.ValueString())
+	if err != nil {
+		diags.AddError("Invalid session recording playlist ID", err.Error())
+		return posthog.ProjectID(0), posthog.SessionRecordingPlaylist{}, diags
+	}
+
+	filters := sessionRecordingPlaylistFiltersFromModel(data.Filters)
+
+	playlist := posthog.SessionRecordingPlaylist{
+		ID:          playlistID,
+		Name:        data.Name.ValueString(),
+		DerivedName: sessionRecordingPlaylistDerivedName(filters),
+		Description: data.Description.ValueString(),
+		Pinned:      data.Pinned.ValueBool(),
+		Filters:     filters,
+	}
+
+	return projectID, playlist, diags
+}
+
+func (r *sessionRecordingPlaylistResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data sessionRecordingPlaylistResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filters := sessionRecordingPlaylistFiltersFromModel(data.Filters)
+
+	createSessionRecordingPlaylistRequest := posthog.CreateSessionRecordingPlaylistRequest{
+		Name:        data.Name.ValueString(),
+		DerivedName: sessionRecordingPlaylistDerivedName(filters),
+		Description: data.Description.ValueString(),
+		Pinned:      data.Pinned.ValueBool(),
+		Filters:     filters,
+	}
+
+	// Create the playlist
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	res, err := r.client.CreateSessionRecordingPlaylist(ctx, projectID, createSessionRecordingPlaylistRequest)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating session recording playlist: %s", err))
+		return
+	}
+
+	updateSessionRecordingPlaylistModel(&data, res)
+
+	tflog.Trace(ctx, "created session recording playlist", map[string]interface{}{"session_recording_playlist_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *sessionRecordingPlaylistResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data sessionRecordingPlaylistResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	playlistID, err := posthog.SessionRecordingPlaylistIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid session recording playlist ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetSessionRecordingPlaylist(ctx, projectID, playlistID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting session recording playlist %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil || res.Deleted {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	updateSessionRecordingPlaylistModel(&data, res)
+
+	tflog.Trace(ctx, "read session recording playlist", map[string]interface{}{"session_recording_playlist_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *sessionRecordingPlaylistResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data sessionRecordingPlaylistResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, playlist, diags := sessionRecordingPlaylistFromModel(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, err := r.client.UpdateSessionRecordingPlaylist(ctx, projectID, playlist)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating session recording playlist %s: %s", playlist.ID, err))
+		return
+	}
+
+	updateSessionRecordingPlaylistModel(&data, res)
+
+	tflog.Trace(ctx, "updated session recording playlist", map[string]interface{}{"session_recording_playlist_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *sessionRecordingPlaylistResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data sessionRecordingPlaylistResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, playlist, diags := sessionRecordingPlaylistFromModel(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Playlists are soft deleted, like in the web UI
+	playlist.Deleted = true
+
+	_, err := r.client.UpdateSessionRecordingPlaylist(ctx, projectID, playlist)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting session recording playlist %s: %s", playlist.ID, err))
+		return
+	}
+}
+
+func (r *sessionRecordingPlaylistResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, playlistID, err := parseImportID(req.ID, "session recording playlist", posthog.SessionRecordingPlaylistIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), playlistID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
@@ -0,0 +1,590 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+	"github.com/abustany/terraform-provider-posthog/internal/typeutil"
+)
+
+var _ resource.Resource = &subscriptionResource{}
+var _ resource.ResourceWithImportState = &subscriptionResource{}
+var _ resource.ResourceWithConfigValidators = &subscriptionResource{}
+var _ resource.ResourceWithValidateConfig = &subscriptionResource{}
+
+func newSubscriptionResource() resource.Resource {
+	return &subscriptionResource{}
+}
+
+type subscriptionResource struct {
+	client *posthog.Client
+}
+
+type subscriptionResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	ProjectID     types.String `tfsdk:"project_id"`
+	DashboardID   types.String `tfsdk:"dashboard_id"`
+	InsightID     types.String `tfsdk:"insight_id"`
+	Title         types.String `tfsdk:"title"`
+	TargetType    types.String `tfsdk:"target_type"`
+	TargetValue   types.String `tfsdk:"target_value"`
+	Frequency     types.String `tfsdk:"frequency"`
+	Interval      types.Int64  `tfsdk:"interval"`
+	ByWeekday     types.Set    `tfsdk:"byweekday"`
+	BySetPos      types.Int64  `tfsdk:"bysetpos"`
+	StartDate     types.String `tfsdk:"start_date"`
+	UntilDate     types.String `tfsdk:"until_date"`
+	IntegrationID types.String `tfsdk:"integration_id"`
+	InviteMessage types.String `tfsdk:"invite_message"`
+	Summary       types.String `tfsdk:"summary"`
+}
+
+var subscriptionWeekdays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+func (r *subscriptionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_subscription"
+}
+
+func (r *subscriptionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Subscription, periodically sending an export of a dashboard or an insight by email, to Slack or to a webhook. The schedule follows the RRULE format of RFC 5545.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the subscription",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the subscription",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"dashboard_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the exported dashboard",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"insight_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the exported insight",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "Title of the subscription",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"target_type": schema.StringAttribute{
+				MarkdownDescription: "Where the export is sent: `email`, `slack` or `webhook`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(posthog.SubscriptionTargetTypeEmail),
+						string(posthog.SubscriptionTargetTypeSlack),
+						string(posthog.SubscriptionTargetTypeWebhook),
+					),
+				},
+			},
+			"target_value": schema.StringAttribute{
+				MarkdownDescription: "Comma separated email addresses for `email`, channel ID for `slack` (eg. `C0123456789|#reports`), URL for `webhook`",
+				Required:            true,
+			},
+			"frequency": schema.StringAttribute{
+				MarkdownDescription: "Period of the schedule: `daily`, `weekly`, `monthly` or `yearly`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(posthog.SubscriptionFrequencyDaily),
+						string(posthog.SubscriptionFrequencyWeekly),
+						string(posthog.SubscriptionFrequencyMonthly),
+						string(posthog.SubscriptionFrequencyYearly),
+					),
+				},
+			},
+			"interval": schema.Int64Attribute{
+				MarkdownDescription: "Number of periods between exports, eg. 2 with a `weekly` frequency for every other week",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(1),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"byweekday": schema.SetAttribute{
+				MarkdownDescription: "Days of the week the export is sent on (`monday` to `sunday`). Monthly and yearly subscriptions must also set `bysetpos`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(subscriptionWeekdays...)),
+				},
+			},
+			"bysetpos": schema.Int64Attribute{
+				MarkdownDescription: "For monthly and yearly subscriptions, occurrence of the `byweekday` days in the period: 1 to 4, or -1 for the last one (eg. 1 with `monday` for the first monday of the month)",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.OneOf(1, 2, 3, 4, -1),
+				},
+			},
+			"start_date": schema.StringAttribute{
+				MarkdownDescription: "Date and time of the first export, in RFC 3339 format (eg. `2024-01-01T09:00:00Z`), which also sets the time of the day of the exports",
+				Required:            true,
+			},
+			"until_date": schema.StringAttribute{
+				MarkdownDescription: "Date and time after which no export is sent, in RFC 3339 format",
+				Optional:            true,
+			},
+			"integration_id": schema.StringAttribute{
+				MarkdownDescription: "For `slack` subscriptions, ID of the Slack integration posting the exports, see the `posthog_integration` data source. The Slack integration of the project is used if unset.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"invite_message": schema.StringAttribute{
+				MarkdownDescription: "Message sent to the recipients when the subscription is created",
+				Optional:            true,
+			},
+			"summary": schema.StringAttribute{
+				MarkdownDescription: "Human readable summary of the schedule",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *subscriptionResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("dashboard_id"),
+			path.MatchRoot("insight_id"),
+		),
+	}
+}
+
+func (r *subscriptionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *subscriptionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data subscriptionResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Schedule
+
+	if !data.Frequency.IsUnknown() && !data.ByWeekday.IsUnknown() && !data.BySetPos.IsUnknown() {
+		frequency := posthog.SubscriptionFrequency(data.Frequency.ValueString())
+		isMonthlyOrYearly := frequency == posthog.SubscriptionFrequencyMonthly || frequency == posthog.SubscriptionFrequencyYearly
+
+		if !data.BySetPos.IsNull() && !isMonthlyOrYearly {
+			resp.Diagnostics.AddAttributeError(path.Root("bysetpos"), "Unexpected bysetpos", "bysetpos can only be used with monthly and yearly subscriptions.")
+		}
+
+		if !data.BySetPos.IsNull() && data.ByWeekday.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("byweekday"), "Missing byweekday", "bysetpos selects an occurrence of the byweekday days, which must be set.")
+		}
+
+		if isMonthlyOrYearly && !data.ByWeekday.IsNull() && data.BySetPos.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("bysetpos"), "Missing bysetpos", fmt.Sprintf("%s subscriptions sent on given days of the week must set bysetpos to select the occurrence of the days in the period.", frequency))
+		}
+	}
+
+	var startDate, untilDate time.Time
+	var err error
+
+	if !data.StartDate.IsUnknown() && !data.StartDate.IsNull() {
+		startDate, err = time.Parse(time.RFC3339, data.StartDate.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("start_date"), "Invalid start date", err.Error())
+		}
+	}
+
+	if !data.UntilDate.IsUnknown() && !data.UntilDate.IsNull() {
+		untilDate, err = time.Parse(time.RFC3339, data.UntilDate.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("until_date"), "Invalid until date", err.Error())
+		}
+	}
+
+	if !startDate.IsZero() && !untilDate.IsZero() && !untilDate.After(startDate) {
+		resp.Diagnostics.AddAttributeError(path.Root("until_date"), "Invalid until date", "The until date must be after the start date.")
+	}
+
+	// Target
+
+	if data.TargetType.IsUnknown() || data.TargetValue.IsUnknown() || data.TargetValue.IsNull() {
+		return
+	}
+
+	if !data.IntegrationID.IsNull() && !data.IntegrationID.IsUnknown() && posthog.SubscriptionTargetType(data.TargetType.ValueString()) != posthog.SubscriptionTargetTypeSlack {
+		resp.Diagnostics.AddAttributeError(path.Root("integration_id"), "Unexpected integration ID", "integration_id can only be used with slack subscriptions.")
+	}
+
+	targetValue := data.TargetValue.ValueString()
+
+	switch posthog.SubscriptionTargetType(data.TargetType.ValueString()) {
+	case posthog.SubscriptionTargetTypeEmail:
+		for _, email := range strings.Split(targetValue, ",") {
+			if !strings.Contains(email, "@") {
+				resp.Diagnostics.AddAttributeError(path.Root("target_value"), "Invalid email", fmt.Sprintf("%q is not an email address.", strings.TrimSpace(email)))
+			}
+		}
+	case posthog.SubscriptionTargetTypeWebhook:
+		if u, err := url.Parse(targetValue); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			resp.Diagnostics.AddAttributeError(path.Root("target_value"), "Invalid webhook URL", fmt.Sprintf("%q is not an HTTP URL.", targetValue))
+		}
+	}
+}
+
+func updateSubscriptionModel(ctx context.Context, model *subscriptionResourceModel, apiSubscription *posthog.Subscription) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(apiSubscription.ID.String())
+
+	if apiSubscription.Dashboard != nil {
+		model.DashboardID = types.StringValue(apiSubscription.Dashboard.String())
+	} else {
+		model.DashboardID = types.StringNull()
+	}
+
+	if apiSubscription.Insight != nil {
+		model.InsightID = types.StringValue(apiSubscription.Insight.String())
+	} else {
+		model.InsightID = types.StringNull()
+	}
+
+	model.Title = types.StringValue(apiSubscription.Title)
+	model.TargetType = types.StringValue(string(apiSubscription.TargetType))
+	model.TargetValue = types.StringValue(apiSubscription.TargetValue)
+	model.Frequency = types.StringValue(string(apiSubscription.Frequency))
+	model.Interval = types.Int64Value(apiSubscription.Interval)
+	model.BySetPos = types.Int64PointerValue(apiSubscription.BySetPos)
+	model.StartDate = typeutil.TimeValue(model.StartDate, &apiSubscription.StartDate)
+	model.UntilDate = typeutil.TimeValue(model.UntilDate, apiSubscription.UntilDate)
+	model.Summary = types.StringValue(apiSubscription.Summary)
+
+	if apiSubscription.Integration != nil {
+		model.IntegrationID = types.StringValue(apiSubscription.Integration.String())
+	} else {
+		model.IntegrationID = types.StringNull()
+	}
+
+	model.ByWeekday = types.SetNull(types.StringType)
+	if len(apiSubscription.ByWeekday) > 0 {
+		model.ByWeekday, diags = types.SetValueFrom(ctx, types.StringType, apiSubscription.ByWeekday)
+	}
+
+	return diags
+}
+
+func subscriptionFromModel(ctx context.Context, data subscriptionResourceModel) (posthog.CreateSubscriptionRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	s := posthog.CreateSubscriptionRequest{
+		Title:         data.Title.ValueString(),
+		TargetType:    posthog.SubscriptionTargetType(data.TargetType.ValueString()),
+		TargetValue:   data.TargetValue.ValueString(),
+		Frequency:     posthog.SubscriptionFrequency(data.Frequency.ValueString()),
+		Interval:      data.Interval.ValueInt64(),
+		BySetPos:      data.BySetPos.ValueInt64Pointer(),
+		InviteMessage: data.InviteMessage.ValueString(),
+	}
+
+	if !data.DashboardID.IsNull() {
+		dashboardID, err := posthog.DashboardIDFromString(data.DashboardID.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("dashboard_id"), "Invalid dashboard ID", err.Error())
+			return s, diags
+		}
+
+		s.Dashboard = &dashboardID
+	}
+
+	if !data.InsightID.IsNull() {
+		insightID, err := posthog.InsightIDFromString(data.InsightID.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("insight_id"), "Invalid insight ID", err.Error())
+			return s, diags
+		}
+
+		s.Insight = &insightID
+	}
+
+	if !data.IntegrationID.IsNull() && !data.IntegrationID.IsUnknown() {
+		integrationID, err := posthog.IntegrationIDFromString(data.IntegrationID.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("integration_id"), "Invalid integration ID", err.Error())
+			return s, diags
+		}
+
+		s.Integration = &integrationID
+	}
+
+	if !data.ByWeekday.IsNull() {
+		diags.Append(data.ByWeekday.ElementsAs(ctx, &s.ByWeekday, false)...)
+		if diags.HasError() {
+			return s, diags
+		}
+	}
+
+	startDate, err := time.Parse(time.RFC3339, data.StartDate.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("start_date"), "Invalid start date", err.Error())
+		return s, diags
+	}
+
+	s.StartDate = startDate
+
+	if !data.UntilDate.IsNull() {
+		untilDate, err := time.Parse(time.RFC3339, data.UntilDate.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("until_date"), "Invalid until date", err.Error())
+			return s, diags
+		}
+
+		s.UntilDate = &untilDate
+	}
+
+	return s, diags
+}
+
+func (r *subscriptionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data subscriptionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createSubscriptionRequest, diags := subscriptionFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create the subscription
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	res, err := r.client.CreateSubscription(ctx, projectID, createSubscriptionRequest)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating subscription: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(updateSubscriptionModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created subscription", map[string]interface{}{"subscription_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *subscriptionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data subscriptionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	subscriptionID, err := posthog.SubscriptionIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid subscription ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetSubscription(ctx, projectID, subscriptionID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting subscription %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil || res.Deleted {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(updateSubscriptionModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read subscription", map[string]interface{}{"subscription_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// updatedSubscriptionFromModel returns the subscription to send in PATCH
+// requests.
+func updatedSubscriptionFromModel(ctx context.Context, data subscriptionResourceModel) (posthog.ProjectID, posthog.Subscription, diag.Diagnostics) {
+	s, diags := subscriptionFromModel(ctx, data)
+	if diags.HasError() {
+		return 0, posthog.Subscription{}, diags
+	}
+
+	subscription := posthog.Subscription{
+		Dashboard:   s.Dashboard,
+		Insight:     s.Insight,
+		Title:       s.Title,
+		TargetType:  s.TargetType,
+		TargetValue: s.TargetValue,
+		Frequency:   s.Frequency,
+		Interval:    s.Interval,
+		ByWeekday:   s.ByWeekday,
+		BySetPos:    s.BySetPos,
+		StartDate:   s.StartDate,
+		UntilDate:   s.UntilDate,
+		Integration: s.Integration,
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		diags.AddError("Invalid project ID", err.Error())
+		return 0, subscription, diags
+	}
+
+	subscription.ID, err = posthog.SubscriptionIDFromString(data.ID.ValueString())
+	if err != nil {
+		diags.AddError("Invalid subscription ID", err.Error())
+		return 0, subscription, diags
+	}
+
+	return projectID, subscription, diags
+}
+
+func (r *subscriptionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data subscriptionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, subscription, diags := updatedSubscriptionFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, err := r.client.UpdateSubscription(ctx, projectID, subscription)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating subscription %s: %s", subscription.ID, err))
+		return
+	}
+
+	resp.Diagnostics.Append(updateSubscriptionModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated subscription", map[string]interface{}{"subscription_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *subscriptionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data subscriptionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, subscription, diags := updatedSubscriptionFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Subscriptions are soft deleted, like in the web UI
+	subscription.Deleted = true
+
+	_, err := r.client.UpdateSubscription(ctx, projectID, subscription)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting subscription %s: %s", subscription.ID, err))
+		return
+	}
+}
+
+func (r *subscriptionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, subscriptionID, err := parseImportID(req.ID, "subscription", posthog.SubscriptionIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), subscriptionID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
@@ -0,0 +1,1150 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+	"github.com/abustany/terraform-provider-posthog/internal/typeutil"
+)
+
+var _ resource.Resource = &surveyResource{}
+var _ resource.ResourceWithImportState = &surveyResource{}
+var _ resource.ResourceWithValidateConfig = &surveyResource{}
+
+func newSurveyResource() resource.Resource {
+	return &surveyResource{}
+}
+
+type surveyResource struct {
+	client *posthog.Client
+}
+
+type surveyResourceModel struct {
+	ID                     types.String      `tfsdk:"id"`
+	ProjectID              types.String      `tfsdk:"project_id"`
+	Name                   types.String      `tfsdk:"name"`
+	Description            types.String      `tfsdk:"description"`
+	Type                   types.String      `tfsdk:"type"`
+	Questions              types.List        `tfsdk:"questions"`
+	Appearance             *surveyAppearance `tfsdk:"appearance"`
+	Conditions             *surveyConditions `tfsdk:"conditions"`
+	LinkedFlagID           types.String      `tfsdk:"linked_flag_id"`
+	ResponsesLimit         types.Int64       `tfsdk:"responses_limit"`
+	IterationCount         types.Int64       `tfsdk:"iteration_count"`
+	IterationFrequencyDays types.Int64       `tfsdk:"iteration_frequency_days"`
+	StartDate              types.String      `tfsdk:"start_date"`
+	EndDate                types.String      `tfsdk:"end_date"`
+	Archived               types.Bool        `tfsdk:"archived"`
+}
+
+type surveyQuestion struct {
+	ID              types.String `tfsdk:"id"`
+	Type            types.String `tfsdk:"type"`
+	Question        types.String `tfsdk:"question"`
+	Description     types.String `tfsdk:"description"`
+	Optional        types.Bool   `tfsdk:"optional"`
+	ButtonText      types.String `tfsdk:"button_text"`
+	Choices         types.List   `tfsdk:"choices"`
+	HasOpenChoice   types.Bool   `tfsdk:"has_open_choice"`
+	ShuffleOptions  types.Bool   `tfsdk:"shuffle_options"`
+	Display         types.String `tfsdk:"display"`
+	Scale           types.Int64  `tfsdk:"scale"`
+	LowerBoundLabel types.String `tfsdk:"lower_bound_label"`
+	UpperBoundLabel types.String `tfsdk:"upper_bound_label"`
+	Link            types.String `tfsdk:"link"`
+	Branching       types.Object `tfsdk:"branching"`
+}
+
+type surveyBranching struct {
+	Type           types.String `tfsdk:"type"`
+	Index          types.Int64  `tfsdk:"index"`
+	ResponseValues types.Map    `tfsdk:"response_values"`
+}
+
+type surveyAppearance struct {
+	BackgroundColor            types.String `tfsdk:"background_color"`
+	BorderColor                types.String `tfsdk:"border_color"`
+	TextColor                  types.String `tfsdk:"text_color"`
+	SubmitButtonColor          types.String `tfsdk:"submit_button_color"`
+	SubmitButtonTextColor      types.String `tfsdk:"submit_button_text_color"`
+	Placeholder                types.String `tfsdk:"placeholder"`
+	Position                   types.String `tfsdk:"position"`
+	DisplayThankYouMessage     types.Bool   `tfsdk:"display_thank_you_message"`
+	ThankYouMessageHeader      types.String `tfsdk:"thank_you_message_header"`
+	ThankYouMessageDescription types.String `tfsdk:"thank_you_message_description"`
+	WhiteLabel                 types.Bool   `tfsdk:"white_label"`
+	ShuffleQuestions           types.Bool   `tfsdk:"shuffle_questions"`
+	PopupDelaySeconds          types.Int64  `tfsdk:"popup_delay_seconds"`
+	WidgetType                 types.String `tfsdk:"widget_type"`
+	WidgetLabel                types.String `tfsdk:"widget_label"`
+	WidgetSelector             types.String `tfsdk:"widget_selector"`
+	WidgetColor                types.String `tfsdk:"widget_color"`
+}
+
+type surveyConditions struct {
+	URL            types.String `tfsdk:"url"`
+	URLMatchType   types.String `tfsdk:"url_match_type"`
+	Selector       types.String `tfsdk:"selector"`
+	WaitPeriodDays types.Int64  `tfsdk:"wait_period_days"`
+}
+
+func (r *surveyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_survey"
+}
+
+func surveyQuestionsSchema() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "Questions of the survey",
+		Required:            true,
+		Validators: []validator.List{
+			listvalidator.SizeAtLeast(1),
+		},
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					MarkdownDescription: "ID of the question, used to link responses to it",
+					Computed:            true,
+					PlanModifiers: []planmodifier.String{
+						stringplanmodifier.UseStateForUnknown(),
+					},
+				},
+				"type": schema.StringAttribute{
+					MarkdownDescription: "Type of the question, one of `open`, `rating`, `single_choice`, `multiple_choice` or `link`",
+					Required:            true,
+					Validators: []validator.String{
+						stringvalidator.OneOf(
+							string(posthog.SurveyQuestionTypeOpen),
+							string(posthog.SurveyQuestionTypeRating),
+							string(posthog.SurveyQuestionTypeSingleChoice),
+							string(posthog.SurveyQuestionTypeMultipleChoice),
+							string(posthog.SurveyQuestionTypeLink),
+						),
+					},
+				},
+				"question": schema.StringAttribute{
+					MarkdownDescription: "Text of the question",
+					Required:            true,
+				},
+				"description": schema.StringAttribute{
+					MarkdownDescription: "Text displayed below the question",
+					Optional:            true,
+					Computed:            true,
+					Default:             stringdefault.StaticString(""),
+				},
+				"optional": schema.BoolAttribute{
+					MarkdownDescription: "Whether users can skip the question",
+					Optional:            true,
+					Computed:            true,
+					Default:             booldefault.StaticBool(false),
+				},
+				"button_text": schema.StringAttribute{
+					MarkdownDescription: "Text of the button submitting the answer",
+					Optional:            true,
+				},
+				"choices": schema.ListAttribute{
+					MarkdownDescription: "Possible answers, required for `single_choice` and `multiple_choice` questions",
+					ElementType:         types.StringType,
+					Optional:            true,
+					Validators: []validator.List{
+						listvalidator.SizeAtLeast(1),
+					},
+				},
+				"has_open_choice": schema.BoolAttribute{
+					MarkdownDescription: "Whether the last choice lets users type their own answer",
+					Optional:            true,
+					Computed:            true,
+					Default:             booldefault.StaticBool(false),
+				},
+				"shuffle_options": schema.BoolAttribute{
+					MarkdownDescription: "Whether choices are displayed in a random order",
+					Optional:            true,
+					Computed:            true,
+					Default:             booldefault.StaticBool(false),
+				},
+				"display": schema.StringAttribute{
+					MarkdownDescription: "How ratings are displayed, `number` or `emoji`. Required for `rating` questions.",
+					Optional:            true,
+					Validators: []validator.String{
+						stringvalidator.OneOf("number", "emoji"),
+					},
+				},
+				"scale": schema.Int64Attribute{
+					MarkdownDescription: "Maximum rating, one of 3, 5, 7 or 10 (emoji ratings only support 3 and 5). Required for `rating` questions.",
+					Optional:            true,
+					Validators: []validator.Int64{
+						int64validator.OneOf(3, 5, 7, 10),
+					},
+				},
+				"lower_bound_label": schema.StringAttribute{
+					MarkdownDescription: "Label of the lowest rating",
+					Optional:            true,
+				},
+				"upper_bound_label": schema.StringAttribute{
+					MarkdownDescription: "Label of the highest rating",
+					Optional:            true,
+				},
+				"link": schema.StringAttribute{
+					MarkdownDescription: "URL opened by `link` questions",
+					Optional:            true,
+				},
+				"branching": surveyBranchingSchema(),
+			},
+		},
+	}
+}
+
+func surveyBranchingSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "What to display after this question. The next question is displayed if unset.",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of branching: `next_question`, `end` (end the survey), `specific_question` (jump to the question at `index`) or `response_based` (depends on the answer, see `response_values`)",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(posthog.SurveyBranchingTypeNextQuestion),
+						string(posthog.SurveyBranchingTypeEnd),
+						string(posthog.SurveyBranchingTypeSpecificQuestion),
+						string(posthog.SurveyBranchingTypeResponseBased),
+					),
+				},
+			},
+			"index": schema.Int64Attribute{
+				MarkdownDescription: "Index of the question to jump to, starting at 0",
+				Optional:            true,
+			},
+			"response_values": schema.MapAttribute{
+				MarkdownDescription: "Next step for each answer: the index of a question or `end`. Answers of single choice questions are identified by the index of the choice, ratings by `negative`, `neutral` and `positive` (`detractors`, `passives` and `promoters` for a scale of 10).",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// surveyBranchingAttrTypes returns the attribute types of the branching of
+// questions, to build its object values.
+func surveyBranchingAttrTypes() map[string]attr.Type {
+	return surveyBranchingSchema().GetType().(types.ObjectType).AttrTypes
+}
+
+func surveyAppearanceSchema() schema.SingleNestedAttribute {
+	stringAttribute := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			MarkdownDescription: description,
+			Optional:            true,
+		}
+	}
+
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Appearance of popover and widget surveys. Unset settings use the PostHog defaults.",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"background_color":         stringAttribute("Background color, as a CSS color"),
+			"border_color":             stringAttribute("Border color, as a CSS color"),
+			"text_color":               stringAttribute("Text color, as a CSS color"),
+			"submit_button_color":      stringAttribute("Color of the submit button, as a CSS color"),
+			"submit_button_text_color": stringAttribute("Text color of the submit button, as a CSS color"),
+			"placeholder":              stringAttribute("Placeholder of the text fields of open questions"),
+			"position":                 stringAttribute("Position of the popover on the page, eg. `right`, `left` or `center`"),
+			"display_thank_you_message": schema.BoolAttribute{
+				MarkdownDescription: "Whether to display a message once the survey is completed",
+				Optional:            true,
+			},
+			"thank_you_message_header":      stringAttribute("Title of the thank you message"),
+			"thank_you_message_description": stringAttribute("Text of the thank you message"),
+			"white_label": schema.BoolAttribute{
+				MarkdownDescription: "Whether to hide the PostHog branding",
+				Optional:            true,
+			},
+			"shuffle_questions": schema.BoolAttribute{
+				MarkdownDescription: "Whether questions are displayed in a random order",
+				Optional:            true,
+			},
+			"popup_delay_seconds": schema.Int64Attribute{
+				MarkdownDescription: "Delay before displaying the popover, in seconds",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"widget_type": schema.StringAttribute{
+				MarkdownDescription: "How widget surveys are opened: `tab` (a tab displayed on the side of the page), `button` (a button displayed on the page) or `selector` (existing elements matching `widget_selector`)",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("tab", "button", "selector"),
+				},
+			},
+			"widget_label":    stringAttribute("Label of the widget tab or button"),
+			"widget_selector": stringAttribute("CSS selector of the elements opening the survey, for `selector` widgets"),
+			"widget_color":    stringAttribute("Color of the widget tab or button, as a CSS color"),
+		},
+	}
+}
+
+func (r *surveyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Survey",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the survey",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the survey",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the survey",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the survey",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of the survey: `popover` (displayed by posthog-js), `widget` (opened by users from a tab, button or custom element) or `api` (displayed by custom code)",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(posthog.SurveyTypePopover),
+						string(posthog.SurveyTypeWidget),
+						string(posthog.SurveyTypeAPI),
+					),
+				},
+			},
+			"questions":  surveyQuestionsSchema(),
+			"appearance": surveyAppearanceSchema(),
+			"conditions": schema.SingleNestedAttribute{
+				MarkdownDescription: "Conditions users must match to see the survey",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"url": schema.StringAttribute{
+						MarkdownDescription: "URL of the pages the survey is displayed on, matched according to `url_match_type`",
+						Optional:            true,
+					},
+					"url_match_type": schema.StringAttribute{
+						MarkdownDescription: "How the URL is matched, one of `icontains`, `not_icontains`, `regex`, `not_regex`, `exact` or `is_not`",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("icontains", "not_icontains", "regex", "not_regex", "exact", "is_not"),
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("url")),
+						},
+					},
+					"selector": schema.StringAttribute{
+						MarkdownDescription: "CSS selector of an element that must be on the page",
+						Optional:            true,
+					},
+					"wait_period_days": schema.Int64Attribute{
+						MarkdownDescription: "Number of days to wait after a user saw any survey before displaying this one",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+				},
+			},
+			"linked_flag_id": schema.StringAttribute{
+				MarkdownDescription: "ID of a feature flag users must have enabled to see the survey",
+				Optional:            true,
+			},
+			"responses_limit": schema.Int64Attribute{
+				MarkdownDescription: "Number of responses after which the survey is stopped",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"iteration_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of times the survey is repeated, starting from `start_date`",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.AlsoRequires(path.MatchRoot("iteration_frequency_days")),
+				},
+			},
+			"iteration_frequency_days": schema.Int64Attribute{
+				MarkdownDescription: "Number of days between two iterations of the survey",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.AlsoRequires(path.MatchRoot("iteration_count")),
+				},
+			},
+			"start_date": schema.StringAttribute{
+				MarkdownDescription: "Date the survey was launched, in RFC 3339 format. Setting it launches the survey.",
+				Optional:            true,
+			},
+			"end_date": schema.StringAttribute{
+				MarkdownDescription: "Date the survey was stopped, in RFC 3339 format. Setting it stops the survey.",
+				Optional:            true,
+			},
+			"archived": schema.BoolAttribute{
+				MarkdownDescription: "Whether the survey is archived",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *surveyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *surveyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data surveyResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, attribute := range []struct {
+		name  string
+		value types.String
+	}{
+		{"start_date", data.StartDate},
+		{"end_date", data.EndDate},
+	} {
+		if attribute.value.IsNull() || attribute.value.IsUnknown() {
+			continue
+		}
+
+		if _, err := time.Parse(time.RFC3339, attribute.value.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(attribute.name), "Invalid date", err.Error())
+		}
+	}
+
+	if data.Questions.IsNull() || data.Questions.IsUnknown() {
+		return
+	}
+
+	var questions []surveyQuestion
+
+	resp.Diagnostics.Append(data.Questions.ElementsAs(ctx, &questions, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, q := range questions {
+		resp.Diagnostics.Append(validateSurveyQuestion(ctx, path.Root("questions").AtListIndex(i), i, len(questions), q)...)
+	}
+}
+
+// validateSurveyQuestion checks that the attributes of the question match its
+// type, and that its branching refers to existing questions and answers.
+func validateSurveyQuestion(ctx context.Context, p path.Path, index int, nQuestions int, q surveyQuestion) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if q.Type.IsUnknown() {
+		return diags
+	}
+
+	questionType := posthog.SurveyQuestionType(q.Type.ValueString())
+	isChoice := questionType == posthog.SurveyQuestionTypeSingleChoice || questionType == posthog.SurveyQuestionTypeMultipleChoice
+	isRating := questionType == posthog.SurveyQuestionTypeRating
+
+	if isChoice && q.Choices.IsNull() {
+		diags.AddAttributeError(p.AtName("choices"), "Missing choices", fmt.Sprintf("Questions of type %s must have choices.", questionType))
+	}
+
+	if !isChoice {
+		for _, attribute := range []struct {
+			name  string
+			isSet bool
+		}{
+			{"choices", !q.Choices.IsNull()},
+			{"has_open_choice", q.HasOpenChoice.ValueBool()},
+			{"shuffle_options", q.ShuffleOptions.ValueBool()},
+		} {
+			if attribute.isSet {
+				diags.AddAttributeError(p.AtName(attribute.name), "Invalid question", fmt.Sprintf("Only choice questions can set %s.", attribute.name))
+			}
+		}
+	}
+
+	if isRating {
+		if q.Display.IsNull() {
+			diags.AddAttributeError(p.AtName("display"), "Missing display", "Rating questions must set how ratings are displayed.")
+		}
+
+		if q.Scale.IsNull() {
+			diags.AddAttributeError(p.AtName("scale"), "Missing scale", "Rating questions must set a scale.")
+		}
+
+		if q.Display.ValueString() == "emoji" && !q.Scale.IsUnknown() && q.Scale.ValueInt64() != 3 && q.Scale.ValueInt64() != 5 {
+			diags.AddAttributeError(p.AtName("scale"), "Invalid scale", "Emoji ratings only support scales of 3 and 5.")
+		}
+	} else {
+		for _, attribute := range []struct {
+			name  string
+			isSet bool
+		}{
+			{"display", !q.Display.IsNull()},
+			{"scale", !q.Scale.IsNull()},
+			{"lower_bound_label", !q.LowerBoundLabel.IsNull()},
+			{"upper_bound_label", !q.UpperBoundLabel.IsNull()},
+		} {
+			if attribute.isSet {
+				diags.AddAttributeError(p.AtName(attribute.name), "Invalid question", fmt.Sprintf("Only rating questions can set %s.", attribute.name))
+			}
+		}
+	}
+
+	if questionType == posthog.SurveyQuestionTypeLink && q.Link.IsNull() {
+		diags.AddAttributeError(p.AtName("link"), "Missing link", "Link questions must set a link.")
+	}
+
+	if questionType != posthog.SurveyQuestionTypeLink && !q.Link.IsNull() {
+		diags.AddAttributeError(p.AtName("link"), "Invalid question", "Only link questions can set link.")
+	}
+
+	// Branching is an object so that it can be unknown, it is only checked once
+	// its value is known
+	if q.Branching.IsNull() || q.Branching.IsUnknown() {
+		return diags
+	}
+
+	var branching surveyBranching
+
+	d := q.Branching.As(ctx, &branching, basetypes.ObjectAsOptions{})
+	diags.Append(d...)
+	if d.HasError() || branching.Type.IsUnknown() {
+		return diags
+	}
+
+	p = p.AtName("branching")
+
+	checkTarget := func(p path.Path, target int64) {
+		if target < 0 || target >= int64(nQuestions) {
+			diags.AddAttributeError(p, "Invalid branching", fmt.Sprintf("Question %d does not exist, the survey has %d questions.", target, nQuestions))
+		} else if target == int64(index) {
+			diags.AddAttributeError(p, "Invalid branching", "Questions cannot branch to themselves.")
+		}
+	}
+
+	branchingType := posthog.SurveyBranchingType(branching.Type.ValueString())
+
+	if branchingType == posthog.SurveyBranchingTypeSpecificQuestion {
+		if branching.Index.IsNull() {
+			diags.AddAttributeError(p.AtName("index"), "Missing index", "Branching to a specific question requires its index.")
+		} else if !branching.Index.IsUnknown() {
+			checkTarget(p.AtName("index"), branching.Index.ValueInt64())
+		}
+	} else if !branching.Index.IsNull() {
+		diags.AddAttributeError(p.AtName("index"), "Invalid branching", fmt.Sprintf("Only %s branching can set index.", posthog.SurveyBranchingTypeSpecificQuestion))
+	}
+
+	if branchingType != posthog.SurveyBranchingTypeResponseBased {
+		if !branching.ResponseValues.IsNull() {
+			diags.AddAttributeError(p.AtName("response_values"), "Invalid branching", fmt.Sprintf("Only %s branching can set response_values.", posthog.SurveyBranchingTypeResponseBased))
+		}
+
+		return diags
+	}
+
+	if questionType != posthog.SurveyQuestionTypeSingleChoice && !isRating {
+		diags.AddAttributeError(p.AtName("type"), "Invalid branching", "Only single choice and rating questions support response based branching.")
+		return diags
+	}
+
+	if branching.ResponseValues.IsNull() {
+		diags.AddAttributeError(p.AtName("response_values"), "Missing response values", "Response based branching requires the next step of each answer.")
+		return diags
+	}
+
+	if branching.ResponseValues.IsUnknown() {
+		return diags
+	}
+
+	var responseValues map[string]types.String
+
+	diags.Append(branching.ResponseValues.ElementsAs(ctx, &responseValues, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	var validKeys []string
+
+	if isRating && !q.Scale.IsUnknown() {
+		validKeys = surveyRatingResponseKeys(q.Scale.ValueInt64())
+	} else if !isRating && !q.Choices.IsUnknown() {
+		for i := range q.Choices.Elements() {
+			validKeys = append(validKeys, strconv.Itoa(i))
+		}
+	}
+
+	for key, value := range responseValues {
+		if validKeys != nil && !slices.Contains(validKeys, key) {
+			diags.AddAttributeError(p.AtName("response_values").AtMapKey(key), "Invalid branching", fmt.Sprintf("Unknown answer %q, valid answers are %s.", key, strings.Join(validKeys, ", ")))
+		}
+
+		if value.IsUnknown() {
+			continue
+		}
+
+		target, err := surveyBranchTargetFromString(value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(p.AtName("response_values").AtMapKey(key), "Invalid branching", err.Error())
+			continue
+		}
+
+		if !target.End {
+			checkTarget(p.AtName("response_values").AtMapKey(key), target.Index)
+		}
+	}
+
+	return diags
+}
+
+// surveyRatingResponseKeys returns the answers response based branching can
+// use for a rating question with the given scale.
+func surveyRatingResponseKeys(scale int64) []string {
+	if scale == 10 {
+		return []string{"detractors", "passives", "promoters"}
+	}
+
+	return []string{"negative", "neutral", "positive"}
+}
+
+func surveyBranchTargetFromString(s string) (posthog.SurveyBranchTarget, error) {
+	if s == "end" {
+		return posthog.SurveyBranchTarget{End: true}, nil
+	}
+
+	index, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return posthog.SurveyBranchTarget{}, fmt.Errorf("next step must be a question index or end, got %q", s)
+	}
+
+	return posthog.SurveyBranchTarget{Index: index}, nil
+}
+
+func surveyBranchTargetToString(t posthog.SurveyBranchTarget) string {
+	if t.End {
+		return "end"
+	}
+
+	return strconv.FormatInt(t.Index, 10)
+}
+
+func surveyQuestionsToModel(ctx context.Context, apiQuestions []posthog.SurveyQuestion) (types.List, diag.Diagnostics) {
+	var (
+		diags     diag.Diagnostics
+		d         diag.Diagnostics
+		questions []surveyQuestion
+	)
+
+	for _, apiQuestion := range apiQuestions {
+		q := surveyQuestion{
+			ID:              typeutil.NullableStringValue(apiQuestion.ID),
+			Type:            types.StringValue(string(apiQuestion.Type)),
+			Question:        types.StringValue(apiQuestion.Question),
+			Description:     types.StringValue(apiQuestion.Description),
+			Optional:        types.BoolValue(apiQuestion.Optional),
+			ButtonText:      typeutil.NullableStringValue(apiQuestion.ButtonText),
+			Choices:         types.ListNull(types.StringType),
+			HasOpenChoice:   types.BoolValue(apiQuestion.HasOpenChoice),
+			ShuffleOptions:  types.BoolValue(apiQuestion.ShuffleOptions),
+			Display:         typeutil.NullableStringValue(apiQuestion.Display),
+			Scale:           types.Int64Null(),
+			LowerBoundLabel: typeutil.NullableStringValue(apiQuestion.LowerBoundLabel),
+			UpperBoundLabel: typeutil.NullableStringValue(apiQuestion.UpperBoundLabel),
+			Link:            typeutil.NullableStringValue(apiQuestion.Link),
+			Branching:       types.ObjectNull(surveyBranchingAttrTypes()),
+		}
+
+		if len(apiQuestion.Choices) > 0 {
+			q.Choices, d = types.ListValueFrom(ctx, types.StringType, apiQuestion.Choices)
+			diags.Append(d...)
+		}
+
+		if apiQuestion.Scale != 0 {
+			q.Scale = types.Int64Value(apiQuestion.Scale)
+		}
+
+		if apiQuestion.Branching != nil {
+			branching := surveyBranching{
+				Type:           types.StringValue(string(apiQuestion.Branching.Type)),
+				Index:          types.Int64PointerValue(apiQuestion.Branching.Index),
+				ResponseValues: types.MapNull(types.StringType),
+			}
+
+			if apiQuestion.Branching.ResponseValues != nil {
+				responseValues := map[string]string{}
+				for key, target := range apiQuestion.Branching.ResponseValues {
+					responseValues[key] = surveyBranchTargetToString(target)
+				}
+
+				branching.ResponseValues, d = types.MapValueFrom(ctx, types.StringType, responseValues)
+				diags.Append(d...)
+			}
+
+			q.Branching, d = types.ObjectValueFrom(ctx, surveyBranchingAttrTypes(), branching)
+			diags.Append(d...)
+		}
+
+		questions = append(questions, q)
+	}
+
+	if diags.HasError() {
+		return types.ListNull(surveyQuestionsSchema().NestedObject.Type()), diags
+	}
+
+	res, d := types.ListValueFrom(ctx, surveyQuestionsSchema().NestedObject.Type(), questions)
+	diags.Append(d...)
+
+	return res, diags
+}
+
+func surveyQuestionsFromModel(ctx context.Context, questions types.List) ([]posthog.SurveyQuestion, diag.Diagnostics) {
+	var (
+		diags  diag.Diagnostics
+		models []surveyQuestion
+		res    []posthog.SurveyQuestion
+	)
+
+	diags.Append(questions.ElementsAs(ctx, &models, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	for i, q := range models {
+		apiQuestion := posthog.SurveyQuestion{
+			ID:              q.ID.ValueString(),
+			Type:            posthog.SurveyQuestionType(q.Type.ValueString()),
+			Question:        q.Question.ValueString(),
+			Description:     q.Description.ValueString(),
+			Optional:        q.Optional.ValueBool(),
+			ButtonText:      q.ButtonText.ValueString(),
+			HasOpenChoice:   q.HasOpenChoice.ValueBool(),
+			ShuffleOptions:  q.ShuffleOptions.ValueBool(),
+			Display:         q.Display.ValueString(),
+			Scale:           q.Scale.ValueInt64(),
+			LowerBoundLabel: q.LowerBoundLabel.ValueString(),
+			UpperBoundLabel: q.UpperBoundLabel.ValueString(),
+			Link:            q.Link.ValueString(),
+		}
+
+		if !q.Choices.IsNull() {
+			diags.Append(q.Choices.ElementsAs(ctx, &apiQuestion.Choices, false)...)
+		}
+
+		if !q.Branching.IsNull() {
+			var branching surveyBranching
+
+			diags.Append(q.Branching.As(ctx, &branching, basetypes.ObjectAsOptions{})...)
+
+			apiQuestion.Branching = &posthog.SurveyBranching{
+				Type:  posthog.SurveyBranchingType(branching.Type.ValueString()),
+				Index: branching.Index.ValueInt64Pointer(),
+			}
+
+			if !branching.ResponseValues.IsNull() {
+				var responseValues map[string]string
+
+				diags.Append(branching.ResponseValues.ElementsAs(ctx, &responseValues, false)...)
+
+				apiQuestion.Branching.ResponseValues = map[string]posthog.SurveyBranchTarget{}
+				for key, value := range responseValues {
+					target, err := surveyBranchTargetFromString(value)
+					if err != nil {
+						diags.AddAttributeError(path.Root("questions").AtListIndex(i).AtName("branching").AtName("response_values").AtMapKey(key), "Invalid branching", err.Error())
+						continue
+					}
+
+					apiQuestion.Branching.ResponseValues[key] = target
+				}
+			}
+		}
+
+		res = append(res, apiQuestion)
+	}
+
+	return res, diags
+}
+
+func surveyAppearanceToModel(apiAppearance *posthog.SurveyAppearance) *surveyAppearance {
+	if apiAppearance == nil || *apiAppearance == (posthog.SurveyAppearance{}) {
+		return nil
+	}
+
+	return &surveyAppearance{
+		BackgroundColor:            typeutil.NullableStringValue(apiAppearance.BackgroundColor),
+		BorderColor:                typeutil.NullableStringValue(apiAppearance.BorderColor),
+		TextColor:                  typeutil.NullableStringValue(apiAppearance.TextColor),
+		SubmitButtonColor:          typeutil.NullableStringValue(apiAppearance.SubmitButtonColor),
+		SubmitButtonTextColor:      typeutil.NullableStringValue(apiAppearance.SubmitButtonTextColor),
+		Placeholder:                typeutil.NullableStringValue(apiAppearance.Placeholder),
+		Position:                   typeutil.NullableStringValue(apiAppearance.Position),
+		DisplayThankYouMessage:     types.BoolPointerValue(apiAppearance.DisplayThankYouMessage),
+		ThankYouMessageHeader:      typeutil.NullableStringValue(apiAppearance.ThankYouMessageHeader),
+		ThankYouMessageDescription: typeutil.NullableStringValue(apiAppearance.ThankYouMessageDescription),
+		WhiteLabel:                 types.BoolPointerValue(apiAppearance.WhiteLabel),
+		ShuffleQuestions:           types.BoolPointerValue(apiAppearance.ShuffleQuestions),
+		PopupDelaySeconds:          types.Int64PointerValue(apiAppearance.SurveyPopupDelaySeconds),
+		WidgetType:                 typeutil.NullableStringValue(apiAppearance.WidgetType),
+		WidgetLabel:                typeutil.NullableStringValue(apiAppearance.WidgetLabel),
+		WidgetSelector:             typeutil.NullableStringValue(apiAppearance.WidgetSelector),
+		WidgetColor:                typeutil.NullableStringValue(apiAppearance.WidgetColor),
+	}
+}
+
+func surveyAppearanceFromModel(appearance *surveyAppearance) *posthog.SurveyAppearance {
+	if appearance == nil {
+		return nil
+	}
+
+	return &posthog.SurveyAppearance{
+		BackgroundColor:            appearance.BackgroundColor.ValueString(),
+		BorderColor:                appearance.BorderColor.ValueString(),
+		TextColor:                  appearance.TextColor.ValueString(),
+		SubmitButtonColor:          appearance.SubmitButtonColor.ValueString(),
+		SubmitButtonTextColor:      appearance.SubmitButtonTextColor.ValueString(),
+		Placeholder:                appearance.Placeholder.ValueString(),
+		Position:                   appearance.Position.ValueString(),
+		DisplayThankYouMessage:     appearance.DisplayThankYouMessage.ValueBoolPointer(),
+		ThankYouMessageHeader:      appearance.ThankYouMessageHeader.ValueString(),
+		ThankYouMessageDescription: appearance.ThankYouMessageDescription.ValueString(),
+		WhiteLabel:                 appearance.WhiteLabel.ValueBoolPointer(),
+		ShuffleQuestions:           appearance.ShuffleQuestions.ValueBoolPointer(),
+		SurveyPopupDelaySeconds:    appearance.PopupDelaySeconds.ValueInt64Pointer(),
+		WidgetType:                 appearance.WidgetType.ValueString(),
+		WidgetLabel:                appearance.WidgetLabel.ValueString(),
+		WidgetSelector:             appearance.WidgetSelector.ValueString(),
+		WidgetColor:                appearance.WidgetColor.ValueString(),
+	}
+}
+
+func updateSurveyModel(ctx context.Context, model *surveyResourceModel, apiSurvey *posthog.Survey) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(apiSurvey.ID.String())
+	model.Name = types.StringValue(apiSurvey.Name)
+	model.Description = types.StringValue(apiSurvey.Description)
+	model.Type = types.StringValue(string(apiSurvey.Type))
+	model.Appearance = surveyAppearanceToModel(apiSurvey.Appearance)
+	model.ResponsesLimit = types.Int64PointerValue(apiSurvey.ResponsesLimit)
+	model.IterationCount = types.Int64PointerValue(apiSurvey.IterationCount)
+	model.IterationFrequencyDays = types.Int64PointerValue(apiSurvey.IterationFrequencyDays)
+	model.StartDate = typeutil.TimeValue(model.StartDate, apiSurvey.StartDate)
+	model.EndDate = typeutil.TimeValue(model.EndDate, apiSurvey.EndDate)
+	model.Archived = types.BoolValue(apiSurvey.Archived)
+
+	if apiSurvey.LinkedFlagID != nil {
+		model.LinkedFlagID = types.StringValue(apiSurvey.LinkedFlagID.String())
+	} else {
+		model.LinkedFlagID = types.StringNull()
+	}
+
+	if c := apiSurvey.Conditions; c != nil && *c != (posthog.SurveyConditions{}) {
+		model.Conditions = &surveyConditions{
+			URL:            typeutil.NullableStringValue(c.URL),
+			URLMatchType:   typeutil.NullableStringValue(c.URLMatchType),
+			Selector:       typeutil.NullableStringValue(c.Selector),
+			WaitPeriodDays: types.Int64PointerValue(c.SeenSurveyWaitPeriodInDays),
+		}
+	} else {
+		model.Conditions = nil
+	}
+
+	model.Questions, diags = surveyQuestionsToModel(ctx, apiSurvey.Questions)
+
+	return diags
+}
+
+func surveyFromModel(ctx context.Context, data surveyResourceModel) (posthog.Survey, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	survey := posthog.Survey{
+		Name:                   data.Name.ValueString(),
+		Description:            data.Description.ValueString(),
+		Type:                   posthog.SurveyType(data.Type.ValueString()),
+		Appearance:             surveyAppearanceFromModel(data.Appearance),
+		ResponsesLimit:         data.ResponsesLimit.ValueInt64Pointer(),
+		IterationCount:         data.IterationCount.ValueInt64Pointer(),
+		IterationFrequencyDays: data.IterationFrequencyDays.ValueInt64Pointer(),
+		Archived:               data.Archived.ValueBool(),
+	}
+
+	if data.Conditions != nil {
+		survey.Conditions = &posthog.SurveyConditions{
+			URL:                        data.Conditions.URL.ValueString(),
+			URLMatchType:               data.Conditions.URLMatchType.ValueString(),
+			Selector:                   data.Conditions.Selector.ValueString(),
+			SeenSurveyWaitPeriodInDays: data.Conditions.WaitPeriodDays.ValueInt64Pointer(),
+		}
+	}
+
+	questions, d := surveyQuestionsFromModel(ctx, data.Questions)
+	diags.Append(d...)
+	if diags.HasError() {
+		return survey, diags
+	}
+
+	survey.Questions = questions
+
+	if !data.LinkedFlagID.IsNull() {
+		linkedFlagID, err := posthog.FeatureFlagIDFromString(data.LinkedFlagID.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("linked_flag_id"), "Invalid feature flag ID", err.Error())
+			return survey, diags
+		}
+
+		survey.LinkedFlagID = &linkedFlagID
+	}
+
+	if !data.StartDate.IsNull() {
+		startDate, err := time.Parse(time.RFC3339, data.StartDate.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("start_date"), "Invalid date", err.Error())
+			return survey, diags
+		}
+
+		survey.StartDate = &startDate
+	}
+
+	if !data.EndDate.IsNull() {
+		endDate, err := time.Parse(time.RFC3339, data.EndDate.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("end_date"), "Invalid date", err.Error())
+			return survey, diags
+		}
+
+		survey.EndDate = &endDate
+	}
+
+	return survey, diags
+}
+
+func (r *surveyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data surveyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	survey, diags := surveyFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createSurveyRequest := posthog.CreateSurveyRequest{
+		Name:                   survey.Name,
+		Description:            survey.Description,
+		Type:                   survey.Type,
+		Questions:              survey.Questions,
+		Appearance:             survey.Appearance,
+		Conditions:             survey.Conditions,
+		LinkedFlagID:           survey.LinkedFlagID,
+		StartDate:              survey.StartDate,
+		EndDate:                survey.EndDate,
+		ResponsesLimit:         survey.ResponsesLimit,
+		IterationCount:         survey.IterationCount,
+		IterationFrequencyDays: survey.IterationFrequencyDays,
+		Archived:               survey.Archived,
+	}
+
+	// Create the survey
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	res, err := r.client.CreateSurvey(ctx, projectID, createSurveyRequest)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating survey: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(updateSurveyModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created survey", map[string]interface{}{"survey_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *surveyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data surveyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	surveyID, err := posthog.SurveyIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid survey ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetSurvey(ctx, projectID, surveyID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting survey %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(updateSurveyModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read survey", map[string]interface{}{"survey_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *surveyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data surveyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	survey, diags := surveyFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	survey.ID, err = posthog.SurveyIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid survey ID", err.Error())
+		return
+	}
+
+	res, err := r.client.UpdateSurvey(ctx, projectID, survey)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating survey %s: %s", survey.ID, err))
+		return
+	}
+
+	resp.Diagnostics.Append(updateSurveyModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated survey", map[string]interface{}{"survey_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *surveyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data surveyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	surveyID, err := posthog.SurveyIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid survey ID", err.Error())
+		return
+	}
+
+	if err := r.client.DeleteSurvey(ctx, projectID, surveyID); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting survey %s: %s", data.ID, err))
+		return
+	}
+}
+
+func (r *surveyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, surveyID, err := parseImportID(req.ID, "survey", posthog.SurveyIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), surveyID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
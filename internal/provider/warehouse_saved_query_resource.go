@@ -0,0 +1,349 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+var _ resource.Resource = &warehouseSavedQueryResource{}
+var _ resource.ResourceWithImportState = &warehouseSavedQueryResource{}
+
+func newWarehouseSavedQueryResource() resource.Resource {
+	return &warehouseSavedQueryResource{}
+}
+
+type warehouseSavedQueryResource struct {
+	client *posthog.Client
+}
+
+type warehouseSavedQueryResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	ProjectID types.String `tfsdk:"project_id"`
+	Name      types.String `tfsdk:"name"`
+	Query     types.String `tfsdk:"query"`
+	Columns   types.List   `tfsdk:"columns"`
+}
+
+type warehouseColumn struct {
+	Name string `tfsdk:"name"`
+	Type string `tfsdk:"type"`
+}
+
+func (r *warehouseSavedQueryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_warehouse_saved_query"
+}
+
+func warehouseColumnsSchema(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: description,
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Name of the column",
+					Computed:            true,
+				},
+				"type": schema.StringAttribute{
+					MarkdownDescription: "Type of the column (eg. `string`, `integer` or `datetime`)",
+					Computed:            true,
+				},
+			},
+		},
+	}
+}
+
+func (r *warehouseSavedQueryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Data Warehouse Saved Query, a HogQL query saved as a view that other queries can use like a table. The query is validated by PostHog before being saved.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the saved query",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the saved query",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the view, used as a table name in queries (eg. `active_customers`)",
+				Required:            true,
+				Validators: []validator.String{
+					hogQLIdentifierValidator(),
+				},
+			},
+			"query": schema.StringAttribute{
+				MarkdownDescription: "HogQL query of the view",
+				Required:            true,
+			},
+			"columns": warehouseColumnsSchema("Columns of the view, computed by PostHog from the query"),
+		},
+	}
+}
+
+var hogQLIdentifierRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func hogQLIdentifierValidator() validator.String {
+	return stringvalidator.RegexMatches(hogQLIdentifierRe, "must only contain letters, digits and underscores, and not start with a digit")
+}
+
+func (r *warehouseSavedQueryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// validateHogQLQuery validates a HogQL query with PostHog, reporting the
+// errors in the query on the attribute at queryPath.
+func validateHogQLQuery(ctx context.Context, client *posthog.Client, projectID posthog.ProjectID, queryPath path.Path, query string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	queryErrors, err := client.ValidateHogQLQuery(ctx, projectID, query)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Error validating HogQL query: %s", err))
+		return diags
+	}
+
+	for _, e := range queryErrors {
+		message := e.Message
+
+		if e.Start != nil && *e.Start >= 0 && int(*e.Start) <= len(query) {
+			before := query[:*e.Start]
+			line := strings.Count(before, "\n") + 1
+			column := len(before) - strings.LastIndex(before, "\n")
+			message = fmt.Sprintf("line %d, column %d: %s", line, column, message)
+		}
+
+		diags.AddAttributeError(queryPath, "Invalid HogQL query", message)
+	}
+
+	return diags
+}
+
+func warehouseColumnsToModel(ctx context.Context, apiColumns []posthog.WarehouseColumn) (types.List, diag.Diagnostics) {
+	columns := make([]warehouseColumn, 0, len(apiColumns))
+	for _, c := range apiColumns {
+		columns = append(columns, warehouseColumn{Name: c.Name, Type: c.Type})
+	}
+
+	return types.ListValueFrom(ctx, warehouseColumnsSchema("").NestedObject.Type(), columns)
+}
+
+func updateWarehouseSavedQueryModel(ctx context.Context, model *warehouseSavedQueryResourceModel, apiSavedQuery *posthog.WarehouseSavedQuery) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(apiSavedQuery.ID.String())
+	model.Name = types.StringValue(apiSavedQuery.Name)
+	model.Query = types.StringValue(apiSavedQuery.Query.Query)
+	model.Columns, diags = warehouseColumnsToModel(ctx, apiSavedQuery.Columns)
+
+	return diags
+}
+
+func (r *warehouseSavedQueryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data warehouseSavedQueryResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(validateHogQLQuery(ctx, r.client, projectID, path.Root("query"), data.Query.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create the saved query
+
+	res, err := r.client.CreateWarehouseSavedQuery(ctx, projectID, posthog.CreateWarehouseSavedQueryRequest{
+		Name:  data.Name.ValueString(),
+		Query: posthog.WarehouseSavedQueryQuery{Query: data.Query.ValueString()},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating saved query: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(updateWarehouseSavedQueryModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created saved query", map[string]interface{}{"saved_query_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *warehouseSavedQueryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data warehouseSavedQueryResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	savedQueryID, err := posthog.WarehouseSavedQueryIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid saved query ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetWarehouseSavedQuery(ctx, projectID, savedQueryID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting saved query %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(updateWarehouseSavedQueryModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read saved query", map[string]interface{}{"saved_query_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *warehouseSavedQueryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data warehouseSavedQueryResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	savedQuery := posthog.WarehouseSavedQuery{
+		Name:  data.Name.ValueString(),
+		Query: posthog.WarehouseSavedQueryQuery{Query: data.Query.ValueString()},
+	}
+
+	savedQuery.ID, err = posthog.WarehouseSavedQueryIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid saved query ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(validateHogQLQuery(ctx, r.client, projectID, path.Root("query"), data.Query.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, err := r.client.UpdateWarehouseSavedQuery(ctx, projectID, savedQuery)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating saved query %s: %s", savedQuery.ID, err))
+		return
+	}
+
+	resp.Diagnostics.Append(updateWarehouseSavedQueryModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated saved query", map[string]interface{}{"saved_query_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *warehouseSavedQueryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data warehouseSavedQueryResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	savedQueryID, err := posthog.WarehouseSavedQueryIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid saved query ID", err.Error())
+		return
+	}
+
+	err = r.client.DeleteWarehouseSavedQuery(ctx, projectID, savedQueryID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting saved query %s: %s", data.ID, err))
+		return
+	}
+}
+
+func (r *warehouseSavedQueryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, savedQueryID, err := parseImportID(req.ID, "saved query", posthog.WarehouseSavedQueryIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), savedQueryID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
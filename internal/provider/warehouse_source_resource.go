@@ -0,0 +1,602 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+	"github.com/abustany/terraform-provider-posthog/internal/typeutil"
+)
+
+var _ resource.Resource = &warehouseSourceResource{}
+var _ resource.ResourceWithImportState = &warehouseSourceResource{}
+var _ resource.ResourceWithValidateConfig = &warehouseSourceResource{}
+
+func newWarehouseSourceResource() resource.Resource {
+	return &warehouseSourceResource{}
+}
+
+type warehouseSourceResource struct {
+	client *posthog.Client
+}
+
+type warehouseSourceResourceModel struct {
+	ID             types.String            `tfsdk:"id"`
+	ProjectID      types.String            `tfsdk:"project_id"`
+	SourceType     types.String            `tfsdk:"source_type"`
+	Prefix         types.String            `tfsdk:"prefix"`
+	Settings       types.Map               `tfsdk:"settings"`
+	SecretSettings types.Map               `tfsdk:"secret_settings"`
+	SyncFrequency  types.String            `tfsdk:"sync_frequency"`
+	Schemas        []warehouseSourceSchema `tfsdk:"schemas"`
+	Status         types.String            `tfsdk:"status"`
+	LatestError    types.String            `tfsdk:"latest_error"`
+}
+
+type warehouseSourceSchema struct {
+	Name                 types.String `tfsdk:"name"`
+	SyncType             types.String `tfsdk:"sync_type"`
+	IncrementalField     types.String `tfsdk:"incremental_field"`
+	IncrementalFieldType types.String `tfsdk:"incremental_field_type"`
+}
+
+func (r *warehouseSourceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_warehouse_source"
+}
+
+func (r *warehouseSourceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Data Warehouse Source, periodically importing the tables of an external service or database into the data warehouse.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the source",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the source",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the source, for example `Stripe`, `Hubspot`, `Postgres`, `MySQL`, `MSSQL`, `Snowflake`, `BigQuery`, `Zendesk`, `Salesforce` or `Vitally`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(posthog.WarehouseSourceTypeStripe),
+						string(posthog.WarehouseSourceTypeHubspot),
+						string(posthog.WarehouseSourceTypePostgres),
+						string(posthog.WarehouseSourceTypeMySQL),
+						string(posthog.WarehouseSourceTypeMSSQL),
+						string(posthog.WarehouseSourceTypeSnowflake),
+						string(posthog.WarehouseSourceTypeBigQuery),
+						string(posthog.WarehouseSourceTypeZendesk),
+						string(posthog.WarehouseSourceTypeSalesforce),
+						string(posthog.WarehouseSourceTypeVitally),
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"prefix": schema.StringAttribute{
+				MarkdownDescription: "Prefix added to the names of the tables of the source in the data warehouse",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"settings": schema.MapAttribute{
+				MarkdownDescription: "Connection settings of the source, which depend on its type (eg. `host`, `port`, `database`, `user` and `schema` for `Postgres`, `stripe_account_id` for `Stripe`). PostHog doesn't return all the settings, changes made outside of Terraform are not detected.",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+			"secret_settings": schema.MapAttribute{
+				MarkdownDescription: "Secret connection settings of the source (eg. `password` for `Postgres`, `stripe_secret_key` for `Stripe`). PostHog never returns those values, changes made outside of Terraform are not detected.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"sync_frequency": schema.StringAttribute{
+				MarkdownDescription: "How often the tables are synced: `5min`, `30min`, `1hour`, `6hour`, `12hour`, `24hour`, `7day` or `30day`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(posthog.WarehouseSyncFrequency24Hour)),
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(posthog.WarehouseSyncFrequency5Min),
+						string(posthog.WarehouseSyncFrequency30Min),
+						string(posthog.WarehouseSyncFrequency1Hour),
+						string(posthog.WarehouseSyncFrequency6Hour),
+						string(posthog.WarehouseSyncFrequency12Hour),
+						string(posthog.WarehouseSyncFrequency24Hour),
+						string(posthog.WarehouseSyncFrequency7Day),
+						string(posthog.WarehouseSyncFrequency30Day),
+					),
+				},
+			},
+			"schemas": schema.ListNestedAttribute{
+				MarkdownDescription: "Tables of the source to sync, the other tables are not synced",
+				Required:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the table in the source",
+							Required:            true,
+						},
+						"sync_type": schema.StringAttribute{
+							MarkdownDescription: "`full_refresh` to import the whole table at each sync, `incremental` to only import the rows where `incremental_field` increased",
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(string(posthog.WarehouseSyncTypeFullRefresh)),
+							Validators: []validator.String{
+								stringvalidator.OneOf(string(posthog.WarehouseSyncTypeFullRefresh), string(posthog.WarehouseSyncTypeIncremental)),
+							},
+						},
+						"incremental_field": schema.StringAttribute{
+							MarkdownDescription: "Column used to find the new rows, for `incremental` syncs (eg. `updated_at`)",
+							Optional:            true,
+						},
+						"incremental_field_type": schema.StringAttribute{
+							MarkdownDescription: "Type of `incremental_field`: `integer`, `numeric`, `datetime`, `date` or `timestamp`",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("integer", "numeric", "datetime", "date", "timestamp"),
+							},
+						},
+					},
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Status of the source (eg. `Running` or `Error`)",
+				Computed:            true,
+			},
+			"latest_error": schema.StringAttribute{
+				MarkdownDescription: "Error of the last failed sync",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *warehouseSourceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *warehouseSourceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var schemasList types.List
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("schemas"), &schemasList)...)
+	if resp.Diagnostics.HasError() || schemasList.IsNull() || schemasList.IsUnknown() {
+		return
+	}
+
+	var schemas []warehouseSourceSchema
+
+	resp.Diagnostics.Append(schemasList.ElementsAs(ctx, &schemas, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	names := map[string]bool{}
+
+	for i, s := range schemas {
+		schemaPath := path.Root("schemas").AtListIndex(i)
+
+		if !s.Name.IsNull() && !s.Name.IsUnknown() {
+			name := s.Name.ValueString()
+
+			if names[name] {
+				resp.Diagnostics.AddAttributeError(schemaPath.AtName("name"), "Duplicate table", fmt.Sprintf("Table %s is listed several times.", name))
+			}
+
+			names[name] = true
+		}
+
+		if s.SyncType.IsUnknown() {
+			continue
+		}
+
+		// sync_type defaults to full_refresh when null
+		if s.SyncType.ValueString() == string(posthog.WarehouseSyncTypeIncremental) {
+			if s.IncrementalField.IsNull() {
+				resp.Diagnostics.AddAttributeError(schemaPath.AtName("incremental_field"), "Missing incremental field", "Incremental syncs require an incremental field.")
+			}
+
+			if s.IncrementalFieldType.IsNull() {
+				resp.Diagnostics.AddAttributeError(schemaPath.AtName("incremental_field_type"), "Missing incremental field type", "Incremental syncs require the type of the incremental field.")
+			}
+		} else if !s.IncrementalField.IsNull() || !s.IncrementalFieldType.IsNull() {
+			resp.Diagnostics.AddAttributeError(schemaPath.AtName("incremental_field"), "Unexpected incremental field", "The incremental field can only be set for incremental syncs.")
+		}
+	}
+}
+
+// warehouseSourceJobInputs merges the settings and the secret settings.
+func warehouseSourceJobInputs(ctx context.Context, data warehouseSourceResourceModel) (map[string]any, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var settings, secretSettings map[string]string
+
+	diags.Append(data.Settings.ElementsAs(ctx, &settings, false)...)
+	diags.Append(data.SecretSettings.ElementsAs(ctx, &secretSettings, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	res := map[string]any{}
+
+	for k, v := range settings {
+		res[k] = v
+	}
+
+	for k, v := range secretSettings {
+		res[k] = v
+	}
+
+	return res, diags
+}
+
+func warehouseSourceSchemaFromModel(s warehouseSourceSchema, syncFrequency string) posthog.WarehouseSourceSchema {
+	return posthog.WarehouseSourceSchema{
+		Name:                 s.Name.ValueString(),
+		ShouldSync:           true,
+		SyncType:             posthog.WarehouseSyncType(s.SyncType.ValueString()),
+		IncrementalField:     s.IncrementalField.ValueStringPointer(),
+		IncrementalFieldType: s.IncrementalFieldType.ValueStringPointer(),
+		SyncFrequency:        posthog.WarehouseSyncFrequency(syncFrequency),
+	}
+}
+
+func sameWarehouseSourceSchema(a, b posthog.WarehouseSourceSchema) bool {
+	sameString := func(x, y *string) bool {
+		return (x == nil && y == nil) || (x != nil && y != nil && *x == *y)
+	}
+
+	return a.ShouldSync == b.ShouldSync &&
+		a.SyncType == b.SyncType &&
+		a.SyncFrequency == b.SyncFrequency &&
+		sameString(a.IncrementalField, b.IncrementalField) &&
+		sameString(a.IncrementalFieldType, b.IncrementalFieldType)
+}
+
+func updateWarehouseSourceModel(model *warehouseSourceResourceModel, apiSource *posthog.WarehouseSource) {
+	model.ID = types.StringValue(apiSource.ID.String())
+	model.SourceType = types.StringValue(string(apiSource.SourceType))
+	model.Prefix = typeutil.NullableStringPointerValue(apiSource.Prefix)
+	model.Status = types.StringValue(apiSource.Status)
+	model.LatestError = typeutil.NullableStringPointerValue(apiSource.LatestError)
+
+	// Keep the order of the configuration, tables synced outside of Terraform
+	// come last
+	position := map[string]int{}
+	for i, s := range model.Schemas {
+		position[s.Name.ValueString()] = i
+	}
+
+	var synced []posthog.WarehouseSourceSchema
+	for _, s := range apiSource.Schemas {
+		if s.ShouldSync {
+			synced = append(synced, s)
+		}
+	}
+
+	sort.SliceStable(synced, func(i, j int) bool {
+		pi, iKnown := position[synced[i].Name]
+		pj, jKnown := position[synced[j].Name]
+
+		switch {
+		case iKnown && jKnown:
+			return pi < pj
+		case iKnown != jKnown:
+			return iKnown
+		default:
+			return synced[i].Name < synced[j].Name
+		}
+	})
+
+	model.Schemas = nil
+
+	for _, s := range synced {
+		syncType := s.SyncType
+		if syncType == "" {
+			syncType = posthog.WarehouseSyncTypeFullRefresh
+		}
+
+		model.Schemas = append(model.Schemas, warehouseSourceSchema{
+			Name:                 types.StringValue(s.Name),
+			SyncType:             types.StringValue(string(syncType)),
+			IncrementalField:     typeutil.NullableStringPointerValue(s.IncrementalField),
+			IncrementalFieldType: typeutil.NullableStringPointerValue(s.IncrementalFieldType),
+		})
+	}
+
+	if len(synced) > 0 && synced[0].SyncFrequency != "" {
+		model.SyncFrequency = types.StringValue(string(synced[0].SyncFrequency))
+	}
+}
+
+func (r *warehouseSourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data warehouseSourceResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	payload, diags := warehouseSourceJobInputs(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schemas := make([]posthog.WarehouseSourceSchema, 0, len(data.Schemas))
+	for _, s := range data.Schemas {
+		schemas = append(schemas, warehouseSourceSchemaFromModel(s, data.SyncFrequency.ValueString()))
+	}
+
+	payload["schemas"] = schemas
+
+	createWarehouseSourceRequest := posthog.CreateWarehouseSourceRequest{
+		SourceType: posthog.WarehouseSourceType(data.SourceType.ValueString()),
+		Prefix:     data.Prefix.ValueString(),
+		Payload:    payload,
+	}
+
+	// Create the source
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	res, err := r.client.CreateWarehouseSource(ctx, projectID, createWarehouseSourceRequest)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating warehouse source: %s", err))
+		return
+	}
+
+	if res == nil {
+		resp.Diagnostics.AddError("Client Error", "Warehouse source not found after creating it")
+		return
+	}
+
+	updateWarehouseSourceModel(&data, res)
+
+	tflog.Trace(ctx, "created warehouse source", map[string]interface{}{"warehouse_source_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *warehouseSourceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data warehouseSourceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	sourceID, err := posthog.WarehouseSourceIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid warehouse source ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetWarehouseSource(ctx, projectID, sourceID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting warehouse source %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	updateWarehouseSourceModel(&data, res)
+
+	tflog.Trace(ctx, "read warehouse source", map[string]interface{}{"warehouse_source_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *warehouseSourceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state warehouseSourceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	sourceID, err := posthog.WarehouseSourceIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid warehouse source ID", err.Error())
+		return
+	}
+
+	current, err := r.client.GetWarehouseSource(ctx, projectID, sourceID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting warehouse source %s: %s", sourceID, err))
+		return
+	}
+
+	if current == nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Warehouse source %s not found", sourceID))
+		return
+	}
+
+	// Check all the tables before changing anything, so that a typo doesn't
+	// leave the source half updated
+
+	var available []string
+	for _, s := range current.Schemas {
+		available = append(available, s.Name)
+	}
+
+	sort.Strings(available)
+
+	wanted := map[string]warehouseSourceSchema{}
+	for _, s := range data.Schemas {
+		name := s.Name.ValueString()
+		if !slices.Contains(available, name) {
+			resp.Diagnostics.AddAttributeError(path.Root("schemas"), "Unknown table", fmt.Sprintf("Warehouse source %s has no table %s, available tables: %s.", sourceID, name, strings.Join(available, ", ")))
+		}
+
+		wanted[name] = s
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Settings.Equal(state.Settings) || !data.SecretSettings.Equal(state.SecretSettings) {
+		jobInputs, diags := warehouseSourceJobInputs(ctx, data)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if err := r.client.UpdateWarehouseSourceJobInputs(ctx, projectID, sourceID, jobInputs); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating settings of warehouse source %s: %s", sourceID, err))
+			return
+		}
+	}
+
+	// Update the tables to sync
+
+	for _, s := range current.Schemas {
+		update := posthog.WarehouseSourceSchema{ID: s.ID, Name: s.Name, SyncType: s.SyncType, IncrementalField: s.IncrementalField, IncrementalFieldType: s.IncrementalFieldType, SyncFrequency: s.SyncFrequency}
+
+		if w, ok := wanted[s.Name]; ok {
+			update = warehouseSourceSchemaFromModel(w, data.SyncFrequency.ValueString())
+			update.ID = s.ID
+		} else if !s.ShouldSync {
+			continue
+		}
+
+		if sameWarehouseSourceSchema(update, s) {
+			continue
+		}
+
+		if err := r.client.UpdateWarehouseSourceSchema(ctx, projectID, update); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating table %s of warehouse source %s: %s", s.Name, sourceID, err))
+			return
+		}
+	}
+
+	res, err := r.client.GetWarehouseSource(ctx, projectID, sourceID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting warehouse source %s: %s", sourceID, err))
+		return
+	}
+
+	if res == nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Warehouse source %s not found", sourceID))
+		return
+	}
+
+	updateWarehouseSourceModel(&data, res)
+
+	tflog.Trace(ctx, "updated warehouse source", map[string]interface{}{"warehouse_source_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *warehouseSourceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data warehouseSourceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	sourceID, err := posthog.WarehouseSourceIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid warehouse source ID", err.Error())
+		return
+	}
+
+	err = r.client.DeleteWarehouseSource(ctx, projectID, sourceID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting warehouse source %s: %s", data.ID, err))
+		return
+	}
+}
+
+func (r *warehouseSourceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, sourceID, err := parseImportID(req.ID, "warehouse source", posthog.WarehouseSourceIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), sourceID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
@@ -0,0 +1,320 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+var _ resource.Resource = &warehouseTableResource{}
+var _ resource.ResourceWithImportState = &warehouseTableResource{}
+
+func newWarehouseTableResource() resource.Resource {
+	return &warehouseTableResource{}
+}
+
+type warehouseTableResource struct {
+	client *posthog.Client
+}
+
+type warehouseTableResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	ProjectID    types.String `tfsdk:"project_id"`
+	Name         types.String `tfsdk:"name"`
+	Format       types.String `tfsdk:"format"`
+	URLPattern   types.String `tfsdk:"url_pattern"`
+	AccessKey    types.String `tfsdk:"access_key"`
+	AccessSecret types.String `tfsdk:"access_secret"`
+	Columns      types.List   `tfsdk:"columns"`
+}
+
+func (r *warehouseTableResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_warehouse_table"
+}
+
+func (r *warehouseTableResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Data Warehouse Table, reading files from S3 compatible object storage.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the table",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the table",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the table in queries",
+				Required:            true,
+				Validators: []validator.String{
+					hogQLIdentifierValidator(),
+				},
+			},
+			"format": schema.StringAttribute{
+				MarkdownDescription: "Format of the files: `CSV`, `CSVWithNames` (CSV with a header row), `Parquet`, `JSONEachRow`, `Delta` or `DeltaS3Wrapper`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(posthog.WarehouseTableFormatCSV),
+						string(posthog.WarehouseTableFormatCSVWithNames),
+						string(posthog.WarehouseTableFormatParquet),
+						string(posthog.WarehouseTableFormatJSONEachRow),
+						string(posthog.WarehouseTableFormatDelta),
+						string(posthog.WarehouseTableFormatDeltaS3Wrapper),
+					),
+				},
+			},
+			"url_pattern": schema.StringAttribute{
+				MarkdownDescription: "URL of the files, with `*` matching any characters (eg. `https://bucket.s3.amazonaws.com/exports/*.parquet`)",
+				Required:            true,
+			},
+			"access_key": schema.StringAttribute{
+				MarkdownDescription: "Access key ID used to read the files. PostHog never returns this value, changes made outside of Terraform are not detected.",
+				Required:            true,
+			},
+			"access_secret": schema.StringAttribute{
+				MarkdownDescription: "Secret access key used to read the files. PostHog never returns this value, changes made outside of Terraform are not detected.",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"columns": warehouseColumnsSchema("Columns of the table, detected by PostHog from the files"),
+		},
+	}
+}
+
+func (r *warehouseTableResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func updateWarehouseTableModel(ctx context.Context, model *warehouseTableResourceModel, apiTable *posthog.WarehouseTable) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(apiTable.ID.String())
+	model.Name = types.StringValue(apiTable.Name)
+	model.Format = types.StringValue(string(apiTable.Format))
+	model.URLPattern = types.StringValue(apiTable.URLPattern)
+	model.Columns, diags = warehouseColumnsToModel(ctx, apiTable.Columns)
+
+	// The credentials are kept from the state, as they are not returned by
+	// the API
+	if apiTable.Credential != nil {
+		model.AccessKey = keptSecret(model.AccessKey, apiTable.Credential.AccessKey)
+		model.AccessSecret = keptSecret(model.AccessSecret, apiTable.Credential.AccessSecret)
+	}
+
+	return diags
+}
+
+func warehouseTableCredentialFromModel(data warehouseTableResourceModel) posthog.WarehouseTableCredential {
+	return posthog.WarehouseTableCredential{
+		AccessKey:    data.AccessKey.ValueString(),
+		AccessSecret: data.AccessSecret.ValueString(),
+	}
+}
+
+func (r *warehouseTableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data warehouseTableResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createWarehouseTableRequest := posthog.CreateWarehouseTableRequest{
+		Name:       data.Name.ValueString(),
+		Format:     posthog.WarehouseTableFormat(data.Format.ValueString()),
+		URLPattern: data.URLPattern.ValueString(),
+		Credential: warehouseTableCredentialFromModel(data),
+	}
+
+	// Create the table
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	res, err := r.client.CreateWarehouseTable(ctx, projectID, createWarehouseTableRequest)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating warehouse table: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(updateWarehouseTableModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created warehouse table", map[string]interface{}{"warehouse_table_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *warehouseTableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data warehouseTableResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	tableID, err := posthog.WarehouseTableIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid warehouse table ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetWarehouseTable(ctx, projectID, tableID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting warehouse table %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(updateWarehouseTableModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read warehouse table", map[string]interface{}{"warehouse_table_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *warehouseTableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data warehouseTableResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	credential := warehouseTableCredentialFromModel(data)
+
+	table := posthog.WarehouseTable{
+		Name:       data.Name.ValueString(),
+		Format:     posthog.WarehouseTableFormat(data.Format.ValueString()),
+		URLPattern: data.URLPattern.ValueString(),
+		Credential: &credential,
+	}
+
+	table.ID, err = posthog.WarehouseTableIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid warehouse table ID", err.Error())
+		return
+	}
+
+	res, err := r.client.UpdateWarehouseTable(ctx, projectID, table)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating warehouse table %s: %s", table.ID, err))
+		return
+	}
+
+	resp.Diagnostics.Append(updateWarehouseTableModel(ctx, &data, res)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated warehouse table", map[string]interface{}{"warehouse_table_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *warehouseTableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data warehouseTableResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	tableID, err := posthog.WarehouseTableIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid warehouse table ID", err.Error())
+		return
+	}
+
+	err = r.client.DeleteWarehouseTable(ctx, projectID, tableID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting warehouse table %s: %s", data.ID, err))
+		return
+	}
+}
+
+func (r *warehouseTableResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, tableID, err := parseImportID(req.ID, "warehouse table", posthog.WarehouseTableIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), tableID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
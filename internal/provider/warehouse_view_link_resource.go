@@ -0,0 +1,336 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/abustany/terraform-provider-posthog/internal/posthog"
+)
+
+var _ resource.Resource = &warehouseViewLinkResource{}
+var _ resource.ResourceWithImportState = &warehouseViewLinkResource{}
+
+func newWarehouseViewLinkResource() resource.Resource {
+	return &warehouseViewLinkResource{}
+}
+
+type warehouseViewLinkResource struct {
+	client *posthog.Client
+}
+
+type warehouseViewLinkResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	ProjectID        types.String `tfsdk:"project_id"`
+	SourceTableName  types.String `tfsdk:"source_table_name"`
+	SourceTableKey   types.String `tfsdk:"source_table_key"`
+	JoiningTableName types.String `tfsdk:"joining_table_name"`
+	JoiningTableKey  types.String `tfsdk:"joining_table_key"`
+	FieldName        types.String `tfsdk:"field_name"`
+}
+
+func (r *warehouseViewLinkResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_warehouse_view_link"
+}
+
+func (r *warehouseViewLinkResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Posthog Data Warehouse View Link, a join between two tables of the data warehouse (for example a warehouse table and the `persons` table). The tables and keys are validated by PostHog before saving the link.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the view link",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project of the view link",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_table_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the table the joined rows are added to (eg. `persons`, or the name of a `posthog_warehouse_table`)",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"source_table_key": schema.StringAttribute{
+				MarkdownDescription: "HogQL expression evaluated on the source table, matched against `joining_table_key` (eg. `properties.email`)",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"joining_table_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the joined table",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"joining_table_key": schema.StringAttribute{
+				MarkdownDescription: "HogQL expression evaluated on the joined table, matched against `source_table_key` (eg. `email`)",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"field_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the field of the source table holding the joined rows (eg. `stripe_customer`, queried as `persons.stripe_customer.id`)",
+				Required:            true,
+				Validators: []validator.String{
+					hogQLIdentifierValidator(),
+				},
+			},
+		},
+	}
+}
+
+func (r *warehouseViewLinkResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*posthog.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *posthog.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// validateWarehouseViewLink checks that both tables exist and that the keys
+// are valid expressions on them, so that a typo doesn't create a link that
+// breaks all the queries on the source table.
+func validateWarehouseViewLink(ctx context.Context, client *posthog.Client, projectID posthog.ProjectID, data warehouseViewLinkResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	sides := []struct {
+		table   string
+		key     string
+		keyPath path.Path
+	}{
+		{data.SourceTableName.ValueString(), data.SourceTableKey.ValueString(), path.Root("source_table_key")},
+		{data.JoiningTableName.ValueString(), data.JoiningTableKey.ValueString(), path.Root("joining_table_key")},
+	}
+
+	for _, side := range sides {
+		queryErrors, err := client.ValidateHogQLQuery(ctx, projectID, fmt.Sprintf("SELECT %s FROM %s", side.key, side.table))
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Error validating view link: %s", err))
+			return diags
+		}
+
+		for _, e := range queryErrors {
+			diags.AddAttributeError(side.keyPath, "Invalid view link", fmt.Sprintf("Key %s on table %s: %s", side.key, side.table, e.Message))
+		}
+	}
+
+	return diags
+}
+
+func warehouseViewLinkFromModel(data warehouseViewLinkResourceModel) posthog.CreateWarehouseViewLinkRequest {
+	return posthog.CreateWarehouseViewLinkRequest{
+		SourceTableName:  data.SourceTableName.ValueString(),
+		SourceTableKey:   data.SourceTableKey.ValueString(),
+		JoiningTableName: data.JoiningTableName.ValueString(),
+		JoiningTableKey:  data.JoiningTableKey.ValueString(),
+		FieldName:        data.FieldName.ValueString(),
+	}
+}
+
+func updateWarehouseViewLinkModel(model *warehouseViewLinkResourceModel, apiViewLink *posthog.WarehouseViewLink) {
+	model.ID = types.StringValue(apiViewLink.ID.String())
+	model.SourceTableName = types.StringValue(apiViewLink.SourceTableName)
+	model.SourceTableKey = types.StringValue(apiViewLink.SourceTableKey)
+	model.JoiningTableName = types.StringValue(apiViewLink.JoiningTableName)
+	model.JoiningTableKey = types.StringValue(apiViewLink.JoiningTableKey)
+	model.FieldName = types.StringValue(apiViewLink.FieldName)
+}
+
+func (r *warehouseViewLinkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data warehouseViewLinkResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(validateWarehouseViewLink(ctx, r.client, projectID, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create the view link
+
+	res, err := r.client.CreateWarehouseViewLink(ctx, projectID, warehouseViewLinkFromModel(data))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating view link: %s", err))
+		return
+	}
+
+	updateWarehouseViewLinkModel(&data, res)
+
+	tflog.Trace(ctx, "created view link", map[string]interface{}{"view_link_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *warehouseViewLinkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data warehouseViewLinkResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	viewLinkID, err := posthog.WarehouseViewLinkIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid view link ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetWarehouseViewLink(ctx, projectID, viewLinkID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error getting view link %s: %s", data.ID, err))
+		return
+	}
+
+	if res == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	updateWarehouseViewLinkModel(&data, res)
+
+	tflog.Trace(ctx, "read view link", map[string]interface{}{"view_link_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *warehouseViewLinkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data warehouseViewLinkResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	l := warehouseViewLinkFromModel(data)
+	viewLink := posthog.WarehouseViewLink{
+		SourceTableName:  l.SourceTableName,
+		SourceTableKey:   l.SourceTableKey,
+		JoiningTableName: l.JoiningTableName,
+		JoiningTableKey:  l.JoiningTableKey,
+		FieldName:        l.FieldName,
+	}
+
+	viewLink.ID, err = posthog.WarehouseViewLinkIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid view link ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(validateWarehouseViewLink(ctx, r.client, projectID, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, err := r.client.UpdateWarehouseViewLink(ctx, projectID, viewLink)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating view link %s: %s", viewLink.ID, err))
+		return
+	}
+
+	updateWarehouseViewLinkModel(&data, res)
+
+	tflog.Trace(ctx, "updated view link", map[string]interface{}{"view_link_id": res.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *warehouseViewLinkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data warehouseViewLinkResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := posthog.ProjectIDFromString(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project ID", err.Error())
+		return
+	}
+
+	viewLinkID, err := posthog.WarehouseViewLinkIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid view link ID", err.Error())
+		return
+	}
+
+	err = r.client.DeleteWarehouseViewLink(ctx, projectID, viewLinkID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting view link %s: %s", data.ID, err))
+		return
+	}
+}
+
+func (r *warehouseViewLinkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, viewLinkID, err := parseImportID(req.ID, "view link", posthog.WarehouseViewLinkIDFromString)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), viewLinkID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID.String())...)
+}
@@ -1,7 +1,11 @@
 // Package typeutil provides additional helpers to convert between Go and Terraform types.
 package typeutil
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 func IsStringValueUnset(v types.String) bool {
 	return v.IsNull() || v.IsUnknown() || v.ValueString() == ""
@@ -14,3 +18,28 @@ func NullableStringValue(s string) types.String {
 
 	return types.StringValue(s)
 }
+
+// NullableStringPointerValue is like NullableStringValue, converting nil to
+// null as well.
+func NullableStringPointerValue(s *string) types.String {
+	if s == nil {
+		return types.StringNull()
+	}
+
+	return NullableStringValue(*s)
+}
+
+// TimeValue returns t formatted as RFC 3339, or prior if it refers to the
+// same instant (APIs usually return times in UTC, while the configuration
+// might use another timezone). A nil time is converted to null.
+func TimeValue(prior types.String, t *time.Time) types.String {
+	if t == nil {
+		return types.StringNull()
+	}
+
+	if priorTime, err := time.Parse(time.RFC3339, prior.ValueString()); err == nil && priorTime.Equal(*t) {
+		return prior
+	}
+
+	return types.StringValue(t.Format(time.RFC3339))
+}